@@ -1,5 +1,7 @@
 package v1alpha1
 
+import "encoding/json"
+
 type ElasticsearchRefSpec struct {
 	// Name is the Elasticsearch name object
 	// If empty, it use Adresses and secretName to connect on external elasticsearch (not managed by ECK)
@@ -22,3 +24,49 @@ func (h ElasticsearchRefSpec) GetElasticsearchRef() ElasticsearchRefSpec {
 func (h ElasticsearchRefSpec) IsManagedByECK() bool {
 	return h.Name != ""
 }
+
+type KibanaRefSpec struct {
+	// Name is the Kibana name object
+	// If empty, it use Adresses and secretName to connect on external Kibana (not managed by ECK)
+	Name string `json:"name,omitempty"`
+
+	// Addresses is the list of Kibana addresses
+	Addresses []string `json:"addresses,omitempty"`
+
+	// SecretName is the secret that contain the setting to connect on Kibana that is not managed by ECK.
+	// It need to contain only one entry. The user is the key, and the password is the data
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// GetKibanaRef permit to Get infos to connect on Kibana
+func (h KibanaRefSpec) GetKibanaRef() KibanaRefSpec {
+	return h
+}
+
+// IsManagedByECK permit to know if Kibana is managed by ECK
+func (h KibanaRefSpec) IsManagedByECK() bool {
+	return h.Name != ""
+}
+
+// jsonToMap permit to convert JSON string field to map
+// It return nil map when the field is empty
+func jsonToMap(raw string) (map[string]any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	data := make(map[string]any)
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+const (
+	// DeletionPolicyRetain keep the object on Elasticsearch when the resource is deleted
+	DeletionPolicyRetain = "Retain"
+
+	// DeletionPolicyDelete remove the object on Elasticsearch when the resource is deleted
+	DeletionPolicyDelete = "Delete"
+)
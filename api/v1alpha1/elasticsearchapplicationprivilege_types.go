@@ -0,0 +1,116 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ElasticsearchApplicationPrivilegeSpec defines the desired state of ElasticsearchApplicationPrivilege
+// +k8s:openapi-gen=true
+type ElasticsearchApplicationPrivilegeSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Application is the name of the application
+	Application string `json:"application"`
+
+	// Name is the name of the privilege
+	// If empty, it use the resource name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Actions is the list of actions granted by the privilege
+	Actions []string `json:"actions"`
+
+	// Metadata is optional meta-data
+	// JSON string
+	// +optional
+	Metadata string `json:"metadata,omitempty"`
+}
+
+// ElasticsearchApplicationPrivilegeStatus defines the observed state of ElasticsearchApplicationPrivilege
+type ElasticsearchApplicationPrivilegeStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchApplicationPrivilege is the Schema for the elasticsearchapplicationprivileges API
+type ElasticsearchApplicationPrivilege struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchApplicationPrivilegeSpec   `json:"spec,omitempty"`
+	Status ElasticsearchApplicationPrivilegeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchApplicationPrivilegeList contains a list of ElasticsearchApplicationPrivilege
+type ElasticsearchApplicationPrivilegeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchApplicationPrivilege `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchApplicationPrivilege{}, &ElasticsearchApplicationPrivilegeList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchApplicationPrivilege) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchApplicationPrivilege) GetStatus() any {
+	return h.Status
+}
+
+// GetPrivilegeName permit to get the privilege name
+func (h *ElasticsearchApplicationPrivilege) GetPrivilegeName() string {
+	if h.Spec.Name != "" {
+		return h.Spec.Name
+	}
+
+	return h.Name
+}
+
+// ToApplicationPrivilege permit to convert current spec to application privilege spec
+func (h *ElasticsearchApplicationPrivilege) ToApplicationPrivilege() (privilege *elasticsearchhandler.XPackSecurityApplicationPrivilege, err error) {
+	privilege = &elasticsearchhandler.XPackSecurityApplicationPrivilege{
+		Actions: h.Spec.Actions,
+	}
+
+	if privilege.Metadata, err = jsonToMap(h.Spec.Metadata); err != nil {
+		return nil, errors.Wrap(err, "Error when decode metadata")
+	}
+
+	return privilege, nil
+}
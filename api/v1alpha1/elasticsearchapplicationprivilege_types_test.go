@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchApplicationPrivilegeCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchApplicationPrivilege
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchApplicationPrivilege{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchApplicationPrivilegeSpec{
+			Application: "myapp",
+			Actions:     []string{"data:read/*"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchApplicationPrivilege{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchApplicationPrivilegeGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchApplicationPrivilege{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchApplicationPrivilegeSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchApplicationPrivilegeGetStatus() {
+	status := ElasticsearchApplicationPrivilegeStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchApplicationPrivilege{
+		Spec:   ElasticsearchApplicationPrivilegeSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchApplicationPrivilegeToApplicationPrivilege() {
+	test := &ElasticsearchApplicationPrivilege{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "read",
+		},
+		Spec: ElasticsearchApplicationPrivilegeSpec{
+			Application: "myapp",
+			Actions:     []string{"data:read/*", "action:login"},
+			Metadata:    `{"description": "Read access to myapp"}`,
+		},
+	}
+
+	privilege, err := test.ToApplicationPrivilege()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"data:read/*", "action:login"}, privilege.Actions)
+	assert.Equal(t.T(), "Read access to myapp", privilege.Metadata["description"])
+
+	// Name
+	assert.Equal(t.T(), "read", test.GetPrivilegeName())
+	test.Spec.Name = "read-all"
+	assert.Equal(t.T(), "read-all", test.GetPrivilegeName())
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+
+	olivere "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,15 +36,393 @@ type ElasticsearchILMSpec struct {
 	ElasticsearchRefSpec `json:"elasticsearchRef"`
 
 	// Policy is the raw policy on JSON
+	// You need to set policy or phases
+	// +optional
+	Policy string `json:"policy,omitempty"`
+
+	// Phases is the typed policy phases
+	// You need to set policy or phases
+	// +optional
+	Phases *ElasticsearchILMPhases `json:"phases,omitempty"`
+
+	// AutoRetry permit to retry automatically the failed step of indices stuck on ERROR step
+	// +optional
+	AutoRetry *ElasticsearchILMAutoRetry `json:"autoRetry,omitempty"`
+}
+
+// ElasticsearchILMAutoRetry is the automatic retry policy of failed steps
+type ElasticsearchILMAutoRetry struct {
+
+	// MaxAttempts is the max number of retries of the failed step of each index
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts int64 `json:"maxAttempts,omitempty"`
+
+	// Backoff is the minimal duration between 2 retries of the same index
+	// +kubebuilder:default="10m"
+	// +optional
+	Backoff metav1.Duration `json:"backoff,omitempty"`
+}
+
+// ElasticsearchILMPhases is the phases of ILM policy
+type ElasticsearchILMPhases struct {
+
+	// Hot is the hot phase
+	// +optional
+	Hot *ElasticsearchILMHotPhase `json:"hot,omitempty"`
+
+	// Warm is the warm phase
+	// +optional
+	Warm *ElasticsearchILMWarmPhase `json:"warm,omitempty"`
+
+	// Cold is the cold phase
+	// +optional
+	Cold *ElasticsearchILMColdPhase `json:"cold,omitempty"`
+
+	// Frozen is the frozen phase
+	// +optional
+	Frozen *ElasticsearchILMFrozenPhase `json:"frozen,omitempty"`
+
+	// Delete is the delete phase
+	// +optional
+	Delete *ElasticsearchILMDeletePhase `json:"delete,omitempty"`
+}
+
+// ElasticsearchILMHotPhase is the hot phase
+type ElasticsearchILMHotPhase struct {
+
+	// MinAge is the minimum age of index to enter on this phase
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	// +optional
+	MinAge string `json:"min_age,omitempty"`
+
+	// Actions is the actions to run on this phase
+	Actions ElasticsearchILMHotActions `json:"actions"`
+}
+
+// ElasticsearchILMHotActions is the actions allowed on hot phase
+type ElasticsearchILMHotActions struct {
+
+	// +optional
+	Rollover *ElasticsearchILMRolloverAction `json:"rollover,omitempty"`
+
+	// +optional
+	Forcemerge *ElasticsearchILMForcemergeAction `json:"forcemerge,omitempty"`
+
+	// +optional
+	Shrink *ElasticsearchILMShrinkAction `json:"shrink,omitempty"`
+
+	// +optional
+	Readonly *ElasticsearchILMEmptyAction `json:"readonly,omitempty"`
+
+	// +optional
+	SearchableSnapshot *ElasticsearchILMSearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+
+	// +optional
+	SetPriority *ElasticsearchILMSetPriorityAction `json:"set_priority,omitempty"`
+}
+
+// ElasticsearchILMWarmPhase is the warm phase
+type ElasticsearchILMWarmPhase struct {
+
+	// MinAge is the minimum age of index to enter on this phase
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	// +optional
+	MinAge string `json:"min_age,omitempty"`
+
+	// Actions is the actions to run on this phase
+	Actions ElasticsearchILMWarmActions `json:"actions"`
+}
+
+// ElasticsearchILMWarmActions is the actions allowed on warm phase
+type ElasticsearchILMWarmActions struct {
+
+	// +optional
+	Allocate *ElasticsearchILMAllocateAction `json:"allocate,omitempty"`
+
+	// +optional
+	Migrate *ElasticsearchILMMigrateAction `json:"migrate,omitempty"`
+
+	// +optional
+	Forcemerge *ElasticsearchILMForcemergeAction `json:"forcemerge,omitempty"`
+
+	// +optional
+	Shrink *ElasticsearchILMShrinkAction `json:"shrink,omitempty"`
+
+	// +optional
+	Readonly *ElasticsearchILMEmptyAction `json:"readonly,omitempty"`
+
+	// +optional
+	SetPriority *ElasticsearchILMSetPriorityAction `json:"set_priority,omitempty"`
+}
+
+// ElasticsearchILMColdPhase is the cold phase
+type ElasticsearchILMColdPhase struct {
+
+	// MinAge is the minimum age of index to enter on this phase
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	// +optional
+	MinAge string `json:"min_age,omitempty"`
+
+	// Actions is the actions to run on this phase
+	Actions ElasticsearchILMColdActions `json:"actions"`
+}
+
+// ElasticsearchILMColdActions is the actions allowed on cold phase
+type ElasticsearchILMColdActions struct {
+
+	// +optional
+	Allocate *ElasticsearchILMAllocateAction `json:"allocate,omitempty"`
+
+	// +optional
+	Migrate *ElasticsearchILMMigrateAction `json:"migrate,omitempty"`
+
+	// +optional
+	Readonly *ElasticsearchILMEmptyAction `json:"readonly,omitempty"`
+
+	// +optional
+	SearchableSnapshot *ElasticsearchILMSearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+
+	// +optional
+	SetPriority *ElasticsearchILMSetPriorityAction `json:"set_priority,omitempty"`
+}
+
+// ElasticsearchILMFrozenPhase is the frozen phase
+type ElasticsearchILMFrozenPhase struct {
+
+	// MinAge is the minimum age of index to enter on this phase
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	// +optional
+	MinAge string `json:"min_age,omitempty"`
+
+	// Actions is the actions to run on this phase
+	Actions ElasticsearchILMFrozenActions `json:"actions"`
+}
+
+// ElasticsearchILMFrozenActions is the actions allowed on frozen phase
+type ElasticsearchILMFrozenActions struct {
+
+	// SearchableSnapshot is required on frozen phase
+	SearchableSnapshot *ElasticsearchILMSearchableSnapshotAction `json:"searchable_snapshot"`
+}
+
+// ElasticsearchILMDeletePhase is the delete phase
+type ElasticsearchILMDeletePhase struct {
+
+	// MinAge is the minimum age of index to enter on this phase
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	// +optional
+	MinAge string `json:"min_age,omitempty"`
+
+	// Actions is the actions to run on this phase
+	Actions ElasticsearchILMDeleteActions `json:"actions"`
+}
+
+// ElasticsearchILMDeleteActions is the actions allowed on delete phase
+type ElasticsearchILMDeleteActions struct {
+
+	// +optional
+	WaitForSnapshot *ElasticsearchILMWaitForSnapshotAction `json:"wait_for_snapshot,omitempty"`
+
+	// +optional
+	Delete *ElasticsearchILMDeleteAction `json:"delete,omitempty"`
+}
+
+// ElasticsearchILMEmptyAction is an action without parameter
+type ElasticsearchILMEmptyAction struct{}
+
+// ElasticsearchILMRolloverAction roll over the index when one condition is met
+type ElasticsearchILMRolloverAction struct {
+
+	// MaxAge triggers rollover after the maximum elapsed time from index creation
+	// +optional
+	MaxAge string `json:"max_age,omitempty"`
+
+	// MaxDocs triggers rollover when the index reaches this number of documents
+	// +optional
+	MaxDocs *int64 `json:"max_docs,omitempty"`
+
+	// MaxSize triggers rollover when the index reaches this size
+	// +optional
+	MaxSize string `json:"max_size,omitempty"`
+
+	// MaxPrimaryShardSize triggers rollover when the largest primary shard reaches this size
+	// +optional
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+}
+
+// ElasticsearchILMForcemergeAction force merge the index
+type ElasticsearchILMForcemergeAction struct {
+
+	// MaxNumSegments is the number of segments to merge to
+	// +kubebuilder:validation:Minimum=1
+	MaxNumSegments int64 `json:"max_num_segments"`
+
+	// IndexCodec is the codec used to compress the document store
+	// +kubebuilder:validation:Enum=best_compression
+	// +optional
+	IndexCodec string `json:"index_codec,omitempty"`
+}
+
+// ElasticsearchILMShrinkAction shrink the index into a new index with fewer primary shards
+type ElasticsearchILMShrinkAction struct {
+
+	// NumberOfShards is the number of primary shards of shrunken index
+	// +optional
+	NumberOfShards *int64 `json:"number_of_shards,omitempty"`
+
+	// MaxPrimaryShardSize is the max primary shard size of shrunken index
+	// +optional
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+}
+
+// ElasticsearchILMAllocateAction update the index settings to change which nodes are allowed to host the index shards
+type ElasticsearchILMAllocateAction struct {
+
+	// NumberOfReplicas is the number of replicas to allocate to the index
+	// +optional
+	NumberOfReplicas *int64 `json:"number_of_replicas,omitempty"`
+
+	// TotalShardsPerNode is the maximum number of shards for the index on a single node
+	// +optional
+	TotalShardsPerNode *int64 `json:"total_shards_per_node,omitempty"`
+
+	// Include assigns the index to nodes that have at least one of the specified custom attributes
+	// +optional
+	Include map[string]string `json:"include,omitempty"`
+
+	// Exclude assigns the index to nodes that have none of the specified custom attributes
+	// +optional
+	Exclude map[string]string `json:"exclude,omitempty"`
+
+	// Require assigns the index to nodes that have all of the specified custom attributes
+	// +optional
+	Require map[string]string `json:"require,omitempty"`
+}
+
+// ElasticsearchILMMigrateAction move the index to the data tier that corresponds to the current phase
+type ElasticsearchILMMigrateAction struct {
+
+	// Enabled permit to disable the automatic migration
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ElasticsearchILMSearchableSnapshotAction take a snapshot of the managed index and mount it as searchable snapshot
+type ElasticsearchILMSearchableSnapshotAction struct {
+
+	// SnapshotRepository is the repository used to store the snapshot
+	SnapshotRepository string `json:"snapshot_repository"`
+
+	// ForceMergeIndex force merge the index to one segment before take the snapshot
+	// +optional
+	ForceMergeIndex *bool `json:"force_merge_index,omitempty"`
+}
+
+// ElasticsearchILMSetPriorityAction set the priority of the index to recover it after node restart
+type ElasticsearchILMSetPriorityAction struct {
+
+	// Priority is the priority of the index
+	// +kubebuilder:validation:Minimum=0
+	Priority int64 `json:"priority"`
+}
+
+// ElasticsearchILMWaitForSnapshotAction wait for the specified SLM policy to be executed before delete the index
+type ElasticsearchILMWaitForSnapshotAction struct {
+
+	// Policy is the SLM policy name
 	Policy string `json:"policy"`
 }
 
+// ElasticsearchILMDeleteAction permanently remove the index
+type ElasticsearchILMDeleteAction struct {
+
+	// DeleteSearchableSnapshot delete the searchable snapshot created in a previous phase
+	// +optional
+	DeleteSearchableSnapshot *bool `json:"delete_searchable_snapshot,omitempty"`
+}
+
 // ElasticsearchILMStatus defines the observed state of ElasticsearchILM
 type ElasticsearchILMStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// Phases is the number of indices on each phase
+	// +optional
+	Phases map[string]int64 `json:"phases,omitempty"`
+
+	// FailedIndices is the indices stuck on ERROR step
+	// +optional
+	FailedIndices []ElasticsearchILMFailedIndex `json:"failedIndices,omitempty"`
+
+	// InUseBy is the objects that use the policy
+	// +optional
+	InUseBy *ElasticsearchILMInUseBy `json:"inUseBy,omitempty"`
+
+	// RetriedIndices is the indices on which the operator retried the failed step
+	// +optional
+	RetriedIndices []ElasticsearchILMRetriedIndex `json:"retriedIndices,omitempty"`
+}
+
+// ElasticsearchILMRetriedIndex is an index on which the operator retried the failed step
+type ElasticsearchILMRetriedIndex struct {
+
+	// Index is the index name
+	Index string `json:"index"`
+
+	// FailedStep is the step retried
+	FailedStep string `json:"failedStep"`
+
+	// Attempts is the number of retries done by operator
+	Attempts int64 `json:"attempts"`
+
+	// LastRetryTime is the time of the last retry
+	LastRetryTime metav1.Time `json:"lastRetryTime"`
+}
+
+// ElasticsearchILMFailedIndex is an index stuck on ERROR step
+type ElasticsearchILMFailedIndex struct {
+
+	// Index is the index name
+	Index string `json:"index"`
+
+	// Phase is the current phase of index
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Action is the current action of index
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// FailedStep is the step that failed
+	// +optional
+	FailedStep string `json:"failedStep,omitempty"`
+
+	// Reason is the error returned by the failed step
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// RetryCount is the number of automatic retries done by ILM on the failed step
+	// +optional
+	RetryCount int64 `json:"retryCount,omitempty"`
+}
+
+// ElasticsearchILMInUseBy is the objects that use the policy
+type ElasticsearchILMInUseBy struct {
+
+	// Indices is the number of indices that use the policy
+	Indices int64 `json:"indices"`
+
+	// DataStreams is the data streams that use the policy
+	// +optional
+	DataStreams []string `json:"dataStreams,omitempty"`
+
+	// ComposableTemplates is the index templates that use the policy
+	// +optional
+	ComposableTemplates []string `json:"composableTemplates,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -77,3 +459,35 @@ func (h *ElasticsearchILM) GetObjectMeta() metav1.ObjectMeta {
 func (h *ElasticsearchILM) GetStatus() any {
 	return h.Status
 }
+
+// ToPolicy permit to convert current spec to ILM policy
+// It use the raw policy or the typed phases
+func (h *ElasticsearchILM) ToPolicy() (policy *olivere.XPackIlmGetLifecycleResponse, err error) {
+	if (h.Spec.Policy == "") == (h.Spec.Phases == nil) {
+		return nil, errors.New("You need to set policy or phases")
+	}
+
+	policy = &olivere.XPackIlmGetLifecycleResponse{}
+
+	if h.Spec.Policy != "" {
+		if err = json.Unmarshal([]byte(h.Spec.Policy), policy); err != nil {
+			return nil, errors.Wrap(err, "Error on policy format")
+		}
+		return policy, nil
+	}
+
+	// Convert typed phases to map to get the same format than raw policy
+	b, err := json.Marshal(h.Spec.Phases)
+	if err != nil {
+		return nil, err
+	}
+	phases := make(map[string]any)
+	if err = json.Unmarshal(b, &phases); err != nil {
+		return nil, err
+	}
+	policy.Policy = map[string]any{
+		"phases": phases,
+	}
+
+	return policy, nil
+}
@@ -79,3 +79,82 @@ func (t *V1alpha1TestSuite) TestElasticsearchILMGetStatus() {
 
 	assert.Equal(t.T(), status, test.GetStatus())
 }
+
+func (t *V1alpha1TestSuite) TestElasticsearchILMToPolicy() {
+	priority := int64(100)
+	test := &ElasticsearchILM{
+		Spec: ElasticsearchILMSpec{},
+	}
+
+	// When policy and phases are not set
+	_, err := test.ToPolicy()
+	assert.Error(t.T(), err)
+
+	// When policy and phases are set
+	test.Spec.Policy = `{"policy": {"phases": {"delete": {"min_age": "31d", "actions": {"delete": {}}}}}}`
+	test.Spec.Phases = &ElasticsearchILMPhases{}
+	_, err = test.ToPolicy()
+	assert.Error(t.T(), err)
+
+	// When only raw policy is set
+	test.Spec.Phases = nil
+	expected := map[string]any{
+		"phases": map[string]any{
+			"delete": map[string]any{
+				"min_age": "31d",
+				"actions": map[string]any{
+					"delete": map[string]any{},
+				},
+			},
+		},
+	}
+	policy, err := test.ToPolicy()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), expected, policy.Policy)
+
+	// When only phases is set
+	test.Spec.Policy = ""
+	test.Spec.Phases = &ElasticsearchILMPhases{
+		Hot: &ElasticsearchILMHotPhase{
+			Actions: ElasticsearchILMHotActions{
+				Rollover: &ElasticsearchILMRolloverAction{
+					MaxAge:  "7d",
+					MaxSize: "5gb",
+				},
+				SetPriority: &ElasticsearchILMSetPriorityAction{
+					Priority: priority,
+				},
+			},
+		},
+		Delete: &ElasticsearchILMDeletePhase{
+			MinAge: "31d",
+			Actions: ElasticsearchILMDeleteActions{
+				Delete: &ElasticsearchILMDeleteAction{},
+			},
+		},
+	}
+	expected = map[string]any{
+		"phases": map[string]any{
+			"hot": map[string]any{
+				"actions": map[string]any{
+					"rollover": map[string]any{
+						"max_age":  "7d",
+						"max_size": "5gb",
+					},
+					"set_priority": map[string]any{
+						"priority": float64(100),
+					},
+				},
+			},
+			"delete": map[string]any{
+				"min_age": "31d",
+				"actions": map[string]any{
+					"delete": map[string]any{},
+				},
+			},
+		},
+	}
+	policy, err = test.ToPolicy()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), expected, policy.Policy)
+}
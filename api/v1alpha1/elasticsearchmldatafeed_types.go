@@ -0,0 +1,195 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// MLDatafeedStarted is the desired state when datafeed must run
+	MLDatafeedStarted = "started"
+
+	// MLDatafeedStopped is the desired state when datafeed must not run
+	MLDatafeedStopped = "stopped"
+)
+
+// ElasticsearchMLDatafeedSpec defines the desired state of ElasticsearchMLDatafeed
+// +k8s:openapi-gen=true
+type ElasticsearchMLDatafeedSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// JobID is the anomaly detection job that receive the data
+	// It can't be updated after the datafeed is created
+	JobID string `json:"job_id"`
+
+	// Indices is the list of indices to retrieve the data
+	Indices []string `json:"indices"`
+
+	// Query is the Elasticsearch query to retrieve the data
+	// JSON string
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// Frequency is the interval at which scheduled queries are made while the datafeed runs in real time
+	// +optional
+	Frequency string `json:"frequency,omitempty"`
+
+	// QueryDelay is the number of seconds behind real time that data is queried
+	// +optional
+	QueryDelay string `json:"query_delay,omitempty"`
+
+	// ScrollSize is the size parameter that is used in Elasticsearch searches
+	// +optional
+	ScrollSize *int64 `json:"scroll_size,omitempty"`
+
+	// ChunkingConfig specifies how data searches are split into time chunks
+	// JSON string
+	// +optional
+	ChunkingConfig string `json:"chunking_config,omitempty"`
+
+	// DelayedDataCheckConfig specifies whether the datafeed checks for missing data
+	// JSON string
+	// +optional
+	DelayedDataCheckConfig string `json:"delayed_data_check_config,omitempty"`
+
+	// RuntimeMappings is the search-time runtime fields
+	// JSON string
+	// +optional
+	RuntimeMappings string `json:"runtime_mappings,omitempty"`
+
+	// ScriptFields specifies scripts that evaluate custom expressions and returns script fields
+	// JSON string
+	// +optional
+	ScriptFields string `json:"script_fields,omitempty"`
+
+	// Aggregations is the aggregations to use
+	// JSON string
+	// +optional
+	Aggregations string `json:"aggregations,omitempty"`
+
+	// MaxEmptySearches is the number of empty searches before the datafeed is stopped
+	// +optional
+	MaxEmptySearches *int64 `json:"max_empty_searches,omitempty"`
+
+	// IndicesOptions specifies index expansion options that are used during search
+	// JSON string
+	// +optional
+	IndicesOptions string `json:"indices_options,omitempty"`
+
+	// State is the desired state of datafeed
+	// +kubebuilder:validation:Enum=started;stopped
+	// +kubebuilder:default=started
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// ElasticsearchMLDatafeedStatus defines the observed state of ElasticsearchMLDatafeed
+type ElasticsearchMLDatafeedStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// State is the current state of datafeed
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchMLDatafeed is the Schema for the elasticsearchmldatafeeds API
+type ElasticsearchMLDatafeed struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchMLDatafeedSpec   `json:"spec,omitempty"`
+	Status ElasticsearchMLDatafeedStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchMLDatafeedList contains a list of ElasticsearchMLDatafeed
+type ElasticsearchMLDatafeedList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchMLDatafeed `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchMLDatafeed{}, &ElasticsearchMLDatafeedList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchMLDatafeed) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchMLDatafeed) GetStatus() any {
+	return h.Status
+}
+
+// IsStarted permit to know if datafeed must run
+func (h *ElasticsearchMLDatafeed) IsStarted() bool {
+	return h.Spec.State != MLDatafeedStopped
+}
+
+// ToDatafeed permit to convert current spec to datafeed spec
+func (h *ElasticsearchMLDatafeed) ToDatafeed() (datafeed *elasticsearchhandler.MLDatafeed, err error) {
+	datafeed = &elasticsearchhandler.MLDatafeed{
+		JobID:            h.Spec.JobID,
+		Indices:          h.Spec.Indices,
+		Frequency:        h.Spec.Frequency,
+		QueryDelay:       h.Spec.QueryDelay,
+		ScrollSize:       h.Spec.ScrollSize,
+		MaxEmptySearches: h.Spec.MaxEmptySearches,
+	}
+
+	if datafeed.Query, err = jsonToMap(h.Spec.Query); err != nil {
+		return nil, errors.Wrap(err, "Error when decode query")
+	}
+	if datafeed.ChunkingConfig, err = jsonToMap(h.Spec.ChunkingConfig); err != nil {
+		return nil, errors.Wrap(err, "Error when decode chunking_config")
+	}
+	if datafeed.DelayedDataCheckConfig, err = jsonToMap(h.Spec.DelayedDataCheckConfig); err != nil {
+		return nil, errors.Wrap(err, "Error when decode delayed_data_check_config")
+	}
+	if datafeed.RuntimeMappings, err = jsonToMap(h.Spec.RuntimeMappings); err != nil {
+		return nil, errors.Wrap(err, "Error when decode runtime_mappings")
+	}
+	if datafeed.ScriptFields, err = jsonToMap(h.Spec.ScriptFields); err != nil {
+		return nil, errors.Wrap(err, "Error when decode script_fields")
+	}
+	if datafeed.Aggregations, err = jsonToMap(h.Spec.Aggregations); err != nil {
+		return nil, errors.Wrap(err, "Error when decode aggregations")
+	}
+	if datafeed.IndicesOptions, err = jsonToMap(h.Spec.IndicesOptions); err != nil {
+		return nil, errors.Wrap(err, "Error when decode indices_options")
+	}
+
+	return datafeed, nil
+}
@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLDatafeedCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchMLDatafeed
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchMLDatafeed{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchMLDatafeedSpec{
+			JobID:   "test",
+			Indices: []string{"test"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchMLDatafeed{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLDatafeedGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchMLDatafeed{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchMLDatafeedSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLDatafeedGetStatus() {
+	status := ElasticsearchMLDatafeedStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchMLDatafeed{
+		Spec:   ElasticsearchMLDatafeedSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLDatafeedToDatafeed() {
+	test := &ElasticsearchMLDatafeed{
+		Spec: ElasticsearchMLDatafeedSpec{
+			JobID:   "test",
+			Indices: []string{"server-metrics"},
+			Query:   `{"match_all": {}}`,
+		},
+	}
+
+	datafeed, err := test.ToDatafeed()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "test", datafeed.JobID)
+	assert.Equal(t.T(), []string{"server-metrics"}, datafeed.Indices)
+	assert.NotNil(t.T(), datafeed.Query["match_all"])
+	assert.Nil(t.T(), datafeed.Aggregations)
+
+	// When JSON is invalid
+	test.Spec.Aggregations = "fake"
+	_, err = test.ToDatafeed()
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// MLJobOpened is the desired state when job must be opened
+	MLJobOpened = "opened"
+
+	// MLJobClosed is the desired state when job must be closed
+	MLJobClosed = "closed"
+)
+
+// ElasticsearchMLJobSpec defines the desired state of ElasticsearchMLJob
+// +k8s:openapi-gen=true
+type ElasticsearchMLJobSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Description is the description of the job
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Groups is the list of job groups
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// AnalysisConfig specifies how to analyze the data
+	// JSON string
+	// It can't be updated after the job is created
+	AnalysisConfig string `json:"analysis_config"`
+
+	// AnalysisLimits specifies runtime limits for the job
+	// JSON string
+	// It can be updated only when the job is closed
+	// +optional
+	AnalysisLimits string `json:"analysis_limits,omitempty"`
+
+	// DataDescription describes the format of the input data
+	// JSON string
+	// It can't be updated after the job is created
+	DataDescription string `json:"data_description"`
+
+	// ModelPlotConfig permit to store model information along with the results
+	// JSON string
+	// +optional
+	ModelPlotConfig string `json:"model_plot_config,omitempty"`
+
+	// ModelSnapshotRetentionDays is the time in days that model snapshots are retained
+	// +optional
+	ModelSnapshotRetentionDays *int64 `json:"model_snapshot_retention_days,omitempty"`
+
+	// DailyModelSnapshotRetentionAfterDays is the period after which only the first snapshot per day is retained
+	// +optional
+	DailyModelSnapshotRetentionAfterDays *int64 `json:"daily_model_snapshot_retention_after_days,omitempty"`
+
+	// ResultsRetentionDays is the period of time in days that results are retained
+	// +optional
+	ResultsRetentionDays *int64 `json:"results_retention_days,omitempty"`
+
+	// RenormalizationWindowDays is the period over which adjustments to the score are applied
+	// +optional
+	RenormalizationWindowDays *int64 `json:"renormalization_window_days,omitempty"`
+
+	// BackgroundPersistInterval is the time between each periodic persistence of the model
+	// +optional
+	BackgroundPersistInterval string `json:"background_persist_interval,omitempty"`
+
+	// ResultsIndexName is the name of the index where store the results
+	// It can't be updated after the job is created
+	// +optional
+	ResultsIndexName string `json:"results_index_name,omitempty"`
+
+	// CustomSettings is the custom metadata about the job
+	// JSON string
+	// +optional
+	CustomSettings string `json:"custom_settings,omitempty"`
+
+	// AllowLazyOpen permit to open the job when there is insufficient ML node capacity
+	// +optional
+	AllowLazyOpen bool `json:"allow_lazy_open,omitempty"`
+
+	// State is the desired state of job
+	// +kubebuilder:validation:Enum=opened;closed
+	// +kubebuilder:default=opened
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// ElasticsearchMLJobStatus defines the observed state of ElasticsearchMLJob
+type ElasticsearchMLJobStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// State is the current state of job
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ModelMemoryStatus is the status of the mathematical models
+	// +optional
+	ModelMemoryStatus string `json:"modelMemoryStatus,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchMLJob is the Schema for the elasticsearchmljobs API
+type ElasticsearchMLJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchMLJobSpec   `json:"spec,omitempty"`
+	Status ElasticsearchMLJobStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchMLJobList contains a list of ElasticsearchMLJob
+type ElasticsearchMLJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchMLJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchMLJob{}, &ElasticsearchMLJobList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchMLJob) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchMLJob) GetStatus() any {
+	return h.Status
+}
+
+// IsOpened permit to know if job must be opened
+func (h *ElasticsearchMLJob) IsOpened() bool {
+	return h.Spec.State != MLJobClosed
+}
+
+// ToJob permit to convert current spec to anomaly detection job spec
+func (h *ElasticsearchMLJob) ToJob() (job *elasticsearchhandler.MLJob, err error) {
+	job = &elasticsearchhandler.MLJob{
+		Description:                          h.Spec.Description,
+		Groups:                               h.Spec.Groups,
+		ModelSnapshotRetentionDays:           h.Spec.ModelSnapshotRetentionDays,
+		DailyModelSnapshotRetentionAfterDays: h.Spec.DailyModelSnapshotRetentionAfterDays,
+		ResultsRetentionDays:                 h.Spec.ResultsRetentionDays,
+		RenormalizationWindowDays:            h.Spec.RenormalizationWindowDays,
+		BackgroundPersistInterval:            h.Spec.BackgroundPersistInterval,
+		ResultsIndexName:                     h.Spec.ResultsIndexName,
+		AllowLazyOpen:                        h.Spec.AllowLazyOpen,
+	}
+
+	if job.AnalysisConfig, err = jsonToMap(h.Spec.AnalysisConfig); err != nil {
+		return nil, errors.Wrap(err, "Error when decode analysis_config")
+	}
+	if job.AnalysisLimits, err = jsonToMap(h.Spec.AnalysisLimits); err != nil {
+		return nil, errors.Wrap(err, "Error when decode analysis_limits")
+	}
+	if job.DataDescription, err = jsonToMap(h.Spec.DataDescription); err != nil {
+		return nil, errors.Wrap(err, "Error when decode data_description")
+	}
+	if job.ModelPlotConfig, err = jsonToMap(h.Spec.ModelPlotConfig); err != nil {
+		return nil, errors.Wrap(err, "Error when decode model_plot_config")
+	}
+	if job.CustomSettings, err = jsonToMap(h.Spec.CustomSettings); err != nil {
+		return nil, errors.Wrap(err, "Error when decode custom_settings")
+	}
+
+	return job, nil
+}
@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLJobCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchMLJob
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchMLJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchMLJobSpec{
+			AnalysisConfig:  "fake",
+			DataDescription: "fake",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchMLJob{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLJobGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchMLJob{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchMLJobSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLJobGetStatus() {
+	status := ElasticsearchMLJobStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchMLJob{
+		Spec:   ElasticsearchMLJobSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMLJobToJob() {
+	retention := int64(10)
+	test := &ElasticsearchMLJob{
+		Spec: ElasticsearchMLJobSpec{
+			Description:                "test",
+			AnalysisConfig:             `{"bucket_span": "10m", "detectors": [{"function": "sum", "field_name": "total"}]}`,
+			DataDescription:            `{"time_field": "timestamp"}`,
+			ModelSnapshotRetentionDays: &retention,
+		},
+	}
+
+	job, err := test.ToJob()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "test", job.Description)
+	assert.Equal(t.T(), "10m", job.AnalysisConfig["bucket_span"])
+	assert.Equal(t.T(), "timestamp", job.DataDescription["time_field"])
+	assert.Equal(t.T(), int64(10), *job.ModelSnapshotRetentionDays)
+	assert.Nil(t.T(), job.AnalysisLimits)
+
+	// When JSON is invalid
+	test.Spec.AnalysisLimits = "fake"
+	_, err = test.ToJob()
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// MountedIndexMounting is the state when the shards of mounted index are not yet all started
+	MountedIndexMounting = "Mounting"
+
+	// MountedIndexMounted is the state when all shards of mounted index are started
+	MountedIndexMounted = "Mounted"
+)
+
+// ElasticsearchMountedIndexSpec defines the desired state of ElasticsearchMountedIndex
+// +k8s:openapi-gen=true
+type ElasticsearchMountedIndexSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Repository is the snapshot repository name
+	Repository string `json:"repository"`
+
+	// Snapshot is the snapshot name that contain the index
+	Snapshot string `json:"snapshot"`
+
+	// Index is the name of the index in the snapshot to mount
+	Index string `json:"index"`
+
+	// RenamedIndex is the name of the mounted index
+	// If empty, it use the index name
+	// +optional
+	RenamedIndex string `json:"renamed_index,omitempty"`
+
+	// Storage is the mount option for the searchable snapshot index
+	// +kubebuilder:validation:Enum=full_copy;shared_cache
+	// +kubebuilder:default=full_copy
+	// +optional
+	Storage string `json:"storage,omitempty"`
+
+	// IndexSettings is the settings to add to the mounted index
+	// JSON string
+	// +optional
+	IndexSettings string `json:"index_settings,omitempty"`
+
+	// IgnoreIndexSettings is the index settings to remove from the mounted index
+	// +optional
+	IgnoreIndexSettings []string `json:"ignore_index_settings,omitempty"`
+
+	// DeletionPolicy permit to unmount the index when the resource is deleted
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// ElasticsearchMountedIndexStatus defines the observed state of ElasticsearchMountedIndex
+type ElasticsearchMountedIndexStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// State is the current mount state of index
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ShardsTotal is the number of shards to recover
+	// +optional
+	ShardsTotal int64 `json:"shardsTotal,omitempty"`
+
+	// ShardsDone is the number of shards recovered
+	// +optional
+	ShardsDone int64 `json:"shardsDone,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchMountedIndex is the Schema for the elasticsearchmountedindices API
+type ElasticsearchMountedIndex struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchMountedIndexSpec   `json:"spec,omitempty"`
+	Status ElasticsearchMountedIndexStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchMountedIndexList contains a list of ElasticsearchMountedIndex
+type ElasticsearchMountedIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchMountedIndex `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchMountedIndex{}, &ElasticsearchMountedIndexList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchMountedIndex) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchMountedIndex) GetStatus() any {
+	return h.Status
+}
+
+// GetIndexName permit to get the name of mounted index
+func (h *ElasticsearchMountedIndex) GetIndexName() string {
+	if h.Spec.RenamedIndex != "" {
+		return h.Spec.RenamedIndex
+	}
+
+	return h.Spec.Index
+}
+
+// GetStorage permit to get the storage option
+func (h *ElasticsearchMountedIndex) GetStorage() string {
+	if h.Spec.Storage != "" {
+		return h.Spec.Storage
+	}
+
+	return "full_copy"
+}
+
+// IsDeletedOnRemove permit to know if the index must be unmounted when the resource is deleted
+func (h *ElasticsearchMountedIndex) IsDeletedOnRemove() bool {
+	return h.Spec.DeletionPolicy != DeletionPolicyRetain
+}
+
+// ToMountedIndex permit to convert current spec to mounted index spec
+func (h *ElasticsearchMountedIndex) ToMountedIndex() (mount *elasticsearchhandler.MountedIndex, err error) {
+	mount = &elasticsearchhandler.MountedIndex{
+		Index:               h.Spec.Index,
+		RenamedIndex:        h.Spec.RenamedIndex,
+		IgnoreIndexSettings: h.Spec.IgnoreIndexSettings,
+	}
+
+	if mount.IndexSettings, err = jsonToMap(h.Spec.IndexSettings); err != nil {
+		return nil, errors.Wrap(err, "Error when decode index_settings")
+	}
+
+	return mount, nil
+}
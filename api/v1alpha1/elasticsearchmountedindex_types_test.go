@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchMountedIndexCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchMountedIndex
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchMountedIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchMountedIndexSpec{
+			Repository: "backup",
+			Snapshot:   "test",
+			Index:      "test",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchMountedIndex{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMountedIndexGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchMountedIndex{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchMountedIndexSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMountedIndexGetStatus() {
+	status := ElasticsearchMountedIndexStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchMountedIndex{
+		Spec:   ElasticsearchMountedIndexSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMountedIndexToMountedIndex() {
+	test := &ElasticsearchMountedIndex{
+		Spec: ElasticsearchMountedIndexSpec{
+			Repository:    "backup",
+			Snapshot:      "test",
+			Index:         "test",
+			RenamedIndex:  "mounted-test",
+			IndexSettings: `{"index.number_of_replicas": 0}`,
+		},
+	}
+
+	mount, err := test.ToMountedIndex()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "test", mount.Index)
+	assert.Equal(t.T(), "mounted-test", mount.RenamedIndex)
+	assert.Equal(t.T(), float64(0), mount.IndexSettings["index.number_of_replicas"])
+
+	// When index settings is not valid JSON
+	test.Spec.IndexSettings = "fake"
+	_, err = test.ToMountedIndex()
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchMountedIndexGetters() {
+	test := &ElasticsearchMountedIndex{
+		Spec: ElasticsearchMountedIndexSpec{
+			Index: "test",
+		},
+	}
+	assert.Equal(t.T(), "test", test.GetIndexName())
+	assert.Equal(t.T(), "full_copy", test.GetStorage())
+	assert.True(t.T(), test.IsDeletedOnRemove())
+
+	test.Spec.RenamedIndex = "mounted-test"
+	test.Spec.Storage = "shared_cache"
+	test.Spec.DeletionPolicy = DeletionPolicyRetain
+	assert.Equal(t.T(), "mounted-test", test.GetIndexName())
+	assert.Equal(t.T(), "shared_cache", test.GetStorage())
+	assert.False(t.T(), test.IsDeletedOnRemove())
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// RestoreRunning is the state when the restore is in progress
+	RestoreRunning = "Running"
+
+	// RestoreCompleted is the state when all restored shards are started
+	RestoreCompleted = "Completed"
+
+	// RestoreFailed is the state when some restored shards can't be allocated
+	RestoreFailed = "Failed"
+)
+
+// ElasticsearchRestoreSpec defines the desired state of ElasticsearchRestore
+// +k8s:openapi-gen=true
+type ElasticsearchRestoreSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Repository is the snapshot repository name
+	Repository string `json:"repository"`
+
+	// Snapshot is the snapshot name to restore
+	Snapshot string `json:"snapshot"`
+
+	// Indices is the list of data streams and indices to restore
+	// If empty, it restore all regular data streams and indices in the snapshot
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IgnoreUnavailable permit to ignore data streams and indices that are missing in the snapshot
+	// +optional
+	IgnoreUnavailable bool `json:"ignore_unavailable,omitempty"`
+
+	// IncludeGlobalState permit to restore the cluster state
+	// +optional
+	IncludeGlobalState *bool `json:"include_global_state,omitempty"`
+
+	// FeatureStates is the list of feature states to restore
+	// +optional
+	FeatureStates []string `json:"feature_states,omitempty"`
+
+	// IncludeAliases permit to restore aliases for restored data streams and indices
+	// +optional
+	IncludeAliases *bool `json:"include_aliases,omitempty"`
+
+	// Partial permit to restore indices with unavailable shards
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+
+	// RenamePattern is the regex used to rename restored data streams and indices
+	// +optional
+	RenamePattern string `json:"rename_pattern,omitempty"`
+
+	// RenameReplacement is the rename replacement string
+	// +optional
+	RenameReplacement string `json:"rename_replacement,omitempty"`
+
+	// IndexSettings is the index settings to add or change in restored indices
+	// JSON string
+	// +optional
+	IndexSettings string `json:"index_settings,omitempty"`
+
+	// IgnoreIndexSettings is the index settings to not restore from the snapshot
+	// +optional
+	IgnoreIndexSettings []string `json:"ignore_index_settings,omitempty"`
+}
+
+// ElasticsearchRestoreStatus defines the observed state of ElasticsearchRestore
+type ElasticsearchRestoreStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// ObservedGeneration is the generation for which the restore has been run
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// State is the current state of restore
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Indices is the list of restored indices
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// ShardsTotal is the number of shards to restore
+	// +optional
+	ShardsTotal int64 `json:"shardsTotal,omitempty"`
+
+	// ShardsDone is the number of shards successfully restored
+	// +optional
+	ShardsDone int64 `json:"shardsDone,omitempty"`
+
+	// ShardsFailed is the number of shards that failed to be restored
+	// +optional
+	ShardsFailed int64 `json:"shardsFailed,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchRestore is the Schema for the elasticsearchrestores API
+type ElasticsearchRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchRestoreSpec   `json:"spec,omitempty"`
+	Status ElasticsearchRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchRestoreList contains a list of ElasticsearchRestore
+type ElasticsearchRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchRestore{}, &ElasticsearchRestoreList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchRestore) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchRestore) GetStatus() any {
+	return h.Status
+}
+
+// IsAlreadyRun permit to know if the restore has already been run for the current generation
+func (h *ElasticsearchRestore) IsAlreadyRun() bool {
+	return h.Status.ObservedGeneration == h.Generation
+}
+
+// ToRestore permit to convert current spec to restore spec
+func (h *ElasticsearchRestore) ToRestore() (restore *elasticsearchhandler.Restore, err error) {
+	restore = &elasticsearchhandler.Restore{
+		Indices:             h.Spec.Indices,
+		IgnoreUnavailable:   h.Spec.IgnoreUnavailable,
+		IncludeGlobalState:  h.Spec.IncludeGlobalState,
+		FeatureStates:       h.Spec.FeatureStates,
+		IncludeAliases:      h.Spec.IncludeAliases,
+		Partial:             h.Spec.Partial,
+		RenamePattern:       h.Spec.RenamePattern,
+		RenameReplacement:   h.Spec.RenameReplacement,
+		IgnoreIndexSettings: h.Spec.IgnoreIndexSettings,
+	}
+
+	if restore.IndexSettings, err = jsonToMap(h.Spec.IndexSettings); err != nil {
+		return nil, errors.Wrap(err, "Error when decode index_settings")
+	}
+
+	return restore, nil
+}
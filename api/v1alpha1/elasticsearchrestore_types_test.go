@@ -0,0 +1,117 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchRestoreCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchRestore
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchRestoreSpec{
+			Repository: "backup",
+			Snapshot:   "test",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchRestore{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchRestoreGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchRestore{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchRestoreSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchRestoreGetStatus() {
+	status := ElasticsearchRestoreStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchRestore{
+		Spec:   ElasticsearchRestoreSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchRestoreToRestore() {
+	test := &ElasticsearchRestore{
+		Spec: ElasticsearchRestoreSpec{
+			Repository:        "backup",
+			Snapshot:          "test",
+			Indices:           []string{"test"},
+			RenamePattern:     "(.+)",
+			RenameReplacement: "restored-$1",
+			IndexSettings:     `{"index.number_of_replicas": 0}`,
+		},
+	}
+
+	restore, err := test.ToRestore()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"test"}, restore.Indices)
+	assert.Equal(t.T(), "restored-$1", restore.RenameReplacement)
+	assert.Equal(t.T(), float64(0), restore.IndexSettings["index.number_of_replicas"])
+
+	// When index settings is not valid JSON
+	test.Spec.IndexSettings = "fake"
+	_, err = test.ToRestore()
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchRestoreIsAlreadyRun() {
+	test := &ElasticsearchRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 1,
+		},
+	}
+	assert.False(t.T(), test.IsAlreadyRun())
+
+	test.Status.ObservedGeneration = 1
+	assert.True(t.T(), test.IsAlreadyRun())
+
+	test.Generation = 2
+	assert.False(t.T(), test.IsAlreadyRun())
+}
@@ -93,6 +93,52 @@ type ElasticsearchSLMStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// LastSuccess is the last snapshot successfully taken by the policy
+	// +optional
+	LastSuccess *ElasticsearchSLMInvocation `json:"lastSuccess,omitempty"`
+
+	// LastFailure is the last snapshot failed by the policy
+	// +optional
+	LastFailure *ElasticsearchSLMInvocation `json:"lastFailure,omitempty"`
+
+	// NextExecution is the time of the next snapshot
+	// +optional
+	NextExecution *metav1.Time `json:"nextExecution,omitempty"`
+
+	// Stats is the snapshot stats of the policy
+	// +optional
+	Stats *ElasticsearchSLMStats `json:"stats,omitempty"`
+}
+
+// ElasticsearchSLMInvocation is a snapshot taken by the policy
+type ElasticsearchSLMInvocation struct {
+
+	// SnapshotName is the snapshot name
+	SnapshotName string `json:"snapshotName"`
+
+	// Time is the time of the snapshot
+	Time metav1.Time `json:"time"`
+
+	// Reason is the error when snapshot failed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ElasticsearchSLMStats is the snapshot stats of the policy
+type ElasticsearchSLMStats struct {
+
+	// SnapshotsTaken is the number of snapshots taken
+	SnapshotsTaken int64 `json:"snapshotsTaken"`
+
+	// SnapshotsFailed is the number of snapshots failed
+	SnapshotsFailed int64 `json:"snapshotsFailed"`
+
+	// SnapshotsDeleted is the number of snapshots deleted by retention
+	SnapshotsDeleted int64 `json:"snapshotsDeleted"`
+
+	// SnapshotDeletionFailures is the number of snapshots failed to be deleted by retention
+	SnapshotDeletionFailures int64 `json:"snapshotDeletionFailures"`
 }
 
 //+kubebuilder:object:root=true
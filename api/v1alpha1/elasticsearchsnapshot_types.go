@@ -0,0 +1,159 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ElasticsearchSnapshotSpec defines the desired state of ElasticsearchSnapshot
+// +k8s:openapi-gen=true
+type ElasticsearchSnapshotSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Repository is the snapshot repository name
+	Repository string `json:"repository"`
+
+	// Indices is the list of data streams and indices to include in the snapshot
+	// If empty, it include all data streams and indices
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IgnoreUnavailable permit to ignore data streams and indices that are missing or closed
+	// +optional
+	IgnoreUnavailable bool `json:"ignore_unavailable,omitempty"`
+
+	// IncludeGlobalState permit to include the cluster state in the snapshot
+	// +optional
+	IncludeGlobalState *bool `json:"include_global_state,omitempty"`
+
+	// FeatureStates is the list of feature states to include in the snapshot
+	// +optional
+	FeatureStates []string `json:"feature_states,omitempty"`
+
+	// Partial permit to create partial snapshot of indices with unavailable shards
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+
+	// Metadata attaches arbitrary metadata to the snapshot
+	// JSON string
+	// +optional
+	Metadata string `json:"metadata,omitempty"`
+
+	// DeletionPolicy permit to delete the snapshot when the resource is deleted
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// ElasticsearchSnapshotStatus defines the observed state of ElasticsearchSnapshot
+type ElasticsearchSnapshotStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// State is the current state of snapshot
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ShardsTotal is the number of shards to snapshot
+	// +optional
+	ShardsTotal int64 `json:"shardsTotal,omitempty"`
+
+	// ShardsDone is the number of shards successfully snapshoted
+	// +optional
+	ShardsDone int64 `json:"shardsDone,omitempty"`
+
+	// ShardsFailed is the number of shards that failed to be snapshoted
+	// +optional
+	ShardsFailed int64 `json:"shardsFailed,omitempty"`
+
+	// SizeInBytes is the total size of the snapshot
+	// +optional
+	SizeInBytes int64 `json:"sizeInBytes,omitempty"`
+
+	// Failures is the list of shard failures
+	// +optional
+	Failures []string `json:"failures,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchSnapshot is the Schema for the elasticsearchsnapshots API
+type ElasticsearchSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchSnapshotSpec   `json:"spec,omitempty"`
+	Status ElasticsearchSnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchSnapshotList contains a list of ElasticsearchSnapshot
+type ElasticsearchSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchSnapshot{}, &ElasticsearchSnapshotList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchSnapshot) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchSnapshot) GetStatus() any {
+	return h.Status
+}
+
+// IsDeletedOnRemove permit to know if the snapshot must be deleted when the resource is deleted
+func (h *ElasticsearchSnapshot) IsDeletedOnRemove() bool {
+	return h.Spec.DeletionPolicy == DeletionPolicyDelete
+}
+
+// ToSnapshot permit to convert current spec to snapshot spec
+func (h *ElasticsearchSnapshot) ToSnapshot() (snapshot *elasticsearchhandler.Snapshot, err error) {
+	snapshot = &elasticsearchhandler.Snapshot{
+		Indices:            h.Spec.Indices,
+		IgnoreUnavailable:  h.Spec.IgnoreUnavailable,
+		IncludeGlobalState: h.Spec.IncludeGlobalState,
+		FeatureStates:      h.Spec.FeatureStates,
+		Partial:            h.Spec.Partial,
+	}
+
+	if snapshot.Metadata, err = jsonToMap(h.Spec.Metadata); err != nil {
+		return nil, errors.Wrap(err, "Error when decode metadata")
+	}
+
+	return snapshot, nil
+}
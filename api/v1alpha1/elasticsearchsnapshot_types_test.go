@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchSnapshot
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchSnapshotSpec{
+			Repository: "backup",
+			Indices:    []string{"test"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchSnapshot{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchSnapshot{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchSnapshotSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotGetStatus() {
+	status := ElasticsearchSnapshotStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchSnapshot{
+		Spec:   ElasticsearchSnapshotSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotToSnapshot() {
+	includeGlobalState := false
+	test := &ElasticsearchSnapshot{
+		Spec: ElasticsearchSnapshotSpec{
+			Repository:         "backup",
+			Indices:            []string{"test"},
+			IncludeGlobalState: &includeGlobalState,
+			Metadata:           `{"taken_by": "operator"}`,
+		},
+	}
+
+	snapshot, err := test.ToSnapshot()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"test"}, snapshot.Indices)
+	assert.False(t.T(), *snapshot.IncludeGlobalState)
+	assert.Equal(t.T(), "operator", snapshot.Metadata["taken_by"])
+
+	// When metadata is not valid JSON
+	test.Spec.Metadata = "fake"
+	_, err = test.ToSnapshot()
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotIsDeletedOnRemove() {
+	test := &ElasticsearchSnapshot{}
+	assert.False(t.T(), test.IsDeletedOnRemove())
+
+	test.Spec.DeletionPolicy = DeletionPolicyDelete
+	assert.True(t.T(), test.IsDeletedOnRemove())
+}
@@ -17,6 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	olivere "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -32,11 +38,201 @@ type ElasticsearchSnapshotRepositorySpec struct {
 	ElasticsearchRefSpec `json:"elasticsearchRef"`
 
 	// Type the Snapshot repository type
-	Type string `json:"type"`
+	// It's not needed when you use a typed repository
+	// +optional
+	Type string `json:"type,omitempty"`
 
 	// The config of snapshot repository
+	// JSON string
+	// It permit to set the settings not yet supported by typed repositories
 	// +optional
 	Settings string `json:"settings,omitempty"`
+
+	// Fs is the shared file system repository
+	// +optional
+	Fs *ElasticsearchSnapshotRepositoryFs `json:"fs,omitempty"`
+
+	// S3 is the AWS S3 repository
+	// +optional
+	S3 *ElasticsearchSnapshotRepositoryS3 `json:"s3,omitempty"`
+
+	// Gcs is the Google Cloud Storage repository
+	// +optional
+	Gcs *ElasticsearchSnapshotRepositoryGcs `json:"gcs,omitempty"`
+
+	// Azure is the Azure storage repository
+	// +optional
+	Azure *ElasticsearchSnapshotRepositoryAzure `json:"azure,omitempty"`
+
+	// URL is the read-only URL repository
+	// +optional
+	URL *ElasticsearchSnapshotRepositoryURL `json:"url,omitempty"`
+
+	// SourceOnly permit to create a source-only repository that delegate the storage to the repository
+	// +optional
+	SourceOnly bool `json:"sourceOnly,omitempty"`
+
+	// SecretSettings is the settings read from Secret keys, so they are not committed in plaintext
+	// They override the settings with the same name
+	// +optional
+	SecretSettings []ElasticsearchSnapshotRepositorySecretSetting `json:"secretSettings,omitempty"`
+
+	// Cleanup permit to remove periodically the data not referenced by existing snapshots
+	// +optional
+	Cleanup *ElasticsearchSnapshotRepositoryCleanup `json:"cleanup,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryCommonSettings is the settings available on all repository types
+type ElasticsearchSnapshotRepositoryCommonSettings struct {
+
+	// Compress permit to compress the metadata files
+	// +optional
+	Compress *bool `json:"compress,omitempty"`
+
+	// ChunkSize is the maximum size of files in snapshots, like `1gb`
+	// +kubebuilder:validation:Pattern=`^[0-9]+([kKmMgGtTpP]?[bB])?$`
+	// +optional
+	ChunkSize string `json:"chunk_size,omitempty"`
+
+	// MaxSnapshotBytesPerSec is the maximum snapshot creation rate per node, like `40mb`
+	// +kubebuilder:validation:Pattern=`^[0-9]+([kKmMgGtTpP]?[bB])?$`
+	// +optional
+	MaxSnapshotBytesPerSec string `json:"max_snapshot_bytes_per_sec,omitempty"`
+
+	// MaxRestoreBytesPerSec is the maximum snapshot restore rate per node
+	// +kubebuilder:validation:Pattern=`^[0-9]+([kKmMgGtTpP]?[bB])?$`
+	// +optional
+	MaxRestoreBytesPerSec string `json:"max_restore_bytes_per_sec,omitempty"`
+
+	// Readonly permit to register the repository as read-only
+	// +optional
+	Readonly *bool `json:"readonly,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryFs is the shared file system repository
+type ElasticsearchSnapshotRepositoryFs struct {
+	ElasticsearchSnapshotRepositoryCommonSettings `json:",inline"`
+
+	// Location is the path of the shared file system, it must be registered on `path.repo` setting
+	Location string `json:"location"`
+}
+
+// ElasticsearchSnapshotRepositoryS3 is the AWS S3 repository
+type ElasticsearchSnapshotRepositoryS3 struct {
+	ElasticsearchSnapshotRepositoryCommonSettings `json:",inline"`
+
+	// Bucket is the S3 bucket name
+	Bucket string `json:"bucket"`
+
+	// Client is the S3 client name defined on Elasticsearch settings
+	// +optional
+	Client string `json:"client,omitempty"`
+
+	// BasePath is the path of repository data on bucket
+	// +optional
+	BasePath string `json:"base_path,omitempty"`
+
+	// ServerSideEncryption permit to encrypt files on server side with AES256
+	// +optional
+	ServerSideEncryption *bool `json:"server_side_encryption,omitempty"`
+
+	// BufferSize is the size threshold to use multipart upload, like `100mb`
+	// +kubebuilder:validation:Pattern=`^[0-9]+([kKmMgGtTpP]?[bB])?$`
+	// +optional
+	BufferSize string `json:"buffer_size,omitempty"`
+
+	// CannedACL is the canned ACL to add on new buckets and objects
+	// +kubebuilder:validation:Enum=private;public-read;public-read-write;authenticated-read;log-delivery-write;bucket-owner-read;bucket-owner-full-control
+	// +optional
+	CannedACL string `json:"canned_acl,omitempty"`
+
+	// StorageClass is the storage class of new objects
+	// +kubebuilder:validation:Enum=standard;reduced_redundancy;standard_ia;onezone_ia;intelligent_tiering
+	// +optional
+	StorageClass string `json:"storage_class,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryGcs is the Google Cloud Storage repository
+type ElasticsearchSnapshotRepositoryGcs struct {
+	ElasticsearchSnapshotRepositoryCommonSettings `json:",inline"`
+
+	// Bucket is the GCS bucket name
+	Bucket string `json:"bucket"`
+
+	// Client is the GCS client name defined on Elasticsearch settings
+	// +optional
+	Client string `json:"client,omitempty"`
+
+	// BasePath is the path of repository data on bucket
+	// +optional
+	BasePath string `json:"base_path,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryAzure is the Azure storage repository
+type ElasticsearchSnapshotRepositoryAzure struct {
+	ElasticsearchSnapshotRepositoryCommonSettings `json:",inline"`
+
+	// Client is the Azure client name defined on Elasticsearch settings
+	// +optional
+	Client string `json:"client,omitempty"`
+
+	// Container is the Azure container name
+	// +optional
+	Container string `json:"container,omitempty"`
+
+	// BasePath is the path of repository data on container
+	// +optional
+	BasePath string `json:"base_path,omitempty"`
+
+	// LocationMode is the location used to read and write data
+	// +kubebuilder:validation:Enum=primary_only;secondary_only;primary_then_secondary;secondary_then_primary
+	// +optional
+	LocationMode string `json:"location_mode,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryURL is the read-only URL repository
+type ElasticsearchSnapshotRepositoryURL struct {
+	ElasticsearchSnapshotRepositoryCommonSettings `json:",inline"`
+
+	// URL is the location of repository, it must be registered on `repositories.url.allowed_urls` setting
+	URL string `json:"url"`
+
+	// HTTPMaxRetries is the maximum number of retries for http and https URLs
+	// +optional
+	HTTPMaxRetries *int64 `json:"http_max_retries,omitempty"`
+
+	// HTTPSocketTimeout is the maximum time for data transfers before timeout, like `50s`
+	// +optional
+	HTTPSocketTimeout string `json:"http_socket_timeout,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositorySecretSetting is a setting read from Secret key
+type ElasticsearchSnapshotRepositorySecretSetting struct {
+
+	// Name is the setting name, like `client` or `base_path`
+	Name string `json:"name"`
+
+	// SecretKeyRef is the Secret key that contain the setting value
+	SecretKeyRef ElasticsearchSnapshotRepositorySecretKeyRef `json:"secretKeyRef"`
+}
+
+// ElasticsearchSnapshotRepositorySecretKeyRef is the Secret key that contain the setting value
+type ElasticsearchSnapshotRepositorySecretKeyRef struct {
+
+	// Name is the Secret name
+	Name string `json:"name"`
+
+	// Key is the key of Secret
+	Key string `json:"key"`
+}
+
+// ElasticsearchSnapshotRepositoryCleanup is the cleanup schedule of repository
+type ElasticsearchSnapshotRepositoryCleanup struct {
+
+	// Interval is the duration between 2 cleanups
+	// +kubebuilder:default="24h"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
 }
 
 // ElasticsearchSnapshotRepositoryStatus defines the observed state of ElasticsearchSnapshotRepository
@@ -45,6 +241,23 @@ type ElasticsearchSnapshotRepositoryStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	Conditions []metav1.Condition `json:"conditions"`
+
+	// LastCleanup is the result of the last repository cleanup
+	// +optional
+	LastCleanup *ElasticsearchSnapshotRepositoryCleanupResult `json:"lastCleanup,omitempty"`
+}
+
+// ElasticsearchSnapshotRepositoryCleanupResult is the result of repository cleanup
+type ElasticsearchSnapshotRepositoryCleanupResult struct {
+
+	// Time is the time of the cleanup
+	Time metav1.Time `json:"time"`
+
+	// DeletedBytes is the number of bytes freed by the cleanup
+	DeletedBytes int64 `json:"deletedBytes"`
+
+	// DeletedBlobs is the number of binary large objects removed by the cleanup
+	DeletedBlobs int64 `json:"deletedBlobs"`
 }
 
 //+kubebuilder:object:root=true
@@ -81,3 +294,90 @@ func (h *ElasticsearchSnapshotRepository) GetObjectMeta() metav1.ObjectMeta {
 func (h *ElasticsearchSnapshotRepository) GetStatus() any {
 	return h.Status
 }
+
+// ToRepository permit to convert current spec to snapshot repository
+// The secret settings are provided from Secret
+// The typed repository settings are merged with the free-form settings, then with the secret settings
+func (h *ElasticsearchSnapshotRepository) ToRepository(secretSettings map[string]any) (repository *olivere.SnapshotRepositoryMetaData, err error) {
+	repositoryType, typedSettings, err := h.typedRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	// Elasticsearch return all settings as string, so the typed settings are converted to string
+	settings := map[string]any{}
+	if typedSettings != nil {
+		b, err := json.Marshal(typedSettings)
+		if err != nil {
+			return nil, err
+		}
+		typedSettingsMap := map[string]any{}
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.UseNumber()
+		if err = decoder.Decode(&typedSettingsMap); err != nil {
+			return nil, err
+		}
+		for name, value := range typedSettingsMap {
+			settings[name] = fmt.Sprint(value)
+		}
+	}
+
+	rawSettings, err := jsonToMap(h.Spec.Settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when decode repository setting")
+	}
+	for name, value := range rawSettings {
+		settings[name] = value
+	}
+	for name, value := range secretSettings {
+		settings[name] = value
+	}
+
+	if h.Spec.SourceOnly {
+		settings["delegate_type"] = repositoryType
+		repositoryType = "source"
+	}
+
+	return &olivere.SnapshotRepositoryMetaData{
+		Type:     repositoryType,
+		Settings: settings,
+	}, nil
+}
+
+// typedRepository permit to get the type and the settings of typed repository
+// It return the type field when no typed repository is set
+func (h *ElasticsearchSnapshotRepository) typedRepository() (repositoryType string, settings any, err error) {
+	nbTypedRepositories := 0
+	for _, isSet := range []bool{h.Spec.Fs != nil, h.Spec.S3 != nil, h.Spec.Gcs != nil, h.Spec.Azure != nil, h.Spec.URL != nil} {
+		if isSet {
+			nbTypedRepositories++
+		}
+	}
+	if nbTypedRepositories > 1 {
+		return "", nil, errors.New("You need to set only one typed repository")
+	}
+
+	switch {
+	case h.Spec.Fs != nil:
+		repositoryType, settings = "fs", h.Spec.Fs
+	case h.Spec.S3 != nil:
+		repositoryType, settings = "s3", h.Spec.S3
+	case h.Spec.Gcs != nil:
+		repositoryType, settings = "gcs", h.Spec.Gcs
+	case h.Spec.Azure != nil:
+		repositoryType, settings = "azure", h.Spec.Azure
+	case h.Spec.URL != nil:
+		repositoryType, settings = "url", h.Spec.URL
+	default:
+		if h.Spec.Type == "" {
+			return "", nil, errors.New("You need to set type or a typed repository")
+		}
+		return h.Spec.Type, nil, nil
+	}
+
+	if h.Spec.Type != "" && h.Spec.Type != repositoryType {
+		return "", nil, errors.Errorf("Type %s not match the typed repository %s", h.Spec.Type, repositoryType)
+	}
+
+	return repositoryType, settings, nil
+}
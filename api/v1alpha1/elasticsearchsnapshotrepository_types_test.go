@@ -82,3 +82,99 @@ func (t *V1alpha1TestSuite) TestElasticsearchSnapshotRepositoryGetStatus() {
 
 	assert.Equal(t.T(), status, test.GetStatus())
 }
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotRepositoryToRepository() {
+	test := &ElasticsearchSnapshotRepository{
+		Spec: ElasticsearchSnapshotRepositorySpec{
+			Type:     "s3",
+			Settings: `{"bucket": "backup", "client": "default"}`,
+		},
+	}
+
+	// Without secret settings
+	repository, err := test.ToRepository(nil)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "s3", repository.Type)
+	assert.Equal(t.T(), map[string]any{"bucket": "backup", "client": "default"}, repository.Settings)
+
+	// With secret settings
+	repository, err = test.ToRepository(map[string]any{"client": "secret-client", "base_path": "cluster1"})
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), map[string]any{"bucket": "backup", "client": "secret-client", "base_path": "cluster1"}, repository.Settings)
+
+	// Without settings
+	test.Spec.Settings = ""
+	repository, err = test.ToRepository(map[string]any{"client": "secret-client"})
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), map[string]any{"client": "secret-client"}, repository.Settings)
+
+	// When settings is not valid JSON
+	test.Spec.Settings = "{"
+	_, err = test.ToRepository(nil)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchSnapshotRepositoryToRepositoryTyped() {
+	compress := true
+	test := &ElasticsearchSnapshotRepository{
+		Spec: ElasticsearchSnapshotRepositorySpec{
+			S3: &ElasticsearchSnapshotRepositoryS3{
+				ElasticsearchSnapshotRepositoryCommonSettings: ElasticsearchSnapshotRepositoryCommonSettings{
+					Compress:  &compress,
+					ChunkSize: "1gb",
+				},
+				Bucket:   "backup",
+				BasePath: "cluster1",
+			},
+		},
+	}
+
+	// With typed repository
+	repository, err := test.ToRepository(nil)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "s3", repository.Type)
+	assert.Equal(t.T(), map[string]any{"bucket": "backup", "base_path": "cluster1", "compress": "true", "chunk_size": "1gb"}, repository.Settings)
+
+	// With free-form and secret settings
+	test.Spec.Settings = `{"path_style_access": true}`
+	repository, err = test.ToRepository(map[string]any{"client": "secret-client"})
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), map[string]any{"bucket": "backup", "base_path": "cluster1", "compress": "true", "chunk_size": "1gb", "path_style_access": true, "client": "secret-client"}, repository.Settings)
+
+	// With source only
+	test.Spec.Settings = ""
+	test.Spec.SourceOnly = true
+	repository, err = test.ToRepository(nil)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "source", repository.Type)
+	assert.Equal(t.T(), map[string]any{"bucket": "backup", "base_path": "cluster1", "compress": "true", "chunk_size": "1gb", "delegate_type": "s3"}, repository.Settings)
+
+	// When type not match the typed repository
+	test.Spec.SourceOnly = false
+	test.Spec.Type = "fs"
+	_, err = test.ToRepository(nil)
+	assert.Error(t.T(), err)
+
+	// When multiple typed repositories
+	test.Spec.Type = ""
+	test.Spec.Fs = &ElasticsearchSnapshotRepositoryFs{Location: "/backup"}
+	_, err = test.ToRepository(nil)
+	assert.Error(t.T(), err)
+
+	// When no type
+	test.Spec.Fs = nil
+	test.Spec.S3 = nil
+	_, err = test.ToRepository(nil)
+	assert.Error(t.T(), err)
+
+	// With number setting, it's converted to string like Elasticsearch return it
+	maxRetries := int64(5)
+	test.Spec.URL = &ElasticsearchSnapshotRepositoryURL{
+		URL:            "http://backup.domain.local",
+		HTTPMaxRetries: &maxRetries,
+	}
+	repository, err = test.ToRepository(nil)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "url", repository.Type)
+	assert.Equal(t.T(), map[string]any{"url": "http://backup.domain.local", "http_max_retries": "5"}, repository.Settings)
+}
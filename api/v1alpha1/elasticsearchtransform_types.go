@@ -0,0 +1,304 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// TransformStarted is the desired state when transform must run
+	TransformStarted = "started"
+
+	// TransformStopped is the desired state when transform must not run
+	TransformStopped = "stopped"
+)
+
+// ElasticsearchTransformSpec defines the desired state of ElasticsearchTransform
+// +k8s:openapi-gen=true
+type ElasticsearchTransformSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Description is the free text description of the transform
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Source is the source of the data
+	Source ElasticsearchTransformSource `json:"source"`
+
+	// Dest is the destination for the transform
+	Dest ElasticsearchTransformDest `json:"dest"`
+
+	// Pivot is the method for transforming the data
+	// JSON string
+	// Pivot and latest are mutually exclusive
+	// +optional
+	Pivot string `json:"pivot,omitempty"`
+
+	// Latest is the method for transforming the data
+	// Pivot and latest are mutually exclusive
+	// +optional
+	Latest *ElasticsearchTransformLatest `json:"latest,omitempty"`
+
+	// Sync permit to run transform continuously
+	// +optional
+	Sync *ElasticsearchTransformSync `json:"sync,omitempty"`
+
+	// Frequency is the interval between checks for changes in the source indices when the transform is running continuously
+	// +optional
+	Frequency string `json:"frequency,omitempty"`
+
+	// RetentionPolicy defines a retention policy for the transform
+	// +optional
+	RetentionPolicy *ElasticsearchTransformRetentionPolicy `json:"retention_policy,omitempty"`
+
+	// Settings defines optional transform settings
+	// JSON string
+	// +optional
+	Settings string `json:"settings,omitempty"`
+
+	// State is the desired state of transform
+	// +kubebuilder:validation:Enum=started;stopped
+	// +kubebuilder:default=started
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// ElasticsearchTransformSource is the source sub section
+type ElasticsearchTransformSource struct {
+
+	// Index is the list of source indices
+	Index []string `json:"index"`
+
+	// Query is the query clause that retrieves a subset of data from the source index
+	// JSON string
+	// +optional
+	Query string `json:"query,omitempty"`
+
+	// RuntimeMappings is the search-time runtime fields
+	// JSON string
+	// +optional
+	RuntimeMappings string `json:"runtime_mappings,omitempty"`
+}
+
+// ElasticsearchTransformDest is the dest sub section
+type ElasticsearchTransformDest struct {
+
+	// Index is the destination index
+	Index string `json:"index"`
+
+	// Pipeline is the unique identifier for an ingest pipeline
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// ElasticsearchTransformLatest is the latest sub section
+type ElasticsearchTransformLatest struct {
+
+	// UniqueKey is the list of fields used to group the data
+	UniqueKey []string `json:"unique_key"`
+
+	// Sort is the date field that is used to identify the latest documents
+	Sort string `json:"sort"`
+}
+
+// ElasticsearchTransformSync is the sync sub section
+type ElasticsearchTransformSync struct {
+
+	// Time is the time based synchronization
+	Time ElasticsearchTransformSyncTime `json:"time"`
+}
+
+// ElasticsearchTransformSyncTime is the time based synchronization
+type ElasticsearchTransformSyncTime struct {
+
+	// Field is the date field that is used to identify new documents in the source
+	Field string `json:"field"`
+
+	// Delay is the time delay between the current time and the latest input data time
+	// +optional
+	Delay string `json:"delay,omitempty"`
+}
+
+// ElasticsearchTransformRetentionPolicy is the retention policy sub section
+type ElasticsearchTransformRetentionPolicy struct {
+
+	// Time is the time based retention policy
+	Time ElasticsearchTransformRetentionPolicyTime `json:"time"`
+}
+
+// ElasticsearchTransformRetentionPolicyTime is the time based retention policy
+type ElasticsearchTransformRetentionPolicyTime struct {
+
+	// Field is the date field that is used to calculate the age of the document
+	Field string `json:"field"`
+
+	// MaxAge is the max age of document in destination index
+	MaxAge string `json:"max_age"`
+}
+
+// ElasticsearchTransformStatus defines the observed state of ElasticsearchTransform
+type ElasticsearchTransformStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// State is the current state of transform
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Health is the health status of transform
+	// +optional
+	Health string `json:"health,omitempty"`
+
+	// Checkpoint is the last completed checkpoint
+	// +optional
+	Checkpoint int64 `json:"checkpoint,omitempty"`
+
+	// DocumentsProcessed is the number of documents that have been processed from the source index
+	// +optional
+	DocumentsProcessed int64 `json:"documentsProcessed,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticsearchTransform is the Schema for the elasticsearchtransforms API
+type ElasticsearchTransform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticsearchTransformSpec   `json:"spec,omitempty"`
+	Status ElasticsearchTransformStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchTransformList contains a list of ElasticsearchTransform
+type ElasticsearchTransformList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchTransform `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchTransform{}, &ElasticsearchTransformList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *ElasticsearchTransform) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *ElasticsearchTransform) GetStatus() any {
+	return h.Status
+}
+
+// IsStarted permit to know if transform must run
+func (h *ElasticsearchTransform) IsStarted() bool {
+	return h.Spec.State != TransformStopped
+}
+
+// ToTransform permit to convert current spec to transform spec
+func (h *ElasticsearchTransform) ToTransform() (*elasticsearchhandler.Transform, error) {
+	if (h.Spec.Pivot == "") == (h.Spec.Latest == nil) {
+		return nil, errors.New("You need to set pivot or latest")
+	}
+
+	transform := &elasticsearchhandler.Transform{
+		Description: h.Spec.Description,
+		Source: &elasticsearchhandler.TransformSource{
+			Index: h.Spec.Source.Index,
+		},
+		Dest: &elasticsearchhandler.TransformDest{
+			Index:    h.Spec.Dest.Index,
+			Pipeline: h.Spec.Dest.Pipeline,
+		},
+		Frequency: h.Spec.Frequency,
+	}
+
+	if h.Spec.Source.Query != "" {
+		query := make(map[string]any)
+		if err := json.Unmarshal([]byte(h.Spec.Source.Query), &query); err != nil {
+			return nil, err
+		}
+		transform.Source.Query = query
+	}
+
+	if h.Spec.Source.RuntimeMappings != "" {
+		rm := make(map[string]any)
+		if err := json.Unmarshal([]byte(h.Spec.Source.RuntimeMappings), &rm); err != nil {
+			return nil, err
+		}
+		transform.Source.RuntimeMappings = rm
+	}
+
+	if h.Spec.Pivot != "" {
+		pivot := make(map[string]any)
+		if err := json.Unmarshal([]byte(h.Spec.Pivot), &pivot); err != nil {
+			return nil, err
+		}
+		transform.Pivot = pivot
+	}
+
+	if h.Spec.Latest != nil {
+		transform.Latest = &elasticsearchhandler.TransformLatest{
+			UniqueKey: h.Spec.Latest.UniqueKey,
+			Sort:      h.Spec.Latest.Sort,
+		}
+	}
+
+	if h.Spec.Sync != nil {
+		transform.Sync = &elasticsearchhandler.TransformSync{
+			Time: &elasticsearchhandler.TransformSyncTime{
+				Field: h.Spec.Sync.Time.Field,
+				Delay: h.Spec.Sync.Time.Delay,
+			},
+		}
+	}
+
+	if h.Spec.RetentionPolicy != nil {
+		transform.RetentionPolicy = &elasticsearchhandler.TransformRetentionPolicy{
+			Time: &elasticsearchhandler.TransformRetentionPolicyTime{
+				Field:  h.Spec.RetentionPolicy.Time.Field,
+				MaxAge: h.Spec.RetentionPolicy.Time.MaxAge,
+			},
+		}
+	}
+
+	if h.Spec.Settings != "" {
+		settings := make(map[string]any)
+		if err := json.Unmarshal([]byte(h.Spec.Settings), &settings); err != nil {
+			return nil, err
+		}
+		transform.Settings = settings
+	}
+
+	return transform, nil
+}
@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestElasticsearchTransformCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *ElasticsearchTransform
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &ElasticsearchTransform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: ElasticsearchTransformSpec{
+			Source: ElasticsearchTransformSource{
+				Index: []string{"test"},
+			},
+			Dest: ElasticsearchTransformDest{
+				Index: "test",
+			},
+			Pivot: "fake",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &ElasticsearchTransform{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchTransformGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &ElasticsearchTransform{
+		ObjectMeta: meta,
+		Spec:       ElasticsearchTransformSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchTransformGetStatus() {
+	status := ElasticsearchTransformStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &ElasticsearchTransform{
+		Spec:   ElasticsearchTransformSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestElasticsearchTransformToTransform() {
+	test := &ElasticsearchTransform{
+		Spec: ElasticsearchTransformSpec{
+			Source: ElasticsearchTransformSource{
+				Index: []string{"test"},
+			},
+			Dest: ElasticsearchTransformDest{
+				Index: "test-transform",
+			},
+		},
+	}
+
+	// When pivot and latest are not set
+	_, err := test.ToTransform()
+	assert.Error(t.T(), err)
+
+	// When pivot and latest are set
+	test.Spec.Pivot = `{"group_by": {"user": {"terms": {"field": "user"}}}}`
+	test.Spec.Latest = &ElasticsearchTransformLatest{
+		UniqueKey: []string{"user"},
+		Sort:      "@timestamp",
+	}
+	_, err = test.ToTransform()
+	assert.Error(t.T(), err)
+
+	// When only pivot is set
+	test.Spec.Latest = nil
+	test.Spec.Sync = &ElasticsearchTransformSync{
+		Time: ElasticsearchTransformSyncTime{
+			Field: "@timestamp",
+		},
+	}
+	transform, err := test.ToTransform()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "test-transform", transform.Dest.Index)
+	assert.Equal(t.T(), "@timestamp", transform.Sync.Time.Field)
+	assert.NotNil(t.T(), transform.Pivot["group_by"])
+	assert.Nil(t.T(), transform.Latest)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+const (
+	// FleetEnrollmentTokenKey is the key of Secret that store the enrollment token
+	// It's the environment variable read by Elastic Agent to enroll
+	FleetEnrollmentTokenKey = "FLEET_ENROLLMENT_TOKEN"
+)
+
+// FleetAgentPolicySpec defines the desired state of FleetAgentPolicy
+// +k8s:openapi-gen=true
+type FleetAgentPolicySpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// ID is the agent policy identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the display name of the agent policy
+	// If empty, it use the resource name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the data stream namespace used by the agent policy
+	// +kubebuilder:default=default
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Description is the description of the agent policy
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// MonitoringEnabled is the agent monitoring to collect, `logs` and / or `metrics`
+	// +kubebuilder:default={logs,metrics}
+	// +optional
+	MonitoringEnabled []string `json:"monitoring_enabled,omitempty"`
+
+	// DataOutputID is the output ID where to send data
+	// If empty, it use the default output
+	// +optional
+	DataOutputID string `json:"data_output_id,omitempty"`
+
+	// MonitoringOutputID is the output ID where to send monitoring data
+	// If empty, it use the default output
+	// +optional
+	MonitoringOutputID string `json:"monitoring_output_id,omitempty"`
+
+	// FleetServerHostID is the Fleet server host ID used by agents
+	// If empty, it use the default Fleet server host
+	// +optional
+	FleetServerHostID string `json:"fleet_server_host_id,omitempty"`
+
+	// InactivityTimeout is the time in seconds before an agent is set as inactive
+	// +optional
+	InactivityTimeout int64 `json:"inactivity_timeout,omitempty"`
+
+	// UnenrollTimeout is the time in seconds before an inactive agent is unenrolled
+	// +optional
+	UnenrollTimeout int64 `json:"unenroll_timeout,omitempty"`
+
+	// EnrollmentTokenSecret is the Secret name where to export the enrollment token
+	// If empty, it use `<resource name>-enrollment-token`
+	// +optional
+	EnrollmentTokenSecret string `json:"enrollmentTokenSecret,omitempty"`
+}
+
+// FleetAgentPolicyStatus defines the observed state of FleetAgentPolicy
+type FleetAgentPolicyStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// EnrollmentTokenID is the ID of enrollment token exported on Secret
+	// +optional
+	EnrollmentTokenID string `json:"enrollmentTokenID,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// FleetAgentPolicy is the Schema for the fleetagentpolicies API
+type FleetAgentPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FleetAgentPolicySpec   `json:"spec,omitempty"`
+	Status FleetAgentPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FleetAgentPolicyList contains a list of FleetAgentPolicy
+type FleetAgentPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FleetAgentPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FleetAgentPolicy{}, &FleetAgentPolicyList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *FleetAgentPolicy) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *FleetAgentPolicy) GetStatus() any {
+	return h.Status
+}
+
+// GetPolicyID permit to get the agent policy ID
+func (h *FleetAgentPolicy) GetPolicyID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// GetEnrollmentTokenSecretName permit to get the Secret name where to export the enrollment token
+func (h *FleetAgentPolicy) GetEnrollmentTokenSecretName() string {
+	if h.Spec.EnrollmentTokenSecret != "" {
+		return h.Spec.EnrollmentTokenSecret
+	}
+
+	return fmt.Sprintf("%s-enrollment-token", h.Name)
+}
+
+// ToAgentPolicy permit to convert current spec to agent policy
+func (h *FleetAgentPolicy) ToAgentPolicy() *kibanahandler.FleetAgentPolicy {
+	policy := &kibanahandler.FleetAgentPolicy{
+		ID:                 h.GetPolicyID(),
+		Name:               h.Spec.Name,
+		Namespace:          h.Spec.Namespace,
+		Description:        h.Spec.Description,
+		MonitoringEnabled:  h.Spec.MonitoringEnabled,
+		DataOutputID:       h.Spec.DataOutputID,
+		MonitoringOutputID: h.Spec.MonitoringOutputID,
+		FleetServerHostID:  h.Spec.FleetServerHostID,
+		InactivityTimeout:  h.Spec.InactivityTimeout,
+		UnenrollTimeout:    h.Spec.UnenrollTimeout,
+	}
+
+	if policy.Name == "" {
+		policy.Name = h.Name
+	}
+	if policy.Namespace == "" {
+		policy.Namespace = "default"
+	}
+	if policy.MonitoringEnabled == nil {
+		policy.MonitoringEnabled = []string{}
+	}
+
+	return policy
+}
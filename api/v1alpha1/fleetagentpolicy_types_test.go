@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestFleetAgentPolicyCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *FleetAgentPolicy
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &FleetAgentPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: FleetAgentPolicySpec{
+			Name: "Linux servers",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &FleetAgentPolicy{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestFleetAgentPolicyGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &FleetAgentPolicy{
+		ObjectMeta: meta,
+		Spec:       FleetAgentPolicySpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestFleetAgentPolicyGetStatus() {
+	status := FleetAgentPolicyStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+		EnrollmentTokenID: "1",
+	}
+	test := &FleetAgentPolicy{
+		Spec:   FleetAgentPolicySpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestFleetAgentPolicyToAgentPolicy() {
+	test := &FleetAgentPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "linux",
+		},
+		Spec: FleetAgentPolicySpec{
+			Description: "Agent policy of linux servers",
+		},
+	}
+
+	policy := test.ToAgentPolicy()
+	assert.Equal(t.T(), "linux", policy.ID)
+	assert.Equal(t.T(), "linux", policy.Name)
+	assert.Equal(t.T(), "default", policy.Namespace)
+	assert.Equal(t.T(), "Agent policy of linux servers", policy.Description)
+	assert.Equal(t.T(), []string{}, policy.MonitoringEnabled)
+	assert.Equal(t.T(), "linux-enrollment-token", test.GetEnrollmentTokenSecretName())
+
+	// ID, name, namespace and Secret
+	test.Spec.ID = "linux-servers"
+	test.Spec.Name = "Linux servers"
+	test.Spec.Namespace = "production"
+	test.Spec.MonitoringEnabled = []string{"logs"}
+	test.Spec.EnrollmentTokenSecret = "agent-token"
+	policy = test.ToAgentPolicy()
+	assert.Equal(t.T(), "linux-servers", policy.ID)
+	assert.Equal(t.T(), "Linux servers", policy.Name)
+	assert.Equal(t.T(), "production", policy.Namespace)
+	assert.Equal(t.T(), []string{"logs"}, policy.MonitoringEnabled)
+	assert.Equal(t.T(), "agent-token", test.GetEnrollmentTokenSecretName())
+}
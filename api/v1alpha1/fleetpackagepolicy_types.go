@@ -0,0 +1,179 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// FleetPackagePolicySpec defines the desired state of FleetPackagePolicy
+// +k8s:openapi-gen=true
+type FleetPackagePolicySpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// ID is the package policy identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the display name of the package policy
+	// If empty, it use the resource name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace is the data stream namespace used by the package policy
+	// If empty, it use the namespace of agent policy
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Description is the description of the package policy
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// AgentPolicy is the FleetAgentPolicy resource name where to add the package policy
+	// AgentPolicy and policy_id are mutually exclusive
+	// +optional
+	AgentPolicy string `json:"agentPolicy,omitempty"`
+
+	// PolicyID is the agent policy ID where to add the package policy, when the agent policy is not managed by operator
+	// AgentPolicy and policy_id are mutually exclusive
+	// +optional
+	PolicyID string `json:"policy_id,omitempty"`
+
+	// Package is the integration package to use
+	Package FleetPackagePolicyPackage `json:"package"`
+
+	// Vars is the package level variables
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Vars *runtime.RawExtension `json:"vars,omitempty"`
+
+	// Inputs is the inputs configuration, the key is the input ID like `system-logfile`
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Inputs *runtime.RawExtension `json:"inputs,omitempty"`
+}
+
+// FleetPackagePolicyPackage is the integration package to use
+type FleetPackagePolicyPackage struct {
+	// Name is the package name, like `system`
+	Name string `json:"name"`
+
+	// Version is the package version
+	Version string `json:"version"`
+}
+
+// FleetPackagePolicyStatus defines the observed state of FleetPackagePolicy
+type FleetPackagePolicyStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// FleetPackagePolicy is the Schema for the fleetpackagepolicies API
+type FleetPackagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FleetPackagePolicySpec   `json:"spec,omitempty"`
+	Status FleetPackagePolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FleetPackagePolicyList contains a list of FleetPackagePolicy
+type FleetPackagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FleetPackagePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FleetPackagePolicy{}, &FleetPackagePolicyList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *FleetPackagePolicy) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *FleetPackagePolicy) GetStatus() any {
+	return h.Status
+}
+
+// GetPackagePolicyID permit to get the package policy ID
+func (h *FleetPackagePolicy) GetPackagePolicyID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// ToPackagePolicy permit to convert current spec to package policy
+// The policyID is the agent policy ID resolved from agentPolicy or policy_id
+func (h *FleetPackagePolicy) ToPackagePolicy(policyID string) (policy *kibanahandler.FleetPackagePolicy, err error) {
+	if policyID == "" {
+		return nil, errors.New("You need to set agentPolicy or policy_id")
+	}
+
+	policy = &kibanahandler.FleetPackagePolicy{
+		ID:          h.GetPackagePolicyID(),
+		Name:        h.Spec.Name,
+		Namespace:   h.Spec.Namespace,
+		Description: h.Spec.Description,
+		PolicyID:    policyID,
+		Package: kibanahandler.FleetPackagePolicyPackage{
+			Name:    h.Spec.Package.Name,
+			Version: h.Spec.Package.Version,
+		},
+	}
+
+	if policy.Name == "" {
+		policy.Name = h.Name
+	}
+
+	if h.Spec.Vars != nil && len(h.Spec.Vars.Raw) > 0 {
+		if err = json.Unmarshal(h.Spec.Vars.Raw, &policy.Vars); err != nil {
+			return nil, errors.Wrap(err, "Error when decode vars")
+		}
+	}
+
+	if h.Spec.Inputs != nil && len(h.Spec.Inputs.Raw) > 0 {
+		if err = json.Unmarshal(h.Spec.Inputs.Raw, &policy.Inputs); err != nil {
+			return nil, errors.Wrap(err, "Error when decode inputs")
+		}
+	}
+
+	return policy, nil
+}
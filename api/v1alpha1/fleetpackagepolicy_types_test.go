@@ -0,0 +1,133 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestFleetPackagePolicyCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *FleetPackagePolicy
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &FleetPackagePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: FleetPackagePolicySpec{
+			AgentPolicy: "linux",
+			Package: FleetPackagePolicyPackage{
+				Name:    "system",
+				Version: "1.20.4",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &FleetPackagePolicy{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestFleetPackagePolicyGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &FleetPackagePolicy{
+		ObjectMeta: meta,
+		Spec:       FleetPackagePolicySpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestFleetPackagePolicyGetStatus() {
+	status := FleetPackagePolicyStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &FleetPackagePolicy{
+		Spec:   FleetPackagePolicySpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestFleetPackagePolicyToPackagePolicy() {
+	test := &FleetPackagePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "linux-system",
+		},
+		Spec: FleetPackagePolicySpec{
+			AgentPolicy: "linux",
+			Package: FleetPackagePolicyPackage{
+				Name:    "system",
+				Version: "1.20.4",
+			},
+			Vars: &runtime.RawExtension{
+				Raw: []byte(`{"period": "10s"}`),
+			},
+			Inputs: &runtime.RawExtension{
+				Raw: []byte(`{"system-logfile": {"enabled": true, "streams": {"system.auth": {"vars": {"paths": ["/var/log/auth.log*"]}}}}}`),
+			},
+		},
+	}
+
+	// When agent policy ID is not resolved
+	_, err := test.ToPackagePolicy("")
+	assert.Error(t.T(), err)
+
+	policy, err := test.ToPackagePolicy("linux-servers")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "linux-system", policy.ID)
+	assert.Equal(t.T(), "linux-system", policy.Name)
+	assert.Equal(t.T(), "linux-servers", policy.PolicyID)
+	assert.Equal(t.T(), "system", policy.Package.Name)
+	assert.Equal(t.T(), "10s", policy.Vars["period"])
+	assert.Equal(t.T(), true, policy.Inputs["system-logfile"].(map[string]any)["enabled"])
+
+	// When vars and inputs are not set
+	test.Spec.ID = "system"
+	test.Spec.Vars = nil
+	test.Spec.Inputs = nil
+	policy, err = test.ToPackagePolicy("linux-servers")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "system", policy.ID)
+	assert.Nil(t.T(), policy.Vars)
+	assert.Nil(t.T(), policy.Inputs)
+
+	// When inputs is not valid
+	test.Spec.Inputs = &runtime.RawExtension{
+		Raw: []byte(`["system-logfile"]`),
+	}
+	_, err = test.ToPackagePolicy("linux-servers")
+	assert.Error(t.T(), err)
+}
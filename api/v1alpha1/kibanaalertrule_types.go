@@ -0,0 +1,241 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaAlertRuleSpec defines the desired state of KibanaAlertRule
+// +k8s:openapi-gen=true
+type KibanaAlertRuleSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// Space is the space ID where to create the rule
+	// +kubebuilder:default=default
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// ID is the rule identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the display name of the rule
+	// If empty, it use the resource name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RuleTypeID is the rule type, like `.index-threshold` or `.es-query`
+	RuleTypeID string `json:"rule_type_id"`
+
+	// Consumer is the application that own the rule
+	// +kubebuilder:default=alerts
+	// +optional
+	Consumer string `json:"consumer,omitempty"`
+
+	// Schedule is the interval the rule is checked, like `1m`
+	Schedule string `json:"schedule"`
+
+	// Params is the rule parameters, it depend of the rule type
+	// JSON string
+	Params string `json:"params"`
+
+	// Actions is the list of actions run when the rule is triggered
+	// +optional
+	Actions []KibanaAlertRuleSpecAction `json:"actions,omitempty"`
+
+	// Tags is the list of tags
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// Enabled permit to enable or disable the rule
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NotifyWhen is when the actions are run
+	// +kubebuilder:validation:Enum=onActionGroupChange;onActiveAlert;onThrottleInterval
+	// +optional
+	NotifyWhen string `json:"notify_when,omitempty"`
+
+	// Throttle is the period of time to wait before running actions again, like `10m`
+	// Only used with `onThrottleInterval`
+	// +optional
+	Throttle string `json:"throttle,omitempty"`
+}
+
+// KibanaAlertRuleSpecAction is the action object
+type KibanaAlertRuleSpecAction struct {
+	// Group is the action group, it depend of the rule type
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Connector is the name of the KibanaConnector resource used by the action
+	// It need to be on the same namespace and space than the rule
+	Connector string `json:"connector"`
+
+	// Params is the action parameters, like the message
+	// JSON string
+	// +optional
+	Params string `json:"params,omitempty"`
+}
+
+// KibanaAlertRuleStatus defines the observed state of KibanaAlertRule
+type KibanaAlertRuleStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// ExecutionStatus is the status of the last rule execution, like `ok`, `active` or `error`
+	// +optional
+	ExecutionStatus string `json:"executionStatus,omitempty"`
+
+	// LastExecutionDate is the date of the last rule execution
+	// +optional
+	LastExecutionDate string `json:"lastExecutionDate,omitempty"`
+
+	// LastRunOutcome is the outcome of the last rule execution, like `succeeded`, `warning` or `failed`
+	// +optional
+	LastRunOutcome string `json:"lastRunOutcome,omitempty"`
+
+	// Error is the error of the last rule execution
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaAlertRule is the Schema for the kibanaalertrules API
+type KibanaAlertRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaAlertRuleSpec   `json:"spec,omitempty"`
+	Status KibanaAlertRuleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaAlertRuleList contains a list of KibanaAlertRule
+type KibanaAlertRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaAlertRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaAlertRule{}, &KibanaAlertRuleList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaAlertRule) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaAlertRule) GetStatus() any {
+	return h.Status
+}
+
+// GetRuleID permit to get the rule ID
+func (h *KibanaAlertRule) GetRuleID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// GetSpace permit to get the space where to create the rule
+func (h *KibanaAlertRule) GetSpace() string {
+	if h.Spec.Space != "" {
+		return h.Spec.Space
+	}
+
+	return "default"
+}
+
+// IsEnabled permit to know if the rule must be enabled
+func (h *KibanaAlertRule) IsEnabled() bool {
+	return h.Spec.Enabled == nil || *h.Spec.Enabled
+}
+
+// ToAlertRule permit to convert current spec to Kibana rule
+// The connectorIDs map the KibanaConnector name to the connector ID
+func (h *KibanaAlertRule) ToAlertRule(connectorIDs map[string]string) (rule *kibanahandler.KibanaAlertRule, err error) {
+	rule = &kibanahandler.KibanaAlertRule{
+		ID:         h.GetRuleID(),
+		Name:       h.Spec.Name,
+		RuleTypeID: h.Spec.RuleTypeID,
+		Consumer:   h.Spec.Consumer,
+		Schedule: kibanahandler.KibanaAlertRuleSchedule{
+			Interval: h.Spec.Schedule,
+		},
+		Tags:       h.Spec.Tags,
+		Enabled:    h.IsEnabled(),
+		NotifyWhen: h.Spec.NotifyWhen,
+		Throttle:   h.Spec.Throttle,
+	}
+
+	if rule.Name == "" {
+		rule.Name = h.Name
+	}
+	if rule.Consumer == "" {
+		rule.Consumer = "alerts"
+	}
+
+	if rule.Params, err = jsonToMap(h.Spec.Params); err != nil {
+		return nil, errors.Wrap(err, "Error when decode params")
+	}
+	if rule.Params == nil {
+		rule.Params = map[string]any{}
+	}
+
+	if h.Spec.Actions != nil {
+		rule.Actions = make([]kibanahandler.KibanaAlertRuleAction, 0, len(h.Spec.Actions))
+		for _, action := range h.Spec.Actions {
+			connectorID, ok := connectorIDs[action.Connector]
+			if !ok {
+				return nil, errors.Errorf("Connector %s not found", action.Connector)
+			}
+			a := kibanahandler.KibanaAlertRuleAction{
+				Group: action.Group,
+				ID:    connectorID,
+			}
+			if a.Params, err = jsonToMap(action.Params); err != nil {
+				return nil, errors.Wrapf(err, "Error when decode params of action %s", action.Connector)
+			}
+			if a.Params == nil {
+				a.Params = map[string]any{}
+			}
+			rule.Actions = append(rule.Actions, a)
+		}
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaAlertRuleCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaAlertRule
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	enabled := true
+	created = &KibanaAlertRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaAlertRuleSpec{
+			Space:      "default",
+			RuleTypeID: ".index-threshold",
+			Consumer:   "alerts",
+			Schedule:   "1m",
+			Params:     "{}",
+			Enabled:    &enabled,
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaAlertRule{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaAlertRuleGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaAlertRule{
+		ObjectMeta: meta,
+		Spec:       KibanaAlertRuleSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaAlertRuleGetStatus() {
+	status := KibanaAlertRuleStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+		ExecutionStatus: "ok",
+	}
+	test := &KibanaAlertRule{
+		Spec:   KibanaAlertRuleSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaAlertRuleToAlertRule() {
+	test := &KibanaAlertRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cpu-high",
+		},
+		Spec: KibanaAlertRuleSpec{
+			RuleTypeID: ".index-threshold",
+			Schedule:   "1m",
+			Params:     `{"aggType": "avg", "threshold": [1000]}`,
+			Actions: []KibanaAlertRuleSpecAction{
+				{
+					Group:     "threshold met",
+					Connector: "slack",
+					Params:    `{"message": "CPU is high"}`,
+				},
+			},
+			Tags:       []string{"cpu"},
+			NotifyWhen: "onActionGroupChange",
+		},
+	}
+
+	rule, err := test.ToAlertRule(map[string]string{"slack": "my-slack"})
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "cpu-high", rule.ID)
+	assert.Equal(t.T(), "cpu-high", rule.Name)
+	assert.Equal(t.T(), "alerts", rule.Consumer)
+	assert.Equal(t.T(), "1m", rule.Schedule.Interval)
+	assert.Equal(t.T(), "avg", rule.Params["aggType"])
+	assert.Equal(t.T(), "my-slack", rule.Actions[0].ID)
+	assert.Equal(t.T(), "CPU is high", rule.Actions[0].Params["message"])
+	assert.True(t.T(), rule.Enabled)
+
+	// When connector not found
+	_, err = test.ToAlertRule(map[string]string{})
+	assert.Error(t.T(), err)
+
+	// When disabled
+	enabled := false
+	test.Spec.Enabled = &enabled
+	rule, err = test.ToAlertRule(map[string]string{"slack": "my-slack"})
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), rule.Enabled)
+
+	// ID and space
+	assert.Equal(t.T(), "default", test.GetSpace())
+	test.Spec.ID = "my-rule"
+	test.Spec.Space = "marketing"
+	assert.Equal(t.T(), "my-rule", test.GetRuleID())
+	assert.Equal(t.T(), "marketing", test.GetSpace())
+}
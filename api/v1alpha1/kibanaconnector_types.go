@@ -0,0 +1,156 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaConnectorSpec defines the desired state of KibanaConnector
+// +k8s:openapi-gen=true
+type KibanaConnectorSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// Space is the space ID where to create the connector
+	// +kubebuilder:default=default
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// ID is the connector identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the display name of the connector
+	// If empty, it use the resource name
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ConnectorTypeID is the connector type, like `.slack` or `.email`
+	ConnectorTypeID string `json:"connector_type_id"`
+
+	// Config is the connector configuration
+	// JSON string
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// SecretRef is the Secret that contain the connector secrets, like password or webhook URL
+	// Each key of the Secret is a connector secret
+	// +optional
+	SecretRef *KibanaConnectorSecretRef `json:"secretRef,omitempty"`
+}
+
+// KibanaConnectorSecretRef is the Secret that contain the connector secrets
+type KibanaConnectorSecretRef struct {
+	// Name is the Secret name
+	Name string `json:"name"`
+}
+
+// KibanaConnectorStatus defines the observed state of KibanaConnector
+type KibanaConnectorStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// SecretResourceVersion is the resource version of the Secret used on the last update
+	// It permit to detect when secrets change, because of Kibana never return them
+	// +optional
+	SecretResourceVersion string `json:"secretResourceVersion,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaConnector is the Schema for the kibanaconnectors API
+type KibanaConnector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaConnectorSpec   `json:"spec,omitempty"`
+	Status KibanaConnectorStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaConnectorList contains a list of KibanaConnector
+type KibanaConnectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaConnector `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaConnector{}, &KibanaConnectorList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaConnector) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaConnector) GetStatus() any {
+	return h.Status
+}
+
+// GetConnectorID permit to get the connector ID
+func (h *KibanaConnector) GetConnectorID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// GetSpace permit to get the space where to create the connector
+func (h *KibanaConnector) GetSpace() string {
+	if h.Spec.Space != "" {
+		return h.Spec.Space
+	}
+
+	return "default"
+}
+
+// ToConnector permit to convert current spec to Kibana connector
+// The secrets are provided from Secret
+func (h *KibanaConnector) ToConnector(secrets map[string]any) (connector *kibanahandler.KibanaConnector, err error) {
+	connector = &kibanahandler.KibanaConnector{
+		ID:              h.GetConnectorID(),
+		Name:            h.Spec.Name,
+		ConnectorTypeID: h.Spec.ConnectorTypeID,
+		Secrets:         secrets,
+	}
+
+	if connector.Name == "" {
+		connector.Name = h.Name
+	}
+
+	if connector.Config, err = jsonToMap(h.Spec.Config); err != nil {
+		return nil, errors.Wrap(err, "Error when decode config")
+	}
+
+	return connector, nil
+}
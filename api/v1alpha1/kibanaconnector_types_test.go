@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaConnectorCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaConnector
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &KibanaConnector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaConnectorSpec{
+			Space:           "default",
+			ConnectorTypeID: ".slack",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaConnector{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaConnectorGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaConnector{
+		ObjectMeta: meta,
+		Spec:       KibanaConnectorSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaConnectorGetStatus() {
+	status := KibanaConnectorStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+		SecretResourceVersion: "1",
+	}
+	test := &KibanaConnector{
+		Spec:   KibanaConnectorSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaConnectorToConnector() {
+	test := &KibanaConnector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "smtp",
+		},
+		Spec: KibanaConnectorSpec{
+			ConnectorTypeID: ".email",
+			Config:          `{"from": "alert@domain.com", "host": "smtp.domain.com", "port": 25}`,
+		},
+	}
+
+	connector, err := test.ToConnector(map[string]any{"user": "alert", "password": "secret"})
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "smtp", connector.ID)
+	assert.Equal(t.T(), "smtp", connector.Name)
+	assert.Equal(t.T(), ".email", connector.ConnectorTypeID)
+	assert.Equal(t.T(), "smtp.domain.com", connector.Config["host"])
+	assert.Equal(t.T(), "secret", connector.Secrets["password"])
+
+	// ID, name and space
+	assert.Equal(t.T(), "default", test.GetSpace())
+	test.Spec.ID = "my-smtp"
+	test.Spec.Name = "SMTP"
+	test.Spec.Space = "marketing"
+	connector, err = test.ToConnector(nil)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "my-smtp", connector.ID)
+	assert.Equal(t.T(), "SMTP", connector.Name)
+	assert.Equal(t.T(), "marketing", test.GetSpace())
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaDataViewSpec defines the desired state of KibanaDataView
+// +k8s:openapi-gen=true
+type KibanaDataViewSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// Space is the space ID where to create the data view
+	// +kubebuilder:default=default
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// ID is the data view identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Title is the comma-separated list of data streams, indices, and aliases to search, like `logs-*`
+	Title string `json:"title"`
+
+	// Name is the display name of the data view
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// TimeFieldName is the timestamp field name used for time-based data views
+	// +optional
+	TimeFieldName string `json:"timeFieldName,omitempty"`
+
+	// RuntimeFields is the map of runtime fields, the key is the field name
+	// +optional
+	RuntimeFields map[string]KibanaDataViewSpecRuntimeField `json:"runtimeFields,omitempty"`
+
+	// FieldFormats is the map of field formats, the key is the field name
+	// +optional
+	FieldFormats map[string]KibanaDataViewSpecFieldFormat `json:"fieldFormats,omitempty"`
+
+	// Default permit to set the data view as the default data view of the space
+	// +optional
+	Default bool `json:"default,omitempty"`
+}
+
+// KibanaDataViewSpecRuntimeField is the runtime field object
+type KibanaDataViewSpecRuntimeField struct {
+	// Type is the runtime field type, like `keyword` or `long`
+	Type string `json:"type"`
+
+	// Script is the painless script that emit the field value
+	// +optional
+	Script string `json:"script,omitempty"`
+}
+
+// KibanaDataViewSpecFieldFormat is the field format object
+type KibanaDataViewSpecFieldFormat struct {
+	// ID is the formatter ID, like `bytes` or `url`
+	ID string `json:"id"`
+
+	// Params is the formatter parameters
+	// JSON string
+	// +optional
+	Params string `json:"params,omitempty"`
+}
+
+// KibanaDataViewStatus defines the observed state of KibanaDataView
+type KibanaDataViewStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaDataView is the Schema for the kibanadataviews API
+type KibanaDataView struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaDataViewSpec   `json:"spec,omitempty"`
+	Status KibanaDataViewStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaDataViewList contains a list of KibanaDataView
+type KibanaDataViewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaDataView `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaDataView{}, &KibanaDataViewList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaDataView) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaDataView) GetStatus() any {
+	return h.Status
+}
+
+// GetDataViewID permit to get the data view ID
+func (h *KibanaDataView) GetDataViewID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// GetSpace permit to get the space where to create the data view
+func (h *KibanaDataView) GetSpace() string {
+	if h.Spec.Space != "" {
+		return h.Spec.Space
+	}
+
+	return "default"
+}
+
+// ToDataView permit to convert current spec to Kibana data view
+func (h *KibanaDataView) ToDataView() (dataView *kibanahandler.KibanaDataView, err error) {
+	dataView = &kibanahandler.KibanaDataView{
+		ID:            h.GetDataViewID(),
+		Title:         h.Spec.Title,
+		Name:          h.Spec.Name,
+		TimeFieldName: h.Spec.TimeFieldName,
+	}
+
+	if h.Spec.RuntimeFields != nil {
+		dataView.RuntimeFieldMap = make(map[string]kibanahandler.KibanaDataViewRuntimeField, len(h.Spec.RuntimeFields))
+		for name, field := range h.Spec.RuntimeFields {
+			f := kibanahandler.KibanaDataViewRuntimeField{
+				Type: field.Type,
+			}
+			if field.Script != "" {
+				f.Script = &kibanahandler.KibanaDataViewScript{
+					Source: field.Script,
+				}
+			}
+			dataView.RuntimeFieldMap[name] = f
+		}
+	}
+
+	if h.Spec.FieldFormats != nil {
+		dataView.FieldFormats = make(map[string]kibanahandler.KibanaDataViewFieldFormat, len(h.Spec.FieldFormats))
+		for name, format := range h.Spec.FieldFormats {
+			f := kibanahandler.KibanaDataViewFieldFormat{
+				ID: format.ID,
+			}
+			if f.Params, err = jsonToMap(format.Params); err != nil {
+				return nil, errors.Wrapf(err, "Error when decode params of field format %s", name)
+			}
+			dataView.FieldFormats[name] = f
+		}
+	}
+
+	return dataView, nil
+}
@@ -0,0 +1,118 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaDataViewCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaDataView
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &KibanaDataView{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaDataViewSpec{
+			Space: "default",
+			Title: "logs-*",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaDataView{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaDataViewGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaDataView{
+		ObjectMeta: meta,
+		Spec:       KibanaDataViewSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaDataViewGetStatus() {
+	status := KibanaDataViewStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &KibanaDataView{
+		Spec:   KibanaDataViewSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaDataViewToDataView() {
+	test := &KibanaDataView{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "logs",
+		},
+		Spec: KibanaDataViewSpec{
+			Title:         "logs-*",
+			TimeFieldName: "@timestamp",
+			RuntimeFields: map[string]KibanaDataViewSpecRuntimeField{
+				"day_of_week": {
+					Type:   "keyword",
+					Script: "emit(doc['@timestamp'].value.dayOfWeekEnum.toString())",
+				},
+			},
+			FieldFormats: map[string]KibanaDataViewSpecFieldFormat{
+				"url": {
+					ID:     "url",
+					Params: `{"type": "a"}`,
+				},
+			},
+		},
+	}
+
+	dataView, err := test.ToDataView()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "logs", dataView.ID)
+	assert.Equal(t.T(), "logs-*", dataView.Title)
+	assert.Equal(t.T(), "keyword", dataView.RuntimeFieldMap["day_of_week"].Type)
+	assert.Equal(t.T(), "emit(doc['@timestamp'].value.dayOfWeekEnum.toString())", dataView.RuntimeFieldMap["day_of_week"].Script.Source)
+	assert.Equal(t.T(), "a", dataView.FieldFormats["url"].Params["type"])
+
+	// ID and space
+	assert.Equal(t.T(), "logs", test.GetDataViewID())
+	assert.Equal(t.T(), "default", test.GetSpace())
+	test.Spec.ID = "my-logs"
+	test.Spec.Space = "marketing"
+	assert.Equal(t.T(), "my-logs", test.GetDataViewID())
+	assert.Equal(t.T(), "marketing", test.GetSpace())
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaRoleSpec defines the desired state of KibanaRole
+// +k8s:openapi-gen=true
+type KibanaRoleSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// Elasticsearch is the Elasticsearch privileges of the role
+	// +optional
+	Elasticsearch KibanaRoleSpecElasticsearch `json:"elasticsearch,omitempty"`
+
+	// Kibana is the list of Kibana privileges per spaces
+	// +optional
+	Kibana []KibanaRoleSpecKibana `json:"kibana,omitempty"`
+
+	// Metadata is optional meta-data
+	// JSON string
+	// +optional
+	Metadata string `json:"metadata,omitempty"`
+}
+
+// KibanaRoleSpecElasticsearch is the Elasticsearch privileges object
+type KibanaRoleSpecElasticsearch struct {
+	// Cluster is a list of cluster privileges
+	// +optional
+	Cluster []string `json:"cluster,omitempty"`
+
+	// Indices is the list of indices permissions
+	// +optional
+	Indices []ElasticsearchRoleSpecIndicesPermissions `json:"indices,omitempty"`
+
+	// RunAs is the list of users that the owners of this role can impersonate
+	// +optional
+	RunAs []string `json:"run_as,omitempty"`
+}
+
+// KibanaRoleSpecKibana is the Kibana privileges object
+// Base and feature are mutually exclusive
+type KibanaRoleSpecKibana struct {
+	// Base is the list of base privileges, like `all` or `read`
+	// +optional
+	Base []string `json:"base,omitempty"`
+
+	// Feature is the privileges per feature, like `discover: [all]`
+	// +optional
+	Feature map[string][]string `json:"feature,omitempty"`
+
+	// Spaces is the list of spaces where privileges are applied
+	// Use `*` to grant privileges on all spaces
+	Spaces []string `json:"spaces"`
+}
+
+// KibanaRoleStatus defines the observed state of KibanaRole
+type KibanaRoleStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaRole is the Schema for the kibanaroles API
+type KibanaRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaRoleSpec   `json:"spec,omitempty"`
+	Status KibanaRoleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaRoleList contains a list of KibanaRole
+type KibanaRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaRole{}, &KibanaRoleList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaRole) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaRole) GetStatus() any {
+	return h.Status
+}
+
+// ToRole permit to convert current spec to Kibana role
+func (h *KibanaRole) ToRole() (role *kibanahandler.KibanaRole, err error) {
+	role = &kibanahandler.KibanaRole{
+		Elasticsearch: kibanahandler.KibanaRoleElasticsearch{
+			Cluster: h.Spec.Elasticsearch.Cluster,
+			RunAs:   h.Spec.Elasticsearch.RunAs,
+		},
+	}
+
+	if role.Metadata, err = jsonToMap(h.Spec.Metadata); err != nil {
+		return nil, errors.Wrap(err, "Error when decode metadata")
+	}
+
+	if h.Spec.Elasticsearch.Indices != nil {
+		role.Elasticsearch.Indices = make([]kibanahandler.KibanaRoleIndicesPermissions, 0, len(h.Spec.Elasticsearch.Indices))
+		for _, indice := range h.Spec.Elasticsearch.Indices {
+			i := kibanahandler.KibanaRoleIndicesPermissions{
+				Names:      indice.Names,
+				Privileges: indice.Privileges,
+				Query:      indice.Query,
+			}
+			if i.FieldSecurity, err = jsonToMap(indice.FieldSecurity); err != nil {
+				return nil, errors.Wrap(err, "Error when decode field_security")
+			}
+			role.Elasticsearch.Indices = append(role.Elasticsearch.Indices, i)
+		}
+	}
+
+	// Kibana API always return base and feature, so we init them to avoid false diff
+	if h.Spec.Kibana != nil {
+		role.Kibana = make([]kibanahandler.KibanaRoleKibanaAccess, 0, len(h.Spec.Kibana))
+		for _, kibana := range h.Spec.Kibana {
+			if len(kibana.Base) > 0 && len(kibana.Feature) > 0 {
+				return nil, errors.Errorf("Base and feature privileges can't be set together on spaces %v", kibana.Spaces)
+			}
+			k := kibanahandler.KibanaRoleKibanaAccess{
+				Base:    kibana.Base,
+				Feature: kibana.Feature,
+				Spaces:  kibana.Spaces,
+			}
+			if k.Base == nil {
+				k.Base = []string{}
+			}
+			if k.Feature == nil {
+				k.Feature = map[string][]string{}
+			}
+			role.Kibana = append(role.Kibana, k)
+		}
+	}
+
+	return role, nil
+}
@@ -0,0 +1,131 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaRoleCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaRole
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &KibanaRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaRoleSpec{
+			Kibana: []KibanaRoleSpecKibana{
+				{
+					Base:   []string{"read"},
+					Spaces: []string{"default"},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaRole{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaRoleGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaRole{
+		ObjectMeta: meta,
+		Spec:       KibanaRoleSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaRoleGetStatus() {
+	status := KibanaRoleStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &KibanaRole{
+		Spec:   KibanaRoleSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaRoleToRole() {
+	test := &KibanaRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: KibanaRoleSpec{
+			Elasticsearch: KibanaRoleSpecElasticsearch{
+				Cluster: []string{"monitor"},
+				Indices: []ElasticsearchRoleSpecIndicesPermissions{
+					{
+						Names:         []string{"logstash-*"},
+						Privileges:    []string{"read"},
+						FieldSecurity: `{"grant": ["*"]}`,
+					},
+				},
+			},
+			Kibana: []KibanaRoleSpecKibana{
+				{
+					Feature: map[string][]string{
+						"discover": {"all"},
+					},
+					Spaces: []string{"marketing"},
+				},
+				{
+					Base:   []string{"read"},
+					Spaces: []string{"default"},
+				},
+			},
+			Metadata: `{"version": 1}`,
+		},
+	}
+
+	role, err := test.ToRole()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"monitor"}, role.Elasticsearch.Cluster)
+	assert.Equal(t.T(), []any{"*"}, role.Elasticsearch.Indices[0].FieldSecurity["grant"])
+	assert.Equal(t.T(), []string{}, role.Kibana[0].Base)
+	assert.Equal(t.T(), []string{"all"}, role.Kibana[0].Feature["discover"])
+	assert.Equal(t.T(), map[string][]string{}, role.Kibana[1].Feature)
+	assert.Equal(t.T(), float64(1), role.Metadata["version"])
+
+	// When base and feature are set together
+	test.Spec.Kibana[1].Feature = map[string][]string{
+		"dashboard": {"read"},
+	}
+	_, err = test.ToRole()
+	assert.Error(t.T(), err)
+}
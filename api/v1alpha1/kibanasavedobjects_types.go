@@ -0,0 +1,141 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaSavedObjectsSpec defines the desired state of KibanaSavedObjects
+// +k8s:openapi-gen=true
+type KibanaSavedObjectsSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// Space is the space ID where to import the saved objects
+	// +kubebuilder:default=default
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// ConfigMaps is the list of ConfigMaps that contain the NDJSON export
+	// +kubebuilder:validation:MinItems=1
+	ConfigMaps []KibanaSavedObjectsConfigMapRef `json:"configMaps"`
+
+	// DeletionPolicy permit to delete the imported saved objects when the resource is deleted
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +kubebuilder:default=Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// KibanaSavedObjectsConfigMapRef is the ConfigMap that contain NDJSON export
+type KibanaSavedObjectsConfigMapRef struct {
+	// Name is the ConfigMap name
+	Name string `json:"name"`
+
+	// Keys is the list of keys to read on ConfigMap
+	// If empty, it read all keys
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+}
+
+// KibanaSavedObjectsStatus defines the observed state of KibanaSavedObjects
+type KibanaSavedObjectsStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+
+	// Hash is the hash of the last content successfully imported
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// Space is the space where the saved objects have been imported
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// Objects is the list of saved objects imported
+	// +optional
+	Objects []KibanaSavedObjectStatus `json:"objects,omitempty"`
+
+	// Errors is the list of saved objects that failed to be imported
+	// +optional
+	Errors []KibanaSavedObjectStatus `json:"errors,omitempty"`
+}
+
+// KibanaSavedObjectStatus is the import status of saved object
+type KibanaSavedObjectStatus struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaSavedObjects is the Schema for the kibanasavedobjects API
+type KibanaSavedObjects struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaSavedObjectsSpec   `json:"spec,omitempty"`
+	Status KibanaSavedObjectsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaSavedObjectsList contains a list of KibanaSavedObjects
+type KibanaSavedObjectsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaSavedObjects `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaSavedObjects{}, &KibanaSavedObjectsList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaSavedObjects) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaSavedObjects) GetStatus() any {
+	return h.Status
+}
+
+// GetSpace permit to get the space where to import saved objects
+func (h *KibanaSavedObjects) GetSpace() string {
+	if h.Spec.Space != "" {
+		return h.Spec.Space
+	}
+
+	return "default"
+}
+
+// IsDeletedOnRemove permit to know if the imported saved objects must be deleted when the resource is deleted
+func (h *KibanaSavedObjects) IsDeletedOnRemove() bool {
+	return h.Spec.DeletionPolicy == DeletionPolicyDelete
+}
@@ -0,0 +1,104 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaSavedObjectsCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaSavedObjects
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &KibanaSavedObjects{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaSavedObjectsSpec{
+			Space:          "default",
+			DeletionPolicy: DeletionPolicyRetain,
+			ConfigMaps: []KibanaSavedObjectsConfigMapRef{
+				{
+					Name: "dashboards",
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaSavedObjects{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSavedObjectsGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaSavedObjects{
+		ObjectMeta: meta,
+		Spec:       KibanaSavedObjectsSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSavedObjectsGetStatus() {
+	status := KibanaSavedObjectsStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+		Hash: "test",
+	}
+	test := &KibanaSavedObjects{
+		Spec:   KibanaSavedObjectsSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSavedObjectsGetSpace() {
+	test := &KibanaSavedObjects{
+		Spec: KibanaSavedObjectsSpec{},
+	}
+	assert.Equal(t.T(), "default", test.GetSpace())
+
+	test.Spec.Space = "marketing"
+	assert.Equal(t.T(), "marketing", test.GetSpace())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSavedObjectsIsDeletedOnRemove() {
+	test := &KibanaSavedObjects{
+		Spec: KibanaSavedObjectsSpec{},
+	}
+	assert.False(t.T(), test.IsDeletedOnRemove())
+
+	test.Spec.DeletionPolicy = DeletionPolicyDelete
+	assert.True(t.T(), test.IsDeletedOnRemove())
+}
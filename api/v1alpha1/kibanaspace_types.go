@@ -0,0 +1,128 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// KibanaSpaceSpec defines the desired state of KibanaSpace
+// +k8s:openapi-gen=true
+type KibanaSpaceSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	KibanaRefSpec `json:"kibanaRef"`
+
+	// ID is the space identifier
+	// If empty, it use the resource name
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Name is the display name of the space
+	Name string `json:"name"`
+
+	// Description is the description of the space
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Color is the hexadecimal color code used in the space avatar
+	// +optional
+	Color string `json:"color,omitempty"`
+
+	// Initials is the initials shown in the space avatar
+	// +kubebuilder:validation:MaxLength=2
+	// +optional
+	Initials string `json:"initials,omitempty"`
+
+	// DisabledFeatures is the list of features disabled in the space
+	// +optional
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+
+	// ImageURL is the data-url encoded image to display in the space avatar
+	// +optional
+	ImageURL string `json:"imageUrl,omitempty"`
+}
+
+// KibanaSpaceStatus defines the observed state of KibanaSpace
+type KibanaSpaceStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// KibanaSpace is the Schema for the kibanaspaces API
+type KibanaSpace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KibanaSpaceSpec   `json:"spec,omitempty"`
+	Status KibanaSpaceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KibanaSpaceList contains a list of KibanaSpace
+type KibanaSpaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KibanaSpace `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaSpace{}, &KibanaSpaceList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *KibanaSpace) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *KibanaSpace) GetStatus() any {
+	return h.Status
+}
+
+// GetSpaceID permit to get the space ID
+func (h *KibanaSpace) GetSpaceID() string {
+	if h.Spec.ID != "" {
+		return h.Spec.ID
+	}
+
+	return h.Name
+}
+
+// ToSpace permit to convert current spec to Kibana space
+func (h *KibanaSpace) ToSpace() *kibanahandler.KibanaSpace {
+	return &kibanahandler.KibanaSpace{
+		ID:               h.GetSpaceID(),
+		Name:             h.Spec.Name,
+		Description:      h.Spec.Description,
+		Color:            h.Spec.Color,
+		Initials:         h.Spec.Initials,
+		DisabledFeatures: h.Spec.DisabledFeatures,
+		ImageURL:         h.Spec.ImageURL,
+	}
+}
@@ -0,0 +1,102 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestKibanaSpaceCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *KibanaSpace
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &KibanaSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: KibanaSpaceSpec{
+			Name: "Marketing",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &KibanaSpace{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSpaceGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &KibanaSpace{
+		ObjectMeta: meta,
+		Spec:       KibanaSpaceSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSpaceGetStatus() {
+	status := KibanaSpaceStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &KibanaSpace{
+		Spec:   KibanaSpaceSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestKibanaSpaceToSpace() {
+	test := &KibanaSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "marketing",
+		},
+		Spec: KibanaSpaceSpec{
+			Name:             "Marketing",
+			Description:      "This is the Marketing Space",
+			Initials:         "MK",
+			DisabledFeatures: []string{"visualize"},
+		},
+	}
+
+	space := test.ToSpace()
+	assert.Equal(t.T(), "marketing", space.ID)
+	assert.Equal(t.T(), "Marketing", space.Name)
+	assert.Equal(t.T(), "MK", space.Initials)
+	assert.Equal(t.T(), []string{"visualize"}, space.DisabledFeatures)
+
+	// ID
+	assert.Equal(t.T(), "marketing", test.GetSpaceID())
+	test.Spec.ID = "mkt"
+	assert.Equal(t.T(), "mkt", test.GetSpaceID())
+}
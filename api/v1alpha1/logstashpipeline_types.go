@@ -0,0 +1,174 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// LogstashPipelineSpec defines the desired state of LogstashPipeline
+// +k8s:openapi-gen=true
+type LogstashPipelineSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	ElasticsearchRefSpec `json:"elasticsearchRef"`
+
+	// Description is the description of the pipeline
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Pipeline is the pipeline configuration
+	// Pipeline and configMapRef are mutually exclusive
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// ConfigMapRef is the ConfigMap key that contain the pipeline configuration
+	// Pipeline and configMapRef are mutually exclusive
+	// +optional
+	ConfigMapRef *LogstashPipelineConfigMapRef `json:"configMapRef,omitempty"`
+
+	// Settings is the pipeline settings
+	// +optional
+	Settings *LogstashPipelineSettings `json:"settings,omitempty"`
+}
+
+// LogstashPipelineConfigMapRef is the ConfigMap key that contain the pipeline configuration
+type LogstashPipelineConfigMapRef struct {
+	// Name is the ConfigMap name
+	Name string `json:"name"`
+
+	// Key is the key to read on ConfigMap
+	Key string `json:"key"`
+}
+
+// LogstashPipelineSettings is the pipeline settings
+type LogstashPipelineSettings struct {
+	// Workers is the number of workers that will, in parallel, execute the filter and output stages of the pipeline
+	// +optional
+	Workers *int64 `json:"workers,omitempty"`
+
+	// BatchSize is the maximum number of events an individual worker thread collects before executing filters and outputs
+	// +optional
+	BatchSize *int64 `json:"batchSize,omitempty"`
+
+	// BatchDelay is the time in milliseconds to wait for each event before dispatching an undersized batch to pipeline workers
+	// +optional
+	BatchDelay *int64 `json:"batchDelay,omitempty"`
+
+	// QueueType is the internal queuing model for event buffering
+	// +kubebuilder:validation:Enum=memory;persisted
+	// +optional
+	QueueType string `json:"queueType,omitempty"`
+
+	// QueueMaxBytes is the total capacity of the queue when persistent queues are enabled, like `1gb`
+	// +optional
+	QueueMaxBytes string `json:"queueMaxBytes,omitempty"`
+
+	// QueueCheckpointWrites is the maximum number of written events before a checkpoint is forced when persistent queues are enabled
+	// +optional
+	QueueCheckpointWrites *int64 `json:"queueCheckpointWrites,omitempty"`
+}
+
+// LogstashPipelineStatus defines the observed state of LogstashPipeline
+type LogstashPipelineStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	Conditions []metav1.Condition `json:"conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// LogstashPipeline is the Schema for the logstashpipelines API
+type LogstashPipeline struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogstashPipelineSpec   `json:"spec,omitempty"`
+	Status LogstashPipelineStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// LogstashPipelineList contains a list of LogstashPipeline
+type LogstashPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogstashPipeline `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LogstashPipeline{}, &LogstashPipelineList{})
+}
+
+// GetObjectMeta permit to get the current ObjectMeta
+func (h *LogstashPipeline) GetObjectMeta() metav1.ObjectMeta {
+	return h.ObjectMeta
+}
+
+// GetStatus permit to get the current status
+func (h *LogstashPipeline) GetStatus() any {
+	return h.Status
+}
+
+// ToPipeline permit to convert current spec to Logstash pipeline
+// The pipeline configuration is read from ConfigMap by the caller when configMapRef is set
+func (h *LogstashPipeline) ToPipeline(content string) (*elasticsearchhandler.LogstashPipeline, error) {
+	if (h.Spec.Pipeline == "") == (h.Spec.ConfigMapRef == nil) {
+		return nil, errors.New("You need to set pipeline or configMapRef")
+	}
+
+	pipeline := &elasticsearchhandler.LogstashPipeline{
+		Description:      h.Spec.Description,
+		Pipeline:         h.Spec.Pipeline,
+		PipelineSettings: map[string]any{},
+	}
+
+	if h.Spec.ConfigMapRef != nil {
+		pipeline.Pipeline = content
+	}
+
+	if h.Spec.Settings != nil {
+		if h.Spec.Settings.Workers != nil {
+			pipeline.PipelineSettings["pipeline.workers"] = *h.Spec.Settings.Workers
+		}
+		if h.Spec.Settings.BatchSize != nil {
+			pipeline.PipelineSettings["pipeline.batch.size"] = *h.Spec.Settings.BatchSize
+		}
+		if h.Spec.Settings.BatchDelay != nil {
+			pipeline.PipelineSettings["pipeline.batch.delay"] = *h.Spec.Settings.BatchDelay
+		}
+		if h.Spec.Settings.QueueType != "" {
+			pipeline.PipelineSettings["queue.type"] = h.Spec.Settings.QueueType
+		}
+		if h.Spec.Settings.QueueMaxBytes != "" {
+			pipeline.PipelineSettings["queue.max_bytes"] = h.Spec.Settings.QueueMaxBytes
+		}
+		if h.Spec.Settings.QueueCheckpointWrites != nil {
+			pipeline.PipelineSettings["queue.checkpoint.writes"] = *h.Spec.Settings.QueueCheckpointWrites
+		}
+	}
+
+	return pipeline, nil
+}
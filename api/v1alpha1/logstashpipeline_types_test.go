@@ -0,0 +1,123 @@
+package v1alpha1
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/stretchr/testify/assert"
+
+	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *V1alpha1TestSuite) TestLogstashPipelineCRUD() {
+	var (
+		key              types.NamespacedName
+		created, fetched *LogstashPipeline
+		err              error
+	)
+
+	key = types.NamespacedName{
+		Name:      "foo-" + helpers.RandomString(5),
+		Namespace: "default",
+	}
+
+	// Create object
+	created = &LogstashPipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: LogstashPipelineSpec{
+			Pipeline: "input {}\n output {}",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), created)
+	assert.NoError(t.T(), err)
+
+	// Get object
+	fetched = &LogstashPipeline{}
+	err = t.k8sClient.Get(context.Background(), key, fetched)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), created, fetched)
+
+	// Delete object
+	err = t.k8sClient.Delete(context.Background(), created)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Get(context.Background(), key, created)
+	assert.Error(t.T(), err)
+}
+
+func (t *V1alpha1TestSuite) TestLogstashPipelineGetObjectMeta() {
+	meta := metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "test",
+	}
+	test := &LogstashPipeline{
+		ObjectMeta: meta,
+		Spec:       LogstashPipelineSpec{},
+	}
+
+	assert.Equal(t.T(), meta, test.GetObjectMeta())
+}
+
+func (t *V1alpha1TestSuite) TestLogstashPipelineGetStatus() {
+	status := LogstashPipelineStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type: "test",
+			},
+		},
+	}
+	test := &LogstashPipeline{
+		Spec:   LogstashPipelineSpec{},
+		Status: status,
+	}
+
+	assert.Equal(t.T(), status, test.GetStatus())
+}
+
+func (t *V1alpha1TestSuite) TestLogstashPipelineToPipeline() {
+	workers := int64(2)
+	test := &LogstashPipeline{
+		Spec: LogstashPipelineSpec{
+			Description: "test",
+		},
+	}
+
+	// When pipeline and configMapRef are not set
+	_, err := test.ToPipeline("")
+	assert.Error(t.T(), err)
+
+	// When pipeline and configMapRef are set
+	test.Spec.Pipeline = "input {}\n output {}"
+	test.Spec.ConfigMapRef = &LogstashPipelineConfigMapRef{
+		Name: "test",
+		Key:  "pipeline.conf",
+	}
+	_, err = test.ToPipeline("input {}\n output {}")
+	assert.Error(t.T(), err)
+
+	// When only pipeline is set
+	test.Spec.ConfigMapRef = nil
+	test.Spec.Settings = &LogstashPipelineSettings{
+		Workers:   &workers,
+		QueueType: "persisted",
+	}
+	pipeline, err := test.ToPipeline("")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "test", pipeline.Description)
+	assert.Equal(t.T(), "input {}\n output {}", pipeline.Pipeline)
+	assert.Equal(t.T(), map[string]any{"pipeline.workers": int64(2), "queue.type": "persisted"}, pipeline.PipelineSettings)
+
+	// When only configMapRef is set
+	test.Spec.Pipeline = ""
+	test.Spec.Settings = nil
+	test.Spec.ConfigMapRef = &LogstashPipelineConfigMapRef{
+		Name: "test",
+		Key:  "pipeline.conf",
+	}
+	pipeline, err = test.ToPipeline("input { beats {} }\n output {}")
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "input { beats {} }\n output {}", pipeline.Pipeline)
+	assert.Empty(t.T(), pipeline.PipelineSettings)
+}
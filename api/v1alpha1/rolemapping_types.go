@@ -19,7 +19,8 @@ package v1alpha1
 import (
 	"encoding/json"
 
-	olivere "github.com/olivere/elastic/v7"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,11 +39,23 @@ type RoleMappingSpec struct {
 	Enabled bool `json:"enabled"`
 
 	// Roles is the list of role to map
-	Roles []string `json:"roles"`
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// RoleTemplates is the list of mustache templates that derive the roles from user attributes
+	// +optional
+	RoleTemplates []RoleMappingRoleTemplate `json:"roleTemplates,omitempty"`
 
 	// Rules is the mapping rules
 	// JSON string
-	Rules string `json:"rules"`
+	// Rules and typedRules are mutually exclusive
+	// +optional
+	Rules string `json:"rules,omitempty"`
+
+	// TypedRules is the mapping rules
+	// Rules and typedRules are mutually exclusive
+	// +optional
+	TypedRules *RoleMappingRule `json:"typedRules,omitempty"`
 
 	// Metadata is the meta data
 	// JSON string
@@ -50,6 +63,55 @@ type RoleMappingSpec struct {
 	Metadata string `json:"metadata"`
 }
 
+// RoleMappingRoleTemplate is a mustache template that derive roles from user attributes
+type RoleMappingRoleTemplate struct {
+
+	// Source is the mustache template, like `{{#tojson}}groups{{/tojson}}`
+	Source string `json:"source"`
+
+	// Format is the format of template result
+	// Set `json` when template produce a list of roles
+	// +kubebuilder:validation:Enum=string;json
+	// +kubebuilder:default=string
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// RoleMappingRule is a mapping rule, it can be nested with any, all and except
+// Only one of any, all, field or except can be set
+// The nested rules are not validated by the CRD schema because it can't be recursive
+type RoleMappingRule struct {
+
+	// Any match when at least one of rules match
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Any []RoleMappingRule `json:"any,omitempty"`
+
+	// All match when all rules match
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	All []RoleMappingRule `json:"all,omitempty"`
+
+	// Field match when the user field match one of values
+	// +optional
+	Field RoleMappingFieldExpression `json:"field,omitempty"`
+
+	// Except match when the rule not match. It can only be used on all rule
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Except *RoleMappingRule `json:"except,omitempty"`
+}
+
+// RoleMappingFieldExpression is the user field, like `username`, `dn`, `groups`, `realm.name` or `metadata.xxx`, and the values to match
+// The values can use wildcard `*` or regex `/.../`
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type RoleMappingFieldExpression map[string][]string
+
 // RoleMappingStatus defines the observed state of RoleMapping
 type RoleMappingStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -93,18 +155,51 @@ func (h *RoleMapping) GetStatus() any {
 	return h.Status
 }
 
-func (h *RoleMapping) ToRoleMapping() (*olivere.XPackSecurityRoleMapping, error) {
-	rm := &olivere.XPackSecurityRoleMapping{
+// ToRoleMapping permit to convert to role mapping object expected by Elasticsearch
+func (h *RoleMapping) ToRoleMapping() (*elasticsearchhandler.XPackSecurityRoleMapping, error) {
+	if len(h.Spec.Roles) == 0 && len(h.Spec.RoleTemplates) == 0 {
+		return nil, errors.New("You need to set roles or roleTemplates")
+	}
+	if h.Spec.Rules != "" && h.Spec.TypedRules != nil {
+		return nil, errors.New("You can't set rules and typedRules")
+	}
+
+	rm := &elasticsearchhandler.XPackSecurityRoleMapping{
 		Enabled: h.Spec.Enabled,
 		Roles:   h.Spec.Roles,
 	}
 
+	if len(h.Spec.RoleTemplates) > 0 {
+		rm.RoleTemplates = make([]elasticsearchhandler.XPackSecurityRoleTemplate, 0, len(h.Spec.RoleTemplates))
+		for _, roleTemplate := range h.Spec.RoleTemplates {
+			// Elasticsearch always return the format
+			format := roleTemplate.Format
+			if format == "" {
+				format = "string"
+			}
+			rm.RoleTemplates = append(rm.RoleTemplates, elasticsearchhandler.XPackSecurityRoleTemplate{
+				Template: map[string]any{
+					"source": roleTemplate.Source,
+				},
+				Format: format,
+			})
+		}
+	}
+
 	if h.Spec.Rules != "" {
 		rules := make(map[string]any)
 		if err := json.Unmarshal([]byte(h.Spec.Rules), &rules); err != nil {
 			return nil, err
 		}
 		rm.Rules = rules
+	} else if h.Spec.TypedRules != nil {
+		rules, err := h.Spec.TypedRules.toMap()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when convert typedRules")
+		}
+		rm.Rules = rules
+	} else {
+		return nil, errors.New("You need to set rules or typedRules")
 	}
 
 	if h.Spec.Metadata != "" {
@@ -117,3 +212,77 @@ func (h *RoleMapping) ToRoleMapping() (*olivere.XPackSecurityRoleMapping, error)
 
 	return rm, nil
 }
+
+// toMap permit to convert the rule and its nested rules to the rule expected by Elasticsearch
+func (h *RoleMappingRule) toMap() (map[string]any, error) {
+	anyRules, err := roleMappingRulesToMaps(h.Any)
+	if err != nil {
+		return nil, err
+	}
+	allRules, err := roleMappingRulesToMaps(h.All)
+	if err != nil {
+		return nil, err
+	}
+	var except map[string]any
+	if h.Except != nil {
+		if except, err = h.Except.toMap(); err != nil {
+			return nil, err
+		}
+	}
+
+	return roleMappingRuleToMap(anyRules, allRules, h.Field, except)
+}
+
+// roleMappingRulesToMaps permit to convert a list of nested rules
+func roleMappingRulesToMaps(rules []RoleMappingRule) ([]map[string]any, error) {
+	maps := make([]map[string]any, 0, len(rules))
+	for i := range rules {
+		r, err := rules[i].toMap()
+		if err != nil {
+			return nil, err
+		}
+		maps = append(maps, r)
+	}
+
+	return maps, nil
+}
+
+// roleMappingRuleToMap permit to build the rule expected by Elasticsearch
+// It return error if not exactly one of any, all, field or except is set
+func roleMappingRuleToMap(anyRules, allRules []map[string]any, field RoleMappingFieldExpression, except map[string]any) (map[string]any, error) {
+	rule := map[string]any{}
+
+	if len(anyRules) > 0 {
+		rule["any"] = anyRules
+	}
+	if len(allRules) > 0 {
+		rule["all"] = allRules
+	}
+	if len(field) > 0 {
+		if len(field) > 1 {
+			return nil, errors.New("Field rule must have only one field")
+		}
+		fieldRule := map[string]any{}
+		for name, values := range field {
+			// Elasticsearch return single value as scalar
+			switch len(values) {
+			case 0:
+				return nil, errors.Errorf("Field rule %s must have at least one value", name)
+			case 1:
+				fieldRule[name] = values[0]
+			default:
+				fieldRule[name] = values
+			}
+		}
+		rule["field"] = fieldRule
+	}
+	if except != nil {
+		rule["except"] = except
+	}
+
+	if len(rule) != 1 {
+		return nil, errors.New("Rule must have only one of any, all, field or except")
+	}
+
+	return rule, nil
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
 	"github.com/disaster37/operator-elk-extra/pkg/helpers"
 	"github.com/stretchr/testify/assert"
 
@@ -77,3 +78,202 @@ func (t *V1alpha1TestSuite) TestElasticsearchRoleMappingGetStatus() {
 
 	assert.Equal(t.T(), status, test.GetStatus())
 }
+
+func (t *V1alpha1TestSuite) TestElasticsearchRoleMappingToRoleMapping() {
+	test := &RoleMapping{
+		Spec: RoleMappingSpec{
+			Enabled: true,
+			Roles:   []string{"superuser"},
+			Rules:   `{"field": {"username": "admin"}}`,
+		},
+	}
+
+	// With raw rules
+	rm, err := test.ToRoleMapping()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), &elasticsearchhandler.XPackSecurityRoleMapping{
+		Enabled: true,
+		Roles:   []string{"superuser"},
+		Rules: map[string]any{
+			"field": map[string]any{
+				"username": "admin",
+			},
+		},
+	}, rm)
+
+	// With typed rules and role templates
+	test.Spec.Rules = ""
+	test.Spec.Roles = nil
+	test.Spec.RoleTemplates = []RoleMappingRoleTemplate{
+		{
+			Source: "{{#tojson}}groups{{/tojson}}",
+			Format: "json",
+		},
+		{
+			Source: "saml_user",
+		},
+	}
+	test.Spec.TypedRules = &RoleMappingRule{
+		All: []RoleMappingRule{
+			{
+				Any: []RoleMappingRule{
+					{
+						Field: RoleMappingFieldExpression{
+							"realm.name": []string{"saml1"},
+						},
+					},
+					{
+						Field: RoleMappingFieldExpression{
+							"groups": []string{"admins", "ops"},
+						},
+					},
+				},
+			},
+			{
+				Except: &RoleMappingRule{
+					All: []RoleMappingRule{
+						{
+							Field: RoleMappingFieldExpression{
+								"metadata.disabled": []string{"true"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rm, err = test.ToRoleMapping()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), &elasticsearchhandler.XPackSecurityRoleMapping{
+		Enabled: true,
+		RoleTemplates: []elasticsearchhandler.XPackSecurityRoleTemplate{
+			{
+				Template: map[string]any{
+					"source": "{{#tojson}}groups{{/tojson}}",
+				},
+				Format: "json",
+			},
+			{
+				Template: map[string]any{
+					"source": "saml_user",
+				},
+				Format: "string",
+			},
+		},
+		Rules: map[string]any{
+			"all": []map[string]any{
+				{
+					"any": []map[string]any{
+						{
+							"field": map[string]any{
+								"realm.name": "saml1",
+							},
+						},
+						{
+							"field": map[string]any{
+								"groups": []string{"admins", "ops"},
+							},
+						},
+					},
+				},
+				{
+					"except": map[string]any{
+						"all": []map[string]any{
+							{
+								"field": map[string]any{
+									"metadata.disabled": "true",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, rm)
+
+	// When rules are nested on more than 4 levels
+	test.Spec.TypedRules = &RoleMappingRule{
+		All: []RoleMappingRule{
+			{
+				Any: []RoleMappingRule{
+					{
+						All: []RoleMappingRule{
+							{
+								Any: []RoleMappingRule{
+									{
+										Except: &RoleMappingRule{
+											Field: RoleMappingFieldExpression{
+												"username": []string{"guest"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rm, err = test.ToRoleMapping()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), map[string]any{
+		"all": []map[string]any{
+			{
+				"any": []map[string]any{
+					{
+						"all": []map[string]any{
+							{
+								"any": []map[string]any{
+									{
+										"except": map[string]any{
+											"field": map[string]any{
+												"username": "guest",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, rm.Rules)
+
+	// When rule has multiple expressions
+	test.Spec.TypedRules = &RoleMappingRule{
+		Field: RoleMappingFieldExpression{
+			"username": []string{"admin"},
+		},
+		Except: &RoleMappingRule{
+			Field: RoleMappingFieldExpression{
+				"username": []string{"guest"},
+			},
+		},
+	}
+	_, err = test.ToRoleMapping()
+	assert.Error(t.T(), err)
+
+	// When rules and typed rules are set
+	test.Spec.TypedRules = &RoleMappingRule{
+		Field: RoleMappingFieldExpression{
+			"username": []string{"admin"},
+		},
+	}
+	test.Spec.Rules = `{"field": {"username": "admin"}}`
+	_, err = test.ToRoleMapping()
+	assert.Error(t.T(), err)
+
+	// When no rules
+	test.Spec.TypedRules = nil
+	test.Spec.Rules = ""
+	_, err = test.ToRoleMapping()
+	assert.Error(t.T(), err)
+
+	// When no roles
+	test.Spec.Rules = `{"field": {"username": "admin"}}`
+	test.Spec.RoleTemplates = nil
+	_, err = test.ToRoleMapping()
+	assert.Error(t.T(), err)
+}
@@ -18,14 +18,27 @@ package v1alpha1
 
 import (
 	"encoding/json"
+	"fmt"
 
 	olivere "github.com/olivere/elastic/v7"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+const (
+	// UserSecretUsernameKey is the key of Secret that store the username
+	UserSecretUsernameKey = "username"
+
+	// UserSecretPasswordKey is the key of Secret that store the generated password
+	UserSecretPasswordKey = "password"
+
+	// UserSecretURLKey is the key of Secret that store the Elasticsearch URL
+	UserSecretURLKey = "url"
+)
+
 // UserSpec defines the desired state of User
 // +k8s:openapi-gen=true
 type UserSpec struct {
@@ -58,8 +71,24 @@ type UserSpec struct {
 	// +optional
 	PasswordHash string `json:"password_hash,omitempty"`
 
+	// GeneratePassword permit to generate a random password and store it on Secret with the username and the Elasticsearch URL
+	// +optional
+	GeneratePassword *UserGeneratePassword `json:"generatePassword,omitempty"`
+
+	// Rotation permit to generate a new password periodically
+	// It need secret or generatePassword
+	// +optional
+	Rotation *UserRotation `json:"rotation,omitempty"`
+
 	// Roles is the list of roles
+	// It's not used by reserved users
+	// +optional
 	Roles []string `json:"roles"`
+
+	// Reserved permit to manage a built-in reserved user, like `elastic` or `kibana_system`
+	// Only the password and the enabled state are managed, and the user is never deleted
+	// +optional
+	Reserved bool `json:"reserved,omitempty"`
 }
 
 type UserSecret struct {
@@ -71,6 +100,42 @@ type UserSecret struct {
 	Key string `json:"key"`
 }
 
+// UserGeneratePassword is the settings to generate password
+type UserGeneratePassword struct {
+
+	// Length is the password length
+	// +kubebuilder:validation:Minimum=6
+	// +kubebuilder:validation:Maximum=256
+	// +kubebuilder:default=32
+	// +optional
+	Length int64 `json:"length,omitempty"`
+
+	// Charset is the list of characters used to generate password
+	// +kubebuilder:validation:Pattern=`^[!-~]+$`
+	// +kubebuilder:default="abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// +optional
+	Charset string `json:"charset,omitempty"`
+
+	// SecretName is the Secret name where to store the credentials
+	// If empty, it use `<resource name>-credentials`
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// UserRotation is the password rotation policy
+type UserRotation struct {
+
+	// Interval is the duration between 2 rotations
+	// +kubebuilder:default="2160h"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// PreviousSecretName is the Secret name where to keep the previous credentials on rotation
+	// It permit to applications to fallback on them until they load the new password
+	// +optional
+	PreviousSecretName string `json:"previousSecretName,omitempty"`
+}
+
 // UserStatus defines the observed state of User
 type UserStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -78,7 +143,15 @@ type UserStatus struct {
 
 	Conditions []metav1.Condition `json:"conditions"`
 
-	PasswordHash string `json:"passwordHash"`
+	// PasswordFingerprint is the keyed fingerprint of the password set on user
+	// It permit to detect the password change without exposing a hash of the password
+	// +optional
+	PasswordFingerprint string `json:"passwordFingerprint,omitempty"`
+
+	// LastRotationTime is the last time the password was rotated
+	// It's initialized when the rotation is enabled
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -116,8 +189,40 @@ func (h *User) GetStatus() any {
 	return h.Status
 }
 
+// GetGeneratedPasswordSecretName permit to get the Secret name where to store the generated password
+func (h *User) GetGeneratedPasswordSecretName() string {
+	if h.Spec.GeneratePassword != nil && h.Spec.GeneratePassword.SecretName != "" {
+		return h.Spec.GeneratePassword.SecretName
+	}
+
+	return fmt.Sprintf("%s-credentials", h.Name)
+}
+
+// IsPasswordFromSecret permit to know if the password is read from Secret
+// It's the case when the password is generated
+func (h *User) IsPasswordFromSecret() bool {
+	return h.Spec.Secret != nil || h.Spec.GeneratePassword != nil
+}
+
 // ToUser permit to convert to User object
 func (h *User) ToUser() (*olivere.XPackSecurityPutUserRequest, error) {
+	nbPasswordSources := 0
+	if h.Spec.Secret != nil {
+		nbPasswordSources++
+	}
+	if h.Spec.PasswordHash != "" {
+		nbPasswordSources++
+	}
+	if h.Spec.GeneratePassword != nil {
+		nbPasswordSources++
+	}
+	if nbPasswordSources > 1 {
+		return nil, errors.New("You need to set only one of secret, password_hash or generatePassword")
+	}
+	if h.Spec.Rotation != nil && !h.IsPasswordFromSecret() {
+		return nil, errors.New("You need to set secret or generatePassword to use rotation")
+	}
+
 	user := &olivere.XPackSecurityPutUserRequest{
 		Enabled:      h.Spec.Enabled,
 		Email:        h.Spec.Email,
@@ -74,3 +74,48 @@ func (t *V1alpha1TestSuite) TestUserGetStatus() {
 
 	assert.Equal(t.T(), status, test.GetStatus())
 }
+
+func (t *V1alpha1TestSuite) TestUserGetGeneratedPasswordSecretName() {
+	test := &User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: UserSpec{
+			GeneratePassword: &UserGeneratePassword{},
+		},
+	}
+
+	// With default name
+	assert.Equal(t.T(), "test-credentials", test.GetGeneratedPasswordSecretName())
+
+	// With custom name
+	test.Spec.GeneratePassword.SecretName = "custom"
+	assert.Equal(t.T(), "custom", test.GetGeneratedPasswordSecretName())
+}
+
+func (t *V1alpha1TestSuite) TestUserToUser() {
+	test := &User{
+		Spec: UserSpec{
+			Enabled:          true,
+			Roles:            []string{"superuser"},
+			GeneratePassword: &UserGeneratePassword{},
+		},
+	}
+
+	// With generated password
+	user, err := test.ToUser()
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []string{"superuser"}, user.Roles)
+	assert.True(t.T(), test.IsPasswordFromSecret())
+
+	// When multiple password sources
+	test.Spec.PasswordHash = "hash"
+	_, err = test.ToUser()
+	assert.Error(t.T(), err)
+
+	// When rotation without password on Secret
+	test.Spec.GeneratePassword = nil
+	test.Spec.Rotation = &UserRotation{}
+	_, err = test.ToUser()
+	assert.Error(t.T(), err)
+}
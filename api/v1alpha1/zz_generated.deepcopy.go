@@ -23,9 +23,111 @@ package v1alpha1
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchApplicationPrivilege) DeepCopyInto(out *ElasticsearchApplicationPrivilege) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchApplicationPrivilege.
+func (in *ElasticsearchApplicationPrivilege) DeepCopy() *ElasticsearchApplicationPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchApplicationPrivilege)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchApplicationPrivilege) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchApplicationPrivilegeList) DeepCopyInto(out *ElasticsearchApplicationPrivilegeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchApplicationPrivilege, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchApplicationPrivilegeList.
+func (in *ElasticsearchApplicationPrivilegeList) DeepCopy() *ElasticsearchApplicationPrivilegeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchApplicationPrivilegeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchApplicationPrivilegeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchApplicationPrivilegeSpec) DeepCopyInto(out *ElasticsearchApplicationPrivilegeSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchApplicationPrivilegeSpec.
+func (in *ElasticsearchApplicationPrivilegeSpec) DeepCopy() *ElasticsearchApplicationPrivilegeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchApplicationPrivilegeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchApplicationPrivilegeStatus) DeepCopyInto(out *ElasticsearchApplicationPrivilegeStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchApplicationPrivilegeStatus.
+func (in *ElasticsearchApplicationPrivilegeStatus) DeepCopy() *ElasticsearchApplicationPrivilegeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchApplicationPrivilegeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchComponentTemplate) DeepCopyInto(out *ElasticsearchComponentTemplate) {
 	*out = *in
@@ -35,18 +137,2785 @@ func (in *ElasticsearchComponentTemplate) DeepCopyInto(out *ElasticsearchCompone
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplate.
-func (in *ElasticsearchComponentTemplate) DeepCopy() *ElasticsearchComponentTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplate.
+func (in *ElasticsearchComponentTemplate) DeepCopy() *ElasticsearchComponentTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchComponentTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchComponentTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchComponentTemplateList) DeepCopyInto(out *ElasticsearchComponentTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchComponentTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateList.
+func (in *ElasticsearchComponentTemplateList) DeepCopy() *ElasticsearchComponentTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchComponentTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchComponentTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchComponentTemplateSpec) DeepCopyInto(out *ElasticsearchComponentTemplateSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateSpec.
+func (in *ElasticsearchComponentTemplateSpec) DeepCopy() *ElasticsearchComponentTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchComponentTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchComponentTemplateStatus) DeepCopyInto(out *ElasticsearchComponentTemplateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateStatus.
+func (in *ElasticsearchComponentTemplateStatus) DeepCopy() *ElasticsearchComponentTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchComponentTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILM) DeepCopyInto(out *ElasticsearchILM) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILM.
+func (in *ElasticsearchILM) DeepCopy() *ElasticsearchILM {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchILM) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMAllocateAction) DeepCopyInto(out *ElasticsearchILMAllocateAction) {
+	*out = *in
+	if in.NumberOfReplicas != nil {
+		in, out := &in.NumberOfReplicas, &out.NumberOfReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalShardsPerNode != nil {
+		in, out := &in.TotalShardsPerNode, &out.TotalShardsPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Require != nil {
+		in, out := &in.Require, &out.Require
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMAllocateAction.
+func (in *ElasticsearchILMAllocateAction) DeepCopy() *ElasticsearchILMAllocateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMAllocateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMAutoRetry) DeepCopyInto(out *ElasticsearchILMAutoRetry) {
+	*out = *in
+	out.Backoff = in.Backoff
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMAutoRetry.
+func (in *ElasticsearchILMAutoRetry) DeepCopy() *ElasticsearchILMAutoRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMAutoRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMColdActions) DeepCopyInto(out *ElasticsearchILMColdActions) {
+	*out = *in
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(ElasticsearchILMAllocateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(ElasticsearchILMMigrateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ElasticsearchILMEmptyAction)
+		**out = **in
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ElasticsearchILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ElasticsearchILMSetPriorityAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMColdActions.
+func (in *ElasticsearchILMColdActions) DeepCopy() *ElasticsearchILMColdActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMColdActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMColdPhase) DeepCopyInto(out *ElasticsearchILMColdPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMColdPhase.
+func (in *ElasticsearchILMColdPhase) DeepCopy() *ElasticsearchILMColdPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMColdPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMDeleteAction) DeepCopyInto(out *ElasticsearchILMDeleteAction) {
+	*out = *in
+	if in.DeleteSearchableSnapshot != nil {
+		in, out := &in.DeleteSearchableSnapshot, &out.DeleteSearchableSnapshot
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMDeleteAction.
+func (in *ElasticsearchILMDeleteAction) DeepCopy() *ElasticsearchILMDeleteAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMDeleteAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMDeleteActions) DeepCopyInto(out *ElasticsearchILMDeleteActions) {
+	*out = *in
+	if in.WaitForSnapshot != nil {
+		in, out := &in.WaitForSnapshot, &out.WaitForSnapshot
+		*out = new(ElasticsearchILMWaitForSnapshotAction)
+		**out = **in
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(ElasticsearchILMDeleteAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMDeleteActions.
+func (in *ElasticsearchILMDeleteActions) DeepCopy() *ElasticsearchILMDeleteActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMDeleteActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMDeletePhase) DeepCopyInto(out *ElasticsearchILMDeletePhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMDeletePhase.
+func (in *ElasticsearchILMDeletePhase) DeepCopy() *ElasticsearchILMDeletePhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMDeletePhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMEmptyAction) DeepCopyInto(out *ElasticsearchILMEmptyAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMEmptyAction.
+func (in *ElasticsearchILMEmptyAction) DeepCopy() *ElasticsearchILMEmptyAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMEmptyAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMFailedIndex) DeepCopyInto(out *ElasticsearchILMFailedIndex) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMFailedIndex.
+func (in *ElasticsearchILMFailedIndex) DeepCopy() *ElasticsearchILMFailedIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMFailedIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMForcemergeAction) DeepCopyInto(out *ElasticsearchILMForcemergeAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMForcemergeAction.
+func (in *ElasticsearchILMForcemergeAction) DeepCopy() *ElasticsearchILMForcemergeAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMForcemergeAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMFrozenActions) DeepCopyInto(out *ElasticsearchILMFrozenActions) {
+	*out = *in
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ElasticsearchILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMFrozenActions.
+func (in *ElasticsearchILMFrozenActions) DeepCopy() *ElasticsearchILMFrozenActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMFrozenActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMFrozenPhase) DeepCopyInto(out *ElasticsearchILMFrozenPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMFrozenPhase.
+func (in *ElasticsearchILMFrozenPhase) DeepCopy() *ElasticsearchILMFrozenPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMFrozenPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMHotActions) DeepCopyInto(out *ElasticsearchILMHotActions) {
+	*out = *in
+	if in.Rollover != nil {
+		in, out := &in.Rollover, &out.Rollover
+		*out = new(ElasticsearchILMRolloverAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forcemerge != nil {
+		in, out := &in.Forcemerge, &out.Forcemerge
+		*out = new(ElasticsearchILMForcemergeAction)
+		**out = **in
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ElasticsearchILMShrinkAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ElasticsearchILMEmptyAction)
+		**out = **in
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ElasticsearchILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ElasticsearchILMSetPriorityAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMHotActions.
+func (in *ElasticsearchILMHotActions) DeepCopy() *ElasticsearchILMHotActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMHotActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMHotPhase) DeepCopyInto(out *ElasticsearchILMHotPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMHotPhase.
+func (in *ElasticsearchILMHotPhase) DeepCopy() *ElasticsearchILMHotPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMHotPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMInUseBy) DeepCopyInto(out *ElasticsearchILMInUseBy) {
+	*out = *in
+	if in.DataStreams != nil {
+		in, out := &in.DataStreams, &out.DataStreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposableTemplates != nil {
+		in, out := &in.ComposableTemplates, &out.ComposableTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMInUseBy.
+func (in *ElasticsearchILMInUseBy) DeepCopy() *ElasticsearchILMInUseBy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMInUseBy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMList) DeepCopyInto(out *ElasticsearchILMList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchILM, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMList.
+func (in *ElasticsearchILMList) DeepCopy() *ElasticsearchILMList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchILMList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMMigrateAction) DeepCopyInto(out *ElasticsearchILMMigrateAction) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMMigrateAction.
+func (in *ElasticsearchILMMigrateAction) DeepCopy() *ElasticsearchILMMigrateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMMigrateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMPhases) DeepCopyInto(out *ElasticsearchILMPhases) {
+	*out = *in
+	if in.Hot != nil {
+		in, out := &in.Hot, &out.Hot
+		*out = new(ElasticsearchILMHotPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warm != nil {
+		in, out := &in.Warm, &out.Warm
+		*out = new(ElasticsearchILMWarmPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cold != nil {
+		in, out := &in.Cold, &out.Cold
+		*out = new(ElasticsearchILMColdPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(ElasticsearchILMFrozenPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(ElasticsearchILMDeletePhase)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMPhases.
+func (in *ElasticsearchILMPhases) DeepCopy() *ElasticsearchILMPhases {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMPhases)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMRetriedIndex) DeepCopyInto(out *ElasticsearchILMRetriedIndex) {
+	*out = *in
+	in.LastRetryTime.DeepCopyInto(&out.LastRetryTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMRetriedIndex.
+func (in *ElasticsearchILMRetriedIndex) DeepCopy() *ElasticsearchILMRetriedIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMRetriedIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMRolloverAction) DeepCopyInto(out *ElasticsearchILMRolloverAction) {
+	*out = *in
+	if in.MaxDocs != nil {
+		in, out := &in.MaxDocs, &out.MaxDocs
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMRolloverAction.
+func (in *ElasticsearchILMRolloverAction) DeepCopy() *ElasticsearchILMRolloverAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMRolloverAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMSearchableSnapshotAction) DeepCopyInto(out *ElasticsearchILMSearchableSnapshotAction) {
+	*out = *in
+	if in.ForceMergeIndex != nil {
+		in, out := &in.ForceMergeIndex, &out.ForceMergeIndex
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMSearchableSnapshotAction.
+func (in *ElasticsearchILMSearchableSnapshotAction) DeepCopy() *ElasticsearchILMSearchableSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMSearchableSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMSetPriorityAction) DeepCopyInto(out *ElasticsearchILMSetPriorityAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMSetPriorityAction.
+func (in *ElasticsearchILMSetPriorityAction) DeepCopy() *ElasticsearchILMSetPriorityAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMSetPriorityAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMShrinkAction) DeepCopyInto(out *ElasticsearchILMShrinkAction) {
+	*out = *in
+	if in.NumberOfShards != nil {
+		in, out := &in.NumberOfShards, &out.NumberOfShards
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMShrinkAction.
+func (in *ElasticsearchILMShrinkAction) DeepCopy() *ElasticsearchILMShrinkAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMShrinkAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMSpec) DeepCopyInto(out *ElasticsearchILMSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = new(ElasticsearchILMPhases)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoRetry != nil {
+		in, out := &in.AutoRetry, &out.AutoRetry
+		*out = new(ElasticsearchILMAutoRetry)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMSpec.
+func (in *ElasticsearchILMSpec) DeepCopy() *ElasticsearchILMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMStatus) DeepCopyInto(out *ElasticsearchILMStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FailedIndices != nil {
+		in, out := &in.FailedIndices, &out.FailedIndices
+		*out = make([]ElasticsearchILMFailedIndex, len(*in))
+		copy(*out, *in)
+	}
+	if in.InUseBy != nil {
+		in, out := &in.InUseBy, &out.InUseBy
+		*out = new(ElasticsearchILMInUseBy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetriedIndices != nil {
+		in, out := &in.RetriedIndices, &out.RetriedIndices
+		*out = make([]ElasticsearchILMRetriedIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMStatus.
+func (in *ElasticsearchILMStatus) DeepCopy() *ElasticsearchILMStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMWaitForSnapshotAction) DeepCopyInto(out *ElasticsearchILMWaitForSnapshotAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMWaitForSnapshotAction.
+func (in *ElasticsearchILMWaitForSnapshotAction) DeepCopy() *ElasticsearchILMWaitForSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMWaitForSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMWarmActions) DeepCopyInto(out *ElasticsearchILMWarmActions) {
+	*out = *in
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(ElasticsearchILMAllocateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(ElasticsearchILMMigrateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forcemerge != nil {
+		in, out := &in.Forcemerge, &out.Forcemerge
+		*out = new(ElasticsearchILMForcemergeAction)
+		**out = **in
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ElasticsearchILMShrinkAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ElasticsearchILMEmptyAction)
+		**out = **in
+	}
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ElasticsearchILMSetPriorityAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMWarmActions.
+func (in *ElasticsearchILMWarmActions) DeepCopy() *ElasticsearchILMWarmActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMWarmActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchILMWarmPhase) DeepCopyInto(out *ElasticsearchILMWarmPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMWarmPhase.
+func (in *ElasticsearchILMWarmPhase) DeepCopy() *ElasticsearchILMWarmPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchILMWarmPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexTemplate) DeepCopyInto(out *ElasticsearchIndexTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplate.
+func (in *ElasticsearchIndexTemplate) DeepCopy() *ElasticsearchIndexTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchIndexTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexTemplateData) DeepCopyInto(out *ElasticsearchIndexTemplateData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateData.
+func (in *ElasticsearchIndexTemplateData) DeepCopy() *ElasticsearchIndexTemplateData {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexTemplateData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexTemplateList) DeepCopyInto(out *ElasticsearchIndexTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchIndexTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateList.
+func (in *ElasticsearchIndexTemplateList) DeepCopy() *ElasticsearchIndexTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchIndexTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexTemplateSpec) DeepCopyInto(out *ElasticsearchIndexTemplateSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposedOf != nil {
+		in, out := &in.ComposedOf, &out.ComposedOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(ElasticsearchIndexTemplateData)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateSpec.
+func (in *ElasticsearchIndexTemplateSpec) DeepCopy() *ElasticsearchIndexTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchIndexTemplateStatus) DeepCopyInto(out *ElasticsearchIndexTemplateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateStatus.
+func (in *ElasticsearchIndexTemplateStatus) DeepCopy() *ElasticsearchIndexTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchIndexTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLDatafeed) DeepCopyInto(out *ElasticsearchMLDatafeed) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLDatafeed.
+func (in *ElasticsearchMLDatafeed) DeepCopy() *ElasticsearchMLDatafeed {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLDatafeed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMLDatafeed) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLDatafeedList) DeepCopyInto(out *ElasticsearchMLDatafeedList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchMLDatafeed, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLDatafeedList.
+func (in *ElasticsearchMLDatafeedList) DeepCopy() *ElasticsearchMLDatafeedList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLDatafeedList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMLDatafeedList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLDatafeedSpec) DeepCopyInto(out *ElasticsearchMLDatafeedSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScrollSize != nil {
+		in, out := &in.ScrollSize, &out.ScrollSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxEmptySearches != nil {
+		in, out := &in.MaxEmptySearches, &out.MaxEmptySearches
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLDatafeedSpec.
+func (in *ElasticsearchMLDatafeedSpec) DeepCopy() *ElasticsearchMLDatafeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLDatafeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLDatafeedStatus) DeepCopyInto(out *ElasticsearchMLDatafeedStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLDatafeedStatus.
+func (in *ElasticsearchMLDatafeedStatus) DeepCopy() *ElasticsearchMLDatafeedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLDatafeedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLJob) DeepCopyInto(out *ElasticsearchMLJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLJob.
+func (in *ElasticsearchMLJob) DeepCopy() *ElasticsearchMLJob {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMLJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLJobList) DeepCopyInto(out *ElasticsearchMLJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchMLJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLJobList.
+func (in *ElasticsearchMLJobList) DeepCopy() *ElasticsearchMLJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMLJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLJobSpec) DeepCopyInto(out *ElasticsearchMLJobSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ModelSnapshotRetentionDays != nil {
+		in, out := &in.ModelSnapshotRetentionDays, &out.ModelSnapshotRetentionDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DailyModelSnapshotRetentionAfterDays != nil {
+		in, out := &in.DailyModelSnapshotRetentionAfterDays, &out.DailyModelSnapshotRetentionAfterDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResultsRetentionDays != nil {
+		in, out := &in.ResultsRetentionDays, &out.ResultsRetentionDays
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RenormalizationWindowDays != nil {
+		in, out := &in.RenormalizationWindowDays, &out.RenormalizationWindowDays
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLJobSpec.
+func (in *ElasticsearchMLJobSpec) DeepCopy() *ElasticsearchMLJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMLJobStatus) DeepCopyInto(out *ElasticsearchMLJobStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMLJobStatus.
+func (in *ElasticsearchMLJobStatus) DeepCopy() *ElasticsearchMLJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMLJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMountedIndex) DeepCopyInto(out *ElasticsearchMountedIndex) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMountedIndex.
+func (in *ElasticsearchMountedIndex) DeepCopy() *ElasticsearchMountedIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMountedIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMountedIndex) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMountedIndexList) DeepCopyInto(out *ElasticsearchMountedIndexList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchMountedIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMountedIndexList.
+func (in *ElasticsearchMountedIndexList) DeepCopy() *ElasticsearchMountedIndexList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMountedIndexList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchMountedIndexList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMountedIndexSpec) DeepCopyInto(out *ElasticsearchMountedIndexSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.IgnoreIndexSettings != nil {
+		in, out := &in.IgnoreIndexSettings, &out.IgnoreIndexSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMountedIndexSpec.
+func (in *ElasticsearchMountedIndexSpec) DeepCopy() *ElasticsearchMountedIndexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMountedIndexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchMountedIndexStatus) DeepCopyInto(out *ElasticsearchMountedIndexStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchMountedIndexStatus.
+func (in *ElasticsearchMountedIndexStatus) DeepCopy() *ElasticsearchMountedIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchMountedIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRefSpec) DeepCopyInto(out *ElasticsearchRefSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRefSpec.
+func (in *ElasticsearchRefSpec) DeepCopy() *ElasticsearchRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestore) DeepCopyInto(out *ElasticsearchRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestore.
+func (in *ElasticsearchRestore) DeepCopy() *ElasticsearchRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestoreList) DeepCopyInto(out *ElasticsearchRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestoreList.
+func (in *ElasticsearchRestoreList) DeepCopy() *ElasticsearchRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestoreSpec) DeepCopyInto(out *ElasticsearchRestoreSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeAliases != nil {
+		in, out := &in.IncludeAliases, &out.IncludeAliases
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreIndexSettings != nil {
+		in, out := &in.IgnoreIndexSettings, &out.IgnoreIndexSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestoreSpec.
+func (in *ElasticsearchRestoreSpec) DeepCopy() *ElasticsearchRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestoreStatus) DeepCopyInto(out *ElasticsearchRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestoreStatus.
+func (in *ElasticsearchRestoreStatus) DeepCopy() *ElasticsearchRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRole) DeepCopyInto(out *ElasticsearchRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRole.
+func (in *ElasticsearchRole) DeepCopy() *ElasticsearchRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRoleList) DeepCopyInto(out *ElasticsearchRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleList.
+func (in *ElasticsearchRoleList) DeepCopy() *ElasticsearchRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRoleSpec) DeepCopyInto(out *ElasticsearchRoleSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ElasticsearchRoleSpecIndicesPermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]ElasticsearchRoleSpecApplicationPrivileges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunAs != nil {
+		in, out := &in.RunAs, &out.RunAs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpec.
+func (in *ElasticsearchRoleSpec) DeepCopy() *ElasticsearchRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRoleSpecApplicationPrivileges) DeepCopyInto(out *ElasticsearchRoleSpecApplicationPrivileges) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpecApplicationPrivileges.
+func (in *ElasticsearchRoleSpecApplicationPrivileges) DeepCopy() *ElasticsearchRoleSpecApplicationPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRoleSpecApplicationPrivileges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRoleSpecIndicesPermissions) DeepCopyInto(out *ElasticsearchRoleSpecIndicesPermissions) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpecIndicesPermissions.
+func (in *ElasticsearchRoleSpecIndicesPermissions) DeepCopy() *ElasticsearchRoleSpecIndicesPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRoleSpecIndicesPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRoleStatus) DeepCopyInto(out *ElasticsearchRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleStatus.
+func (in *ElasticsearchRoleStatus) DeepCopy() *ElasticsearchRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLM) DeepCopyInto(out *ElasticsearchSLM) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLM.
+func (in *ElasticsearchSLM) DeepCopy() *ElasticsearchSLM {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSLM) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMConfig) DeepCopyInto(out *ElasticsearchSLMConfig) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMConfig.
+func (in *ElasticsearchSLMConfig) DeepCopy() *ElasticsearchSLMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMInvocation) DeepCopyInto(out *ElasticsearchSLMInvocation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMInvocation.
+func (in *ElasticsearchSLMInvocation) DeepCopy() *ElasticsearchSLMInvocation {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMInvocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMList) DeepCopyInto(out *ElasticsearchSLMList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchSLM, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMList.
+func (in *ElasticsearchSLMList) DeepCopy() *ElasticsearchSLMList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSLMList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMRetention) DeepCopyInto(out *ElasticsearchSLMRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMRetention.
+func (in *ElasticsearchSLMRetention) DeepCopy() *ElasticsearchSLMRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMSpec) DeepCopyInto(out *ElasticsearchSLMSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ElasticsearchSLMRetention)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMSpec.
+func (in *ElasticsearchSLMSpec) DeepCopy() *ElasticsearchSLMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMStats) DeepCopyInto(out *ElasticsearchSLMStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMStats.
+func (in *ElasticsearchSLMStats) DeepCopy() *ElasticsearchSLMStats {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSLMStatus) DeepCopyInto(out *ElasticsearchSLMStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccess != nil {
+		in, out := &in.LastSuccess, &out.LastSuccess
+		*out = new(ElasticsearchSLMInvocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(ElasticsearchSLMInvocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextExecution != nil {
+		in, out := &in.NextExecution, &out.NextExecution
+		*out = (*in).DeepCopy()
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(ElasticsearchSLMStats)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMStatus.
+func (in *ElasticsearchSLMStatus) DeepCopy() *ElasticsearchSLMStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSLMStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshot) DeepCopyInto(out *ElasticsearchSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshot.
+func (in *ElasticsearchSnapshot) DeepCopy() *ElasticsearchSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotList) DeepCopyInto(out *ElasticsearchSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotList.
+func (in *ElasticsearchSnapshotList) DeepCopy() *ElasticsearchSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepository) DeepCopyInto(out *ElasticsearchSnapshotRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepository.
+func (in *ElasticsearchSnapshotRepository) DeepCopy() *ElasticsearchSnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSnapshotRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryAzure) DeepCopyInto(out *ElasticsearchSnapshotRepositoryAzure) {
+	*out = *in
+	in.ElasticsearchSnapshotRepositoryCommonSettings.DeepCopyInto(&out.ElasticsearchSnapshotRepositoryCommonSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryAzure.
+func (in *ElasticsearchSnapshotRepositoryAzure) DeepCopy() *ElasticsearchSnapshotRepositoryAzure {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryAzure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryCleanup) DeepCopyInto(out *ElasticsearchSnapshotRepositoryCleanup) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryCleanup.
+func (in *ElasticsearchSnapshotRepositoryCleanup) DeepCopy() *ElasticsearchSnapshotRepositoryCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryCleanupResult) DeepCopyInto(out *ElasticsearchSnapshotRepositoryCleanupResult) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryCleanupResult.
+func (in *ElasticsearchSnapshotRepositoryCleanupResult) DeepCopy() *ElasticsearchSnapshotRepositoryCleanupResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryCleanupResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryCommonSettings) DeepCopyInto(out *ElasticsearchSnapshotRepositoryCommonSettings) {
+	*out = *in
+	if in.Compress != nil {
+		in, out := &in.Compress, &out.Compress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryCommonSettings.
+func (in *ElasticsearchSnapshotRepositoryCommonSettings) DeepCopy() *ElasticsearchSnapshotRepositoryCommonSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryCommonSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryFs) DeepCopyInto(out *ElasticsearchSnapshotRepositoryFs) {
+	*out = *in
+	in.ElasticsearchSnapshotRepositoryCommonSettings.DeepCopyInto(&out.ElasticsearchSnapshotRepositoryCommonSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryFs.
+func (in *ElasticsearchSnapshotRepositoryFs) DeepCopy() *ElasticsearchSnapshotRepositoryFs {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryFs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryGcs) DeepCopyInto(out *ElasticsearchSnapshotRepositoryGcs) {
+	*out = *in
+	in.ElasticsearchSnapshotRepositoryCommonSettings.DeepCopyInto(&out.ElasticsearchSnapshotRepositoryCommonSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryGcs.
+func (in *ElasticsearchSnapshotRepositoryGcs) DeepCopy() *ElasticsearchSnapshotRepositoryGcs {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryGcs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryList) DeepCopyInto(out *ElasticsearchSnapshotRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchSnapshotRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryList.
+func (in *ElasticsearchSnapshotRepositoryList) DeepCopy() *ElasticsearchSnapshotRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchSnapshotRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryS3) DeepCopyInto(out *ElasticsearchSnapshotRepositoryS3) {
+	*out = *in
+	in.ElasticsearchSnapshotRepositoryCommonSettings.DeepCopyInto(&out.ElasticsearchSnapshotRepositoryCommonSettings)
+	if in.ServerSideEncryption != nil {
+		in, out := &in.ServerSideEncryption, &out.ServerSideEncryption
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryS3.
+func (in *ElasticsearchSnapshotRepositoryS3) DeepCopy() *ElasticsearchSnapshotRepositoryS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositorySecretKeyRef) DeepCopyInto(out *ElasticsearchSnapshotRepositorySecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositorySecretKeyRef.
+func (in *ElasticsearchSnapshotRepositorySecretKeyRef) DeepCopy() *ElasticsearchSnapshotRepositorySecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositorySecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositorySecretSetting) DeepCopyInto(out *ElasticsearchSnapshotRepositorySecretSetting) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositorySecretSetting.
+func (in *ElasticsearchSnapshotRepositorySecretSetting) DeepCopy() *ElasticsearchSnapshotRepositorySecretSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositorySecretSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositorySpec) DeepCopyInto(out *ElasticsearchSnapshotRepositorySpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Fs != nil {
+		in, out := &in.Fs, &out.Fs
+		*out = new(ElasticsearchSnapshotRepositoryFs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(ElasticsearchSnapshotRepositoryS3)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gcs != nil {
+		in, out := &in.Gcs, &out.Gcs
+		*out = new(ElasticsearchSnapshotRepositoryGcs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(ElasticsearchSnapshotRepositoryAzure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(ElasticsearchSnapshotRepositoryURL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretSettings != nil {
+		in, out := &in.SecretSettings, &out.SecretSettings
+		*out = make([]ElasticsearchSnapshotRepositorySecretSetting, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cleanup != nil {
+		in, out := &in.Cleanup, &out.Cleanup
+		*out = new(ElasticsearchSnapshotRepositoryCleanup)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositorySpec.
+func (in *ElasticsearchSnapshotRepositorySpec) DeepCopy() *ElasticsearchSnapshotRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryStatus) DeepCopyInto(out *ElasticsearchSnapshotRepositoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastCleanup != nil {
+		in, out := &in.LastCleanup, &out.LastCleanup
+		*out = new(ElasticsearchSnapshotRepositoryCleanupResult)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryStatus.
+func (in *ElasticsearchSnapshotRepositoryStatus) DeepCopy() *ElasticsearchSnapshotRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotRepositoryURL) DeepCopyInto(out *ElasticsearchSnapshotRepositoryURL) {
+	*out = *in
+	in.ElasticsearchSnapshotRepositoryCommonSettings.DeepCopyInto(&out.ElasticsearchSnapshotRepositoryCommonSettings)
+	if in.HTTPMaxRetries != nil {
+		in, out := &in.HTTPMaxRetries, &out.HTTPMaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryURL.
+func (in *ElasticsearchSnapshotRepositoryURL) DeepCopy() *ElasticsearchSnapshotRepositoryURL {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotRepositoryURL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotSpec) DeepCopyInto(out *ElasticsearchSnapshotSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotSpec.
+func (in *ElasticsearchSnapshotSpec) DeepCopy() *ElasticsearchSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSnapshotStatus) DeepCopyInto(out *ElasticsearchSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotStatus.
+func (in *ElasticsearchSnapshotStatus) DeepCopy() *ElasticsearchSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransform) DeepCopyInto(out *ElasticsearchTransform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransform.
+func (in *ElasticsearchTransform) DeepCopy() *ElasticsearchTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchTransform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformDest) DeepCopyInto(out *ElasticsearchTransformDest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformDest.
+func (in *ElasticsearchTransformDest) DeepCopy() *ElasticsearchTransformDest {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformDest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformLatest) DeepCopyInto(out *ElasticsearchTransformLatest) {
+	*out = *in
+	if in.UniqueKey != nil {
+		in, out := &in.UniqueKey, &out.UniqueKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformLatest.
+func (in *ElasticsearchTransformLatest) DeepCopy() *ElasticsearchTransformLatest {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformLatest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformList) DeepCopyInto(out *ElasticsearchTransformList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchTransform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformList.
+func (in *ElasticsearchTransformList) DeepCopy() *ElasticsearchTransformList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchTransformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformRetentionPolicy) DeepCopyInto(out *ElasticsearchTransformRetentionPolicy) {
+	*out = *in
+	out.Time = in.Time
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformRetentionPolicy.
+func (in *ElasticsearchTransformRetentionPolicy) DeepCopy() *ElasticsearchTransformRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformRetentionPolicyTime) DeepCopyInto(out *ElasticsearchTransformRetentionPolicyTime) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformRetentionPolicyTime.
+func (in *ElasticsearchTransformRetentionPolicyTime) DeepCopy() *ElasticsearchTransformRetentionPolicyTime {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformRetentionPolicyTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformSource) DeepCopyInto(out *ElasticsearchTransformSource) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformSource.
+func (in *ElasticsearchTransformSource) DeepCopy() *ElasticsearchTransformSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformSpec) DeepCopyInto(out *ElasticsearchTransformSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	in.Source.DeepCopyInto(&out.Source)
+	out.Dest = in.Dest
+	if in.Latest != nil {
+		in, out := &in.Latest, &out.Latest
+		*out = new(ElasticsearchTransformLatest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(ElasticsearchTransformSync)
+		**out = **in
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(ElasticsearchTransformRetentionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformSpec.
+func (in *ElasticsearchTransformSpec) DeepCopy() *ElasticsearchTransformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformStatus) DeepCopyInto(out *ElasticsearchTransformStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformStatus.
+func (in *ElasticsearchTransformStatus) DeepCopy() *ElasticsearchTransformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformSync) DeepCopyInto(out *ElasticsearchTransformSync) {
+	*out = *in
+	out.Time = in.Time
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformSync.
+func (in *ElasticsearchTransformSync) DeepCopy() *ElasticsearchTransformSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchTransformSyncTime) DeepCopyInto(out *ElasticsearchTransformSyncTime) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchTransformSyncTime.
+func (in *ElasticsearchTransformSyncTime) DeepCopy() *ElasticsearchTransformSyncTime {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchTransformSyncTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchWatcher) DeepCopyInto(out *ElasticsearchWatcher) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcher.
+func (in *ElasticsearchWatcher) DeepCopy() *ElasticsearchWatcher {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchWatcher)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchWatcher) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchWatcherList) DeepCopyInto(out *ElasticsearchWatcherList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchWatcher, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherList.
+func (in *ElasticsearchWatcherList) DeepCopy() *ElasticsearchWatcherList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchWatcherList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchWatcherList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchWatcherSpec) DeepCopyInto(out *ElasticsearchWatcherSpec) {
+	*out = *in
+	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherSpec.
+func (in *ElasticsearchWatcherSpec) DeepCopy() *ElasticsearchWatcherSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchWatcherSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchWatcherStatus) DeepCopyInto(out *ElasticsearchWatcherStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherStatus.
+func (in *ElasticsearchWatcherStatus) DeepCopy() *ElasticsearchWatcherStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchWatcherStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicy) DeepCopyInto(out *FleetAgentPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicy.
+func (in *FleetAgentPolicy) DeepCopy() *FleetAgentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetAgentPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicyList) DeepCopyInto(out *FleetAgentPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetAgentPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicyList.
+func (in *FleetAgentPolicyList) DeepCopy() *FleetAgentPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetAgentPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicySpec) DeepCopyInto(out *FleetAgentPolicySpec) {
+	*out = *in
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.MonitoringEnabled != nil {
+		in, out := &in.MonitoringEnabled, &out.MonitoringEnabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicySpec.
+func (in *FleetAgentPolicySpec) DeepCopy() *FleetAgentPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicyStatus) DeepCopyInto(out *FleetAgentPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicyStatus.
+func (in *FleetAgentPolicyStatus) DeepCopy() *FleetAgentPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPackagePolicy) DeepCopyInto(out *FleetPackagePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPackagePolicy.
+func (in *FleetPackagePolicy) DeepCopy() *FleetPackagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPackagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetPackagePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPackagePolicyList) DeepCopyInto(out *FleetPackagePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetPackagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPackagePolicyList.
+func (in *FleetPackagePolicyList) DeepCopy() *FleetPackagePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPackagePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetPackagePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPackagePolicyPackage) DeepCopyInto(out *FleetPackagePolicyPackage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPackagePolicyPackage.
+func (in *FleetPackagePolicyPackage) DeepCopy() *FleetPackagePolicyPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPackagePolicyPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPackagePolicySpec) DeepCopyInto(out *FleetPackagePolicySpec) {
+	*out = *in
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	out.Package = in.Package
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPackagePolicySpec.
+func (in *FleetPackagePolicySpec) DeepCopy() *FleetPackagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPackagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetPackagePolicyStatus) DeepCopyInto(out *FleetPackagePolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetPackagePolicyStatus.
+func (in *FleetPackagePolicyStatus) DeepCopy() *FleetPackagePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetPackagePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRule) DeepCopyInto(out *KibanaAlertRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRule.
+func (in *KibanaAlertRule) DeepCopy() *KibanaAlertRule {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchComponentTemplate)
+	out := new(KibanaAlertRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchComponentTemplate) DeepCopyObject() runtime.Object {
+func (in *KibanaAlertRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -54,31 +2923,31 @@ func (in *ElasticsearchComponentTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchComponentTemplateList) DeepCopyInto(out *ElasticsearchComponentTemplateList) {
+func (in *KibanaAlertRuleList) DeepCopyInto(out *KibanaAlertRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchComponentTemplate, len(*in))
+		*out = make([]KibanaAlertRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateList.
-func (in *ElasticsearchComponentTemplateList) DeepCopy() *ElasticsearchComponentTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleList.
+func (in *KibanaAlertRuleList) DeepCopy() *KibanaAlertRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchComponentTemplateList)
+	out := new(KibanaAlertRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchComponentTemplateList) DeepCopyObject() runtime.Object {
+func (in *KibanaAlertRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -86,23 +2955,53 @@ func (in *ElasticsearchComponentTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchComponentTemplateSpec) DeepCopyInto(out *ElasticsearchComponentTemplateSpec) {
+func (in *KibanaAlertRuleSpec) DeepCopyInto(out *KibanaAlertRuleSpec) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]KibanaAlertRuleSpecAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateSpec.
-func (in *ElasticsearchComponentTemplateSpec) DeepCopy() *ElasticsearchComponentTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleSpec.
+func (in *KibanaAlertRuleSpec) DeepCopy() *KibanaAlertRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchComponentTemplateSpec)
+	out := new(KibanaAlertRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchComponentTemplateStatus) DeepCopyInto(out *ElasticsearchComponentTemplateStatus) {
+func (in *KibanaAlertRuleSpecAction) DeepCopyInto(out *KibanaAlertRuleSpecAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleSpecAction.
+func (in *KibanaAlertRuleSpecAction) DeepCopy() *KibanaAlertRuleSpecAction {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaAlertRuleSpecAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRuleStatus) DeepCopyInto(out *KibanaAlertRuleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -113,18 +3012,18 @@ func (in *ElasticsearchComponentTemplateStatus) DeepCopyInto(out *ElasticsearchC
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchComponentTemplateStatus.
-func (in *ElasticsearchComponentTemplateStatus) DeepCopy() *ElasticsearchComponentTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleStatus.
+func (in *KibanaAlertRuleStatus) DeepCopy() *KibanaAlertRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchComponentTemplateStatus)
+	out := new(KibanaAlertRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchILM) DeepCopyInto(out *ElasticsearchILM) {
+func (in *KibanaConnector) DeepCopyInto(out *KibanaConnector) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -132,18 +3031,18 @@ func (in *ElasticsearchILM) DeepCopyInto(out *ElasticsearchILM) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILM.
-func (in *ElasticsearchILM) DeepCopy() *ElasticsearchILM {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnector.
+func (in *KibanaConnector) DeepCopy() *KibanaConnector {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchILM)
+	out := new(KibanaConnector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchILM) DeepCopyObject() runtime.Object {
+func (in *KibanaConnector) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -151,31 +3050,31 @@ func (in *ElasticsearchILM) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchILMList) DeepCopyInto(out *ElasticsearchILMList) {
+func (in *KibanaConnectorList) DeepCopyInto(out *KibanaConnectorList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchILM, len(*in))
+		*out = make([]KibanaConnector, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMList.
-func (in *ElasticsearchILMList) DeepCopy() *ElasticsearchILMList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnectorList.
+func (in *KibanaConnectorList) DeepCopy() *KibanaConnectorList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchILMList)
+	out := new(KibanaConnectorList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchILMList) DeepCopyObject() runtime.Object {
+func (in *KibanaConnectorList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -183,23 +3082,43 @@ func (in *ElasticsearchILMList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchILMSpec) DeepCopyInto(out *ElasticsearchILMSpec) {
+func (in *KibanaConnectorSecretRef) DeepCopyInto(out *KibanaConnectorSecretRef) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMSpec.
-func (in *ElasticsearchILMSpec) DeepCopy() *ElasticsearchILMSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnectorSecretRef.
+func (in *KibanaConnectorSecretRef) DeepCopy() *KibanaConnectorSecretRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchILMSpec)
+	out := new(KibanaConnectorSecretRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchILMStatus) DeepCopyInto(out *ElasticsearchILMStatus) {
+func (in *KibanaConnectorSpec) DeepCopyInto(out *KibanaConnectorSpec) {
+	*out = *in
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(KibanaConnectorSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnectorSpec.
+func (in *KibanaConnectorSpec) DeepCopy() *KibanaConnectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaConnectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaConnectorStatus) DeepCopyInto(out *KibanaConnectorStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -210,18 +3129,18 @@ func (in *ElasticsearchILMStatus) DeepCopyInto(out *ElasticsearchILMStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchILMStatus.
-func (in *ElasticsearchILMStatus) DeepCopy() *ElasticsearchILMStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnectorStatus.
+func (in *KibanaConnectorStatus) DeepCopy() *KibanaConnectorStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchILMStatus)
+	out := new(KibanaConnectorStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndexTemplate) DeepCopyInto(out *ElasticsearchIndexTemplate) {
+func (in *KibanaDataView) DeepCopyInto(out *KibanaDataView) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -229,18 +3148,18 @@ func (in *ElasticsearchIndexTemplate) DeepCopyInto(out *ElasticsearchIndexTempla
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplate.
-func (in *ElasticsearchIndexTemplate) DeepCopy() *ElasticsearchIndexTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataView.
+func (in *KibanaDataView) DeepCopy() *KibanaDataView {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchIndexTemplate)
+	out := new(KibanaDataView)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchIndexTemplate) DeepCopyObject() runtime.Object {
+func (in *KibanaDataView) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -248,46 +3167,31 @@ func (in *ElasticsearchIndexTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndexTemplateData) DeepCopyInto(out *ElasticsearchIndexTemplateData) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateData.
-func (in *ElasticsearchIndexTemplateData) DeepCopy() *ElasticsearchIndexTemplateData {
-	if in == nil {
-		return nil
-	}
-	out := new(ElasticsearchIndexTemplateData)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndexTemplateList) DeepCopyInto(out *ElasticsearchIndexTemplateList) {
+func (in *KibanaDataViewList) DeepCopyInto(out *KibanaDataViewList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchIndexTemplate, len(*in))
+		*out = make([]KibanaDataView, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateList.
-func (in *ElasticsearchIndexTemplateList) DeepCopy() *ElasticsearchIndexTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataViewList.
+func (in *KibanaDataViewList) DeepCopy() *KibanaDataViewList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchIndexTemplateList)
+	out := new(KibanaDataViewList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchIndexTemplateList) DeepCopyObject() runtime.Object {
+func (in *KibanaDataViewList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -295,38 +3199,67 @@ func (in *ElasticsearchIndexTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndexTemplateSpec) DeepCopyInto(out *ElasticsearchIndexTemplateSpec) {
+func (in *KibanaDataViewSpec) DeepCopyInto(out *KibanaDataViewSpec) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
-	if in.IndexPatterns != nil {
-		in, out := &in.IndexPatterns, &out.IndexPatterns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.RuntimeFields != nil {
+		in, out := &in.RuntimeFields, &out.RuntimeFields
+		*out = make(map[string]KibanaDataViewSpecRuntimeField, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.ComposedOf != nil {
-		in, out := &in.ComposedOf, &out.ComposedOf
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.FieldFormats != nil {
+		in, out := &in.FieldFormats, &out.FieldFormats
+		*out = make(map[string]KibanaDataViewSpecFieldFormat, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(ElasticsearchIndexTemplateData)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataViewSpec.
+func (in *KibanaDataViewSpec) DeepCopy() *KibanaDataViewSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(KibanaDataViewSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateSpec.
-func (in *ElasticsearchIndexTemplateSpec) DeepCopy() *ElasticsearchIndexTemplateSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaDataViewSpecFieldFormat) DeepCopyInto(out *KibanaDataViewSpecFieldFormat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataViewSpecFieldFormat.
+func (in *KibanaDataViewSpecFieldFormat) DeepCopy() *KibanaDataViewSpecFieldFormat {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchIndexTemplateSpec)
+	out := new(KibanaDataViewSpecFieldFormat)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchIndexTemplateStatus) DeepCopyInto(out *ElasticsearchIndexTemplateStatus) {
+func (in *KibanaDataViewSpecRuntimeField) DeepCopyInto(out *KibanaDataViewSpecRuntimeField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataViewSpecRuntimeField.
+func (in *KibanaDataViewSpecRuntimeField) DeepCopy() *KibanaDataViewSpecRuntimeField {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaDataViewSpecRuntimeField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaDataViewStatus) DeepCopyInto(out *KibanaDataViewStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -337,18 +3270,18 @@ func (in *ElasticsearchIndexTemplateStatus) DeepCopyInto(out *ElasticsearchIndex
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchIndexTemplateStatus.
-func (in *ElasticsearchIndexTemplateStatus) DeepCopy() *ElasticsearchIndexTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaDataViewStatus.
+func (in *KibanaDataViewStatus) DeepCopy() *KibanaDataViewStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchIndexTemplateStatus)
+	out := new(KibanaDataViewStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRefSpec) DeepCopyInto(out *ElasticsearchRefSpec) {
+func (in *KibanaRefSpec) DeepCopyInto(out *KibanaRefSpec) {
 	*out = *in
 	if in.Addresses != nil {
 		in, out := &in.Addresses, &out.Addresses
@@ -357,18 +3290,18 @@ func (in *ElasticsearchRefSpec) DeepCopyInto(out *ElasticsearchRefSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRefSpec.
-func (in *ElasticsearchRefSpec) DeepCopy() *ElasticsearchRefSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRefSpec.
+func (in *KibanaRefSpec) DeepCopy() *KibanaRefSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRefSpec)
+	out := new(KibanaRefSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRole) DeepCopyInto(out *ElasticsearchRole) {
+func (in *KibanaRole) DeepCopyInto(out *KibanaRole) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -376,18 +3309,18 @@ func (in *ElasticsearchRole) DeepCopyInto(out *ElasticsearchRole) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRole.
-func (in *ElasticsearchRole) DeepCopy() *ElasticsearchRole {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRole.
+func (in *KibanaRole) DeepCopy() *KibanaRole {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRole)
+	out := new(KibanaRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchRole) DeepCopyObject() runtime.Object {
+func (in *KibanaRole) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -395,31 +3328,31 @@ func (in *ElasticsearchRole) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRoleList) DeepCopyInto(out *ElasticsearchRoleList) {
+func (in *KibanaRoleList) DeepCopyInto(out *KibanaRoleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchRole, len(*in))
+		*out = make([]KibanaRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleList.
-func (in *ElasticsearchRoleList) DeepCopy() *ElasticsearchRoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRoleList.
+func (in *KibanaRoleList) DeepCopy() *KibanaRoleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRoleList)
+	out := new(KibanaRoleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchRoleList) DeepCopyObject() runtime.Object {
+func (in *KibanaRoleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -427,97 +3360,103 @@ func (in *ElasticsearchRoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRoleSpec) DeepCopyInto(out *ElasticsearchRoleSpec) {
+func (in *KibanaRoleSpec) DeepCopyInto(out *KibanaRoleSpec) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
-	if in.Cluster != nil {
-		in, out := &in.Cluster, &out.Cluster
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Indices != nil {
-		in, out := &in.Indices, &out.Indices
-		*out = make([]ElasticsearchRoleSpecIndicesPermissions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Applications != nil {
-		in, out := &in.Applications, &out.Applications
-		*out = make([]ElasticsearchRoleSpecApplicationPrivileges, len(*in))
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	in.Elasticsearch.DeepCopyInto(&out.Elasticsearch)
+	if in.Kibana != nil {
+		in, out := &in.Kibana, &out.Kibana
+		*out = make([]KibanaRoleSpecKibana, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RunAs != nil {
-		in, out := &in.RunAs, &out.RunAs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpec.
-func (in *ElasticsearchRoleSpec) DeepCopy() *ElasticsearchRoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRoleSpec.
+func (in *KibanaRoleSpec) DeepCopy() *KibanaRoleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRoleSpec)
+	out := new(KibanaRoleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRoleSpecApplicationPrivileges) DeepCopyInto(out *ElasticsearchRoleSpecApplicationPrivileges) {
+func (in *KibanaRoleSpecElasticsearch) DeepCopyInto(out *KibanaRoleSpecElasticsearch) {
 	*out = *in
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ElasticsearchRoleSpecIndicesPermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunAs != nil {
+		in, out := &in.RunAs, &out.RunAs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpecApplicationPrivileges.
-func (in *ElasticsearchRoleSpecApplicationPrivileges) DeepCopy() *ElasticsearchRoleSpecApplicationPrivileges {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRoleSpecElasticsearch.
+func (in *KibanaRoleSpecElasticsearch) DeepCopy() *KibanaRoleSpecElasticsearch {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRoleSpecApplicationPrivileges)
+	out := new(KibanaRoleSpecElasticsearch)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRoleSpecIndicesPermissions) DeepCopyInto(out *ElasticsearchRoleSpecIndicesPermissions) {
+func (in *KibanaRoleSpecKibana) DeepCopyInto(out *KibanaRoleSpecKibana) {
 	*out = *in
-	if in.Names != nil {
-		in, out := &in.Names, &out.Names
+	if in.Base != nil {
+		in, out := &in.Base, &out.Base
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
+	if in.Feature != nil {
+		in, out := &in.Feature, &out.Feature
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleSpecIndicesPermissions.
-func (in *ElasticsearchRoleSpecIndicesPermissions) DeepCopy() *ElasticsearchRoleSpecIndicesPermissions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRoleSpecKibana.
+func (in *KibanaRoleSpecKibana) DeepCopy() *KibanaRoleSpecKibana {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRoleSpecIndicesPermissions)
+	out := new(KibanaRoleSpecKibana)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchRoleStatus) DeepCopyInto(out *ElasticsearchRoleStatus) {
+func (in *KibanaRoleStatus) DeepCopyInto(out *KibanaRoleStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -528,18 +3467,33 @@ func (in *ElasticsearchRoleStatus) DeepCopyInto(out *ElasticsearchRoleStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRoleStatus.
-func (in *ElasticsearchRoleStatus) DeepCopy() *ElasticsearchRoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaRoleStatus.
+func (in *KibanaRoleStatus) DeepCopy() *KibanaRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSavedObjectStatus) DeepCopyInto(out *KibanaSavedObjectStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectStatus.
+func (in *KibanaSavedObjectStatus) DeepCopy() *KibanaSavedObjectStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchRoleStatus)
+	out := new(KibanaSavedObjectStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLM) DeepCopyInto(out *ElasticsearchSLM) {
+func (in *KibanaSavedObjects) DeepCopyInto(out *KibanaSavedObjects) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -547,18 +3501,18 @@ func (in *ElasticsearchSLM) DeepCopyInto(out *ElasticsearchSLM) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLM.
-func (in *ElasticsearchSLM) DeepCopy() *ElasticsearchSLM {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjects.
+func (in *KibanaSavedObjects) DeepCopy() *KibanaSavedObjects {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSLM)
+	out := new(KibanaSavedObjects)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchSLM) DeepCopyObject() runtime.Object {
+func (in *KibanaSavedObjects) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -566,63 +3520,51 @@ func (in *ElasticsearchSLM) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLMConfig) DeepCopyInto(out *ElasticsearchSLMConfig) {
+func (in *KibanaSavedObjectsConfigMapRef) DeepCopyInto(out *KibanaSavedObjectsConfigMapRef) {
 	*out = *in
-	if in.Indices != nil {
-		in, out := &in.Indices, &out.Indices
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.FeatureStates != nil {
-		in, out := &in.FeatureStates, &out.FeatureStates
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMConfig.
-func (in *ElasticsearchSLMConfig) DeepCopy() *ElasticsearchSLMConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsConfigMapRef.
+func (in *KibanaSavedObjectsConfigMapRef) DeepCopy() *KibanaSavedObjectsConfigMapRef {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSLMConfig)
+	out := new(KibanaSavedObjectsConfigMapRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLMList) DeepCopyInto(out *ElasticsearchSLMList) {
+func (in *KibanaSavedObjectsList) DeepCopyInto(out *KibanaSavedObjectsList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchSLM, len(*in))
+		*out = make([]KibanaSavedObjects, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMList.
-func (in *ElasticsearchSLMList) DeepCopy() *ElasticsearchSLMList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsList.
+func (in *KibanaSavedObjectsList) DeepCopy() *KibanaSavedObjectsList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSLMList)
+	out := new(KibanaSavedObjectsList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchSLMList) DeepCopyObject() runtime.Object {
+func (in *KibanaSavedObjectsList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -630,44 +3572,30 @@ func (in *ElasticsearchSLMList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLMRetention) DeepCopyInto(out *ElasticsearchSLMRetention) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMRetention.
-func (in *ElasticsearchSLMRetention) DeepCopy() *ElasticsearchSLMRetention {
-	if in == nil {
-		return nil
-	}
-	out := new(ElasticsearchSLMRetention)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLMSpec) DeepCopyInto(out *ElasticsearchSLMSpec) {
+func (in *KibanaSavedObjectsSpec) DeepCopyInto(out *KibanaSavedObjectsSpec) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
-	in.Config.DeepCopyInto(&out.Config)
-	if in.Retention != nil {
-		in, out := &in.Retention, &out.Retention
-		*out = new(ElasticsearchSLMRetention)
-		**out = **in
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]KibanaSavedObjectsConfigMapRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMSpec.
-func (in *ElasticsearchSLMSpec) DeepCopy() *ElasticsearchSLMSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsSpec.
+func (in *KibanaSavedObjectsSpec) DeepCopy() *KibanaSavedObjectsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSLMSpec)
+	out := new(KibanaSavedObjectsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSLMStatus) DeepCopyInto(out *ElasticsearchSLMStatus) {
+func (in *KibanaSavedObjectsStatus) DeepCopyInto(out *KibanaSavedObjectsStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -676,20 +3604,30 @@ func (in *ElasticsearchSLMStatus) DeepCopyInto(out *ElasticsearchSLMStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]KibanaSavedObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Errors != nil {
+		in, out := &in.Errors, &out.Errors
+		*out = make([]KibanaSavedObjectStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSLMStatus.
-func (in *ElasticsearchSLMStatus) DeepCopy() *ElasticsearchSLMStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsStatus.
+func (in *KibanaSavedObjectsStatus) DeepCopy() *KibanaSavedObjectsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSLMStatus)
+	out := new(KibanaSavedObjectsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSnapshotRepository) DeepCopyInto(out *ElasticsearchSnapshotRepository) {
+func (in *KibanaSpace) DeepCopyInto(out *KibanaSpace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -697,18 +3635,18 @@ func (in *ElasticsearchSnapshotRepository) DeepCopyInto(out *ElasticsearchSnapsh
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepository.
-func (in *ElasticsearchSnapshotRepository) DeepCopy() *ElasticsearchSnapshotRepository {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpace.
+func (in *KibanaSpace) DeepCopy() *KibanaSpace {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSnapshotRepository)
+	out := new(KibanaSpace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchSnapshotRepository) DeepCopyObject() runtime.Object {
+func (in *KibanaSpace) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -716,31 +3654,31 @@ func (in *ElasticsearchSnapshotRepository) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSnapshotRepositoryList) DeepCopyInto(out *ElasticsearchSnapshotRepositoryList) {
+func (in *KibanaSpaceList) DeepCopyInto(out *KibanaSpaceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchSnapshotRepository, len(*in))
+		*out = make([]KibanaSpace, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryList.
-func (in *ElasticsearchSnapshotRepositoryList) DeepCopy() *ElasticsearchSnapshotRepositoryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceList.
+func (in *KibanaSpaceList) DeepCopy() *KibanaSpaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSnapshotRepositoryList)
+	out := new(KibanaSpaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchSnapshotRepositoryList) DeepCopyObject() runtime.Object {
+func (in *KibanaSpaceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -748,23 +3686,28 @@ func (in *ElasticsearchSnapshotRepositoryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSnapshotRepositorySpec) DeepCopyInto(out *ElasticsearchSnapshotRepositorySpec) {
+func (in *KibanaSpaceSpec) DeepCopyInto(out *KibanaSpaceSpec) {
 	*out = *in
-	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	in.KibanaRefSpec.DeepCopyInto(&out.KibanaRefSpec)
+	if in.DisabledFeatures != nil {
+		in, out := &in.DisabledFeatures, &out.DisabledFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositorySpec.
-func (in *ElasticsearchSnapshotRepositorySpec) DeepCopy() *ElasticsearchSnapshotRepositorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceSpec.
+func (in *KibanaSpaceSpec) DeepCopy() *KibanaSpaceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSnapshotRepositorySpec)
+	out := new(KibanaSpaceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchSnapshotRepositoryStatus) DeepCopyInto(out *ElasticsearchSnapshotRepositoryStatus) {
+func (in *KibanaSpaceStatus) DeepCopyInto(out *KibanaSpaceStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -775,18 +3718,18 @@ func (in *ElasticsearchSnapshotRepositoryStatus) DeepCopyInto(out *Elasticsearch
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSnapshotRepositoryStatus.
-func (in *ElasticsearchSnapshotRepositoryStatus) DeepCopy() *ElasticsearchSnapshotRepositoryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceStatus.
+func (in *KibanaSpaceStatus) DeepCopy() *KibanaSpaceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchSnapshotRepositoryStatus)
+	out := new(KibanaSpaceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchWatcher) DeepCopyInto(out *ElasticsearchWatcher) {
+func (in *License) DeepCopyInto(out *License) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -794,18 +3737,18 @@ func (in *ElasticsearchWatcher) DeepCopyInto(out *ElasticsearchWatcher) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcher.
-func (in *ElasticsearchWatcher) DeepCopy() *ElasticsearchWatcher {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new License.
+func (in *License) DeepCopy() *License {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchWatcher)
+	out := new(License)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchWatcher) DeepCopyObject() runtime.Object {
+func (in *License) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -813,31 +3756,31 @@ func (in *ElasticsearchWatcher) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchWatcherList) DeepCopyInto(out *ElasticsearchWatcherList) {
+func (in *LicenseList) DeepCopyInto(out *LicenseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticsearchWatcher, len(*in))
+		*out = make([]License, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherList.
-func (in *ElasticsearchWatcherList) DeepCopy() *ElasticsearchWatcherList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseList.
+func (in *LicenseList) DeepCopy() *LicenseList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchWatcherList)
+	out := new(LicenseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticsearchWatcherList) DeepCopyObject() runtime.Object {
+func (in *LicenseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -845,23 +3788,23 @@ func (in *ElasticsearchWatcherList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchWatcherSpec) DeepCopyInto(out *ElasticsearchWatcherSpec) {
+func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
 	*out = *in
 	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherSpec.
-func (in *ElasticsearchWatcherSpec) DeepCopy() *ElasticsearchWatcherSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseSpec.
+func (in *LicenseSpec) DeepCopy() *LicenseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchWatcherSpec)
+	out := new(LicenseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticsearchWatcherStatus) DeepCopyInto(out *ElasticsearchWatcherStatus) {
+func (in *LicenseStatus) DeepCopyInto(out *LicenseStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -872,18 +3815,18 @@ func (in *ElasticsearchWatcherStatus) DeepCopyInto(out *ElasticsearchWatcherStat
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchWatcherStatus.
-func (in *ElasticsearchWatcherStatus) DeepCopy() *ElasticsearchWatcherStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseStatus.
+func (in *LicenseStatus) DeepCopy() *LicenseStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticsearchWatcherStatus)
+	out := new(LicenseStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *License) DeepCopyInto(out *License) {
+func (in *LogstashPipeline) DeepCopyInto(out *LogstashPipeline) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -891,18 +3834,18 @@ func (in *License) DeepCopyInto(out *License) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new License.
-func (in *License) DeepCopy() *License {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipeline.
+func (in *LogstashPipeline) DeepCopy() *LogstashPipeline {
 	if in == nil {
 		return nil
 	}
-	out := new(License)
+	out := new(LogstashPipeline)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *License) DeepCopyObject() runtime.Object {
+func (in *LogstashPipeline) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -910,31 +3853,46 @@ func (in *License) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseList) DeepCopyInto(out *LicenseList) {
+func (in *LogstashPipelineConfigMapRef) DeepCopyInto(out *LogstashPipelineConfigMapRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineConfigMapRef.
+func (in *LogstashPipelineConfigMapRef) DeepCopy() *LogstashPipelineConfigMapRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LogstashPipelineConfigMapRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogstashPipelineList) DeepCopyInto(out *LogstashPipelineList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]License, len(*in))
+		*out = make([]LogstashPipeline, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseList.
-func (in *LicenseList) DeepCopy() *LicenseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineList.
+func (in *LogstashPipelineList) DeepCopy() *LogstashPipelineList {
 	if in == nil {
 		return nil
 	}
-	out := new(LicenseList)
+	out := new(LogstashPipelineList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LicenseList) DeepCopyObject() runtime.Object {
+func (in *LogstashPipelineList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -942,23 +3900,68 @@ func (in *LicenseList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
+func (in *LogstashPipelineSettings) DeepCopyInto(out *LogstashPipelineSettings) {
+	*out = *in
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.BatchDelay != nil {
+		in, out := &in.BatchDelay, &out.BatchDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.QueueCheckpointWrites != nil {
+		in, out := &in.QueueCheckpointWrites, &out.QueueCheckpointWrites
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineSettings.
+func (in *LogstashPipelineSettings) DeepCopy() *LogstashPipelineSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(LogstashPipelineSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogstashPipelineSpec) DeepCopyInto(out *LogstashPipelineSpec) {
 	*out = *in
 	in.ElasticsearchRefSpec.DeepCopyInto(&out.ElasticsearchRefSpec)
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(LogstashPipelineConfigMapRef)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(LogstashPipelineSettings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseSpec.
-func (in *LicenseSpec) DeepCopy() *LicenseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineSpec.
+func (in *LogstashPipelineSpec) DeepCopy() *LogstashPipelineSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LicenseSpec)
+	out := new(LogstashPipelineSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseStatus) DeepCopyInto(out *LicenseStatus) {
+func (in *LogstashPipelineStatus) DeepCopyInto(out *LogstashPipelineStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -969,12 +3972,12 @@ func (in *LicenseStatus) DeepCopyInto(out *LicenseStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseStatus.
-func (in *LicenseStatus) DeepCopy() *LicenseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineStatus.
+func (in *LogstashPipelineStatus) DeepCopy() *LogstashPipelineStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LicenseStatus)
+	out := new(LogstashPipelineStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1006,6 +4009,35 @@ func (in *RoleMapping) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in RoleMappingFieldExpression) DeepCopyInto(out *RoleMappingFieldExpression) {
+	{
+		in := &in
+		*out = make(RoleMappingFieldExpression, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingFieldExpression.
+func (in RoleMappingFieldExpression) DeepCopy() RoleMappingFieldExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingFieldExpression)
+	in.DeepCopyInto(out)
+	return *out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleMappingList) DeepCopyInto(out *RoleMappingList) {
 	*out = *in
@@ -1038,6 +4070,70 @@ func (in *RoleMappingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingRoleTemplate) DeepCopyInto(out *RoleMappingRoleTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingRoleTemplate.
+func (in *RoleMappingRoleTemplate) DeepCopy() *RoleMappingRoleTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingRoleTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingRule) DeepCopyInto(out *RoleMappingRule) {
+	*out = *in
+	if in.Any != nil {
+		in, out := &in.Any, &out.Any
+		*out = make([]RoleMappingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = make([]RoleMappingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Field != nil {
+		in, out := &in.Field, &out.Field
+		*out = make(RoleMappingFieldExpression, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = new(RoleMappingRule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingRule.
+func (in *RoleMappingRule) DeepCopy() *RoleMappingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RoleMappingSpec) DeepCopyInto(out *RoleMappingSpec) {
 	*out = *in
@@ -1047,6 +4143,16 @@ func (in *RoleMappingSpec) DeepCopyInto(out *RoleMappingSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RoleTemplates != nil {
+		in, out := &in.RoleTemplates, &out.RoleTemplates
+		*out = make([]RoleMappingRoleTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.TypedRules != nil {
+		in, out := &in.TypedRules, &out.TypedRules
+		*out = new(RoleMappingRule)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingSpec.
@@ -1108,6 +4214,21 @@ func (in *User) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserGeneratePassword) DeepCopyInto(out *UserGeneratePassword) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserGeneratePassword.
+func (in *UserGeneratePassword) DeepCopy() *UserGeneratePassword {
+	if in == nil {
+		return nil
+	}
+	out := new(UserGeneratePassword)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserList) DeepCopyInto(out *UserList) {
 	*out = *in
@@ -1140,6 +4261,22 @@ func (in *UserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserRotation) DeepCopyInto(out *UserRotation) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserRotation.
+func (in *UserRotation) DeepCopy() *UserRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSecret) DeepCopyInto(out *UserSecret) {
 	*out = *in
@@ -1164,6 +4301,16 @@ func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 		*out = new(UserSecret)
 		**out = **in
 	}
+	if in.GeneratePassword != nil {
+		in, out := &in.GeneratePassword, &out.GeneratePassword
+		*out = new(UserGeneratePassword)
+		**out = **in
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(UserRotation)
+		**out = **in
+	}
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
@@ -1191,6 +4338,10 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -11,6 +11,8 @@ import (
 	es "github.com/disaster37/operator-elk-extra/pkg/elasticsearch"
 	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
 	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	kb "github.com/disaster37/operator-elk-extra/pkg/kibana"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	elastic "github.com/elastic/go-elasticsearch/v8"
 	"github.com/pkg/errors"
@@ -27,8 +29,11 @@ import (
 
 const (
 	waitDurationWhenError = 1 * time.Minute
+	waitDurationRefresh   = 5 * time.Minute
+	waitDurationProgress  = 30 * time.Second
 	elasticBaseSecret     = "es-elastic-user"
 	elasticBaseService    = "es-http"
+	kibanaBaseService     = "kb-http"
 	name                  = "elk.k8s.webcenter.fr"
 )
 
@@ -37,6 +42,11 @@ type ElasticsearchReferer interface {
 	IsManagedByECK() bool
 }
 
+type KibanaReferer interface {
+	GetKibanaRef() elkv1alpha1.KibanaRefSpec
+	IsManagedByECK() bool
+}
+
 type Reconciler struct {
 	recorder      record.EventRecorder
 	log           *logrus.Entry
@@ -60,12 +70,89 @@ func (r *Reconciler) SetDinamicClient(dc dynamic.Interface) {
 	r.dinamicClient = dc
 }
 
+// requeueToRefreshStatus permit to reconcile again the resource after some time
+// It's needed when the status reflect the state on Elasticsearch and not only the last change
+func requeueToRefreshStatus(o client.Object, res ctrl.Result, err error, duration time.Duration) (ctrl.Result, error) {
+	if err != nil || res != (ctrl.Result{}) {
+		return res, err
+	}
+
+	// Resource not found or being deleted
+	if o.GetName() == "" || !o.GetDeletionTimestamp().IsZero() {
+		return res, err
+	}
+
+	return ctrl.Result{RequeueAfter: duration}, nil
+}
+
+// removeAnnotation permit to remove one-shot annotation from resource
+// It patch a copy of resource, so the status not yet saved is kept
+func removeAnnotation(ctx context.Context, c client.Client, o client.Object, key string) error {
+	patched := o.DeepCopyObject().(client.Object)
+	annotations := patched.GetAnnotations()
+	delete(annotations, key)
+	patched.SetAnnotations(annotations)
+	if err := c.Patch(ctx, patched, client.MergeFrom(o)); err != nil {
+		return err
+	}
+
+	o.SetAnnotations(patched.GetAnnotations())
+	o.SetResourceVersion(patched.GetResourceVersion())
+
+	return nil
+}
+
 func GetElasticsearchHandler(ctx context.Context, resource ElasticsearchReferer, client client.Client, dinamicClient dynamic.Interface, req ctrl.Request, log *logrus.Entry) (esHandler elasticsearchhandler.ElasticsearchHandler, err error) {
 
 	// Retrieve secret or elasticsearch resource that store the connexion credentials
-	secretName := ""
-	hosts := []string{}
-	selfSignedCertificate := false
+	hosts, secretName, selfSignedCertificate, err := getElasticsearchHosts(resource, dinamicClient, req, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read settings to access on Elasticsearch api
+	secret := &core.Secret{}
+	secretNS := types.NamespacedName{
+		Namespace: req.NamespacedName.Namespace,
+		Name:      secretName,
+	}
+	if err = client.Get(ctx, secretNS, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			log.Warnf("Secret %s not yet exist, try later", secretName)
+			return nil, errors.Errorf("Secret %s not yet exist", secretName)
+		}
+		log.Errorf("Error when get resource: %s", err.Error())
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{},
+	}
+	cfg := elastic.Config{
+		Transport: transport,
+		Addresses: hosts,
+	}
+	for user, password := range secret.Data {
+		cfg.Username = user
+		cfg.Password = string(password)
+		break
+	}
+	if selfSignedCertificate {
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	// Create Elasticsearch handler/client
+	esHandler, err = elasticsearchhandler.NewElasticsearchHandler(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return esHandler, nil
+}
+
+// getElasticsearchHosts permit to get the Elasticsearch addresses and the secret name that store the credentials
+func getElasticsearchHosts(resource ElasticsearchReferer, dinamicClient dynamic.Interface, req ctrl.Request, log *logrus.Entry) (hosts []string, secretName string, selfSignedCertificate bool, err error) {
 	if resource.IsManagedByECK() {
 		// From Elasticsearch resource
 		elasticsearch := &es.Elasticsearch{}
@@ -73,13 +160,13 @@ func GetElasticsearchHandler(ctx context.Context, resource ElasticsearchReferer,
 		if err != nil {
 			if k8serrors.IsNotFound(err) {
 				log.Warnf("Elasticsearch %s not yet exist, try later", resource.GetElasticsearchRef().Name)
-				return nil, errors.Errorf("Elasticsearch %s not yet exist", resource.GetElasticsearchRef().Name)
+				return nil, "", false, errors.Errorf("Elasticsearch %s not yet exist", resource.GetElasticsearchRef().Name)
 			}
 			log.Errorf("Error when get resource: %s", err.Error())
-			return nil, err
+			return nil, "", false, err
 		}
 		if err = helpers.UnstructuredToStructured(u, elasticsearch); err != nil {
-			return nil, err
+			return nil, "", false, err
 		}
 
 		// Get secret that store credential
@@ -97,19 +184,66 @@ func GetElasticsearchHandler(ctx context.Context, resource ElasticsearchReferer,
 		hosts = resource.GetElasticsearchRef().Addresses
 	} else {
 		log.Error("You must set the way to connect on Elasticsearch")
-		return nil, errors.New("You must set the way to connect on Elasticsearch")
+		return nil, "", false, errors.New("You must set the way to connect on Elasticsearch")
 	}
 
-	// Read settings to access on Elasticsearch api
-	secret := &core.Secret{}
+	return hosts, secretName, selfSignedCertificate, nil
+}
+
+func GetKibanaHandler(ctx context.Context, resource KibanaReferer, client client.Client, dinamicClient dynamic.Interface, req ctrl.Request, log *logrus.Entry) (kbHandler kibanahandler.KibanaHandler, err error) {
+
+	// Retrieve secret or kibana resource that store the connexion credentials
 	secretNS := types.NamespacedName{
 		Namespace: req.NamespacedName.Namespace,
-		Name:      secretName,
 	}
+	hosts := []string{}
+	selfSignedCertificate := false
+	if resource.IsManagedByECK() {
+		// From Kibana resource
+		kibana := &kb.Kibana{}
+		u, err := dinamicClient.Resource(kb.GVR).Namespace(req.NamespacedName.Namespace).Get(context.Background(), resource.GetKibanaRef().Name, meta.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Warnf("Kibana %s not yet exist, try later", resource.GetKibanaRef().Name)
+				return nil, errors.Errorf("Kibana %s not yet exist", resource.GetKibanaRef().Name)
+			}
+			log.Errorf("Error when get resource: %s", err.Error())
+			return nil, err
+		}
+		if err = helpers.UnstructuredToStructured(u, kibana); err != nil {
+			return nil, err
+		}
+		if kibana.Spec.ElasticsearchRef.Name == "" {
+			return nil, errors.Errorf("Kibana %s is not linked to Elasticsearch", kibana.Name)
+		}
+
+		// Get secret that store credential, it's the elastic user of the Elasticsearch linked to Kibana
+		secretNS.Name = fmt.Sprintf("%s-%s", kibana.Spec.ElasticsearchRef.Name, elasticBaseSecret)
+		if kibana.Spec.ElasticsearchRef.Namespace != "" {
+			secretNS.Namespace = kibana.Spec.ElasticsearchRef.Namespace
+		}
+
+		if kibana.Spec.HTTP.TLS.SelfSignedCertificate.Disabled {
+			hosts = append(hosts, fmt.Sprintf("http://%s-%s.%s:5601", kibana.Name, kibanaBaseService, kibana.Namespace))
+		} else {
+			hosts = append(hosts, fmt.Sprintf("https://%s-%s.%s:5601", kibana.Name, kibanaBaseService, kibana.Namespace))
+			selfSignedCertificate = true
+		}
+
+	} else if len(resource.GetKibanaRef().Addresses) > 0 && resource.GetKibanaRef().SecretName != "" {
+		secretNS.Name = resource.GetKibanaRef().SecretName
+		hosts = resource.GetKibanaRef().Addresses
+	} else {
+		log.Error("You must set the way to connect on Kibana")
+		return nil, errors.New("You must set the way to connect on Kibana")
+	}
+
+	// Read settings to access on Kibana api
+	secret := &core.Secret{}
 	if err = client.Get(ctx, secretNS, secret); err != nil {
 		if k8serrors.IsNotFound(err) {
-			log.Warnf("Secret %s not yet exist, try later", secretName)
-			return nil, errors.Errorf("Secret %s not yet exist", secretName)
+			log.Warnf("Secret %s not yet exist, try later", secretNS.Name)
+			return nil, errors.Errorf("Secret %s not yet exist", secretNS.Name)
 		}
 		log.Errorf("Error when get resource: %s", err.Error())
 		return nil, err
@@ -119,7 +253,7 @@ func GetElasticsearchHandler(ctx context.Context, resource ElasticsearchReferer,
 		Proxy:           http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{},
 	}
-	cfg := elastic.Config{
+	cfg := kibanahandler.Config{
 		Transport: transport,
 		Addresses: hosts,
 	}
@@ -132,11 +266,11 @@ func GetElasticsearchHandler(ctx context.Context, resource ElasticsearchReferer,
 		transport.TLSClientConfig.InsecureSkipVerify = true
 	}
 
-	// Create Elasticsearch handler/client
-	esHandler, err = elasticsearchhandler.NewElasticsearchHandler(cfg, log)
+	// Create Kibana handler/client
+	kbHandler, err = kibanahandler.NewKibanaHandler(cfg, log)
 	if err != nil {
 		return nil, err
 	}
 
-	return esHandler, nil
+	return kbHandler, nil
 }
@@ -0,0 +1,247 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	applicationPrivilegeFinalizer = "application-privilege.elk.k8s.webcenter.fr/finalizer"
+	applicationPrivilegeCondition = "UpdateApplicationPrivilege"
+)
+
+// ElasticsearchApplicationPrivilegeReconciler reconciles a ElasticsearchApplicationPrivilege object
+type ElasticsearchApplicationPrivilegeReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchapplicationprivileges,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchapplicationprivileges/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchapplicationprivileges/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchApplicationPrivilegeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, applicationPrivilegeFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, privilege, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchApplicationPrivilegeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchApplicationPrivilege{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchApplicationPrivilegeReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(privilege.Status.Conditions, applicationPrivilegeCondition) == nil {
+		condition.SetStatusCondition(&privilege.Status.Conditions, v1.Condition{
+			Type:   applicationPrivilegeCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &privilege.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current application privilege
+func (r *ElasticsearchApplicationPrivilegeReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read application privilege from Elasticsearch
+	currentPrivilege, err := esHandler.ApplicationPrivilegeGet(privilege.Spec.Application, privilege.GetPrivilegeName())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get application privilege from Elasticsearch")
+	}
+
+	data["privilege"] = currentPrivilege
+	return res, nil
+}
+
+// Create add new application privilege
+func (r *ElasticsearchApplicationPrivilegeReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+	// Create application privilege on Elasticsearch
+	expectedPrivilege, err := privilege.ToApplicationPrivilege()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to application privilege")
+	}
+	if err = esHandler.ApplicationPrivilegeUpdate(privilege.Spec.Application, privilege.GetPrivilegeName(), expectedPrivilege); err != nil {
+		return res, errors.Wrap(err, "Error when update application privilege")
+	}
+
+	return res, nil
+}
+
+// Update permit to update application privilege from Elasticsearch
+func (r *ElasticsearchApplicationPrivilegeReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return r.Create(ctx, resource, data, meta)
+}
+
+// Delete permit to delete application privilege from Elasticsearch
+func (r *ElasticsearchApplicationPrivilegeReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+	if err = esHandler.ApplicationPrivilegeDelete(privilege.Spec.Application, privilege.GetPrivilegeName()); err != nil {
+		return errors.Wrap(err, "Error when delete application privilege")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected application privilege exist
+func (r *ElasticsearchApplicationPrivilegeReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+	var currentPrivilege *elasticsearchhandler.XPackSecurityApplicationPrivilege
+	var d any
+
+	d, err = helper.Get(data, "privilege")
+	if err != nil {
+		return diff, err
+	}
+	currentPrivilege = d.(*elasticsearchhandler.XPackSecurityApplicationPrivilege)
+	expectedPrivilege, err := privilege.ToApplicationPrivilege()
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentPrivilege == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Application privilege not exist"
+		return diff, nil
+	}
+
+	diffStr, err := esHandler.ApplicationPrivilegeDiff(currentPrivilege, expectedPrivilege)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchApplicationPrivilegeReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&privilege.Status.Conditions, v1.Condition{
+		Type:    applicationPrivilegeCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *ElasticsearchApplicationPrivilegeReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	privilege := resource.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&privilege.Status.Conditions, v1.Condition{
+			Type:    applicationPrivilegeCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Application privilege successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&privilege.Status.Conditions, v1.Condition{
+			Type:    applicationPrivilegeCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Application privilege successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(privilege.Status.Conditions, applicationPrivilegeCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&privilege.Status.Conditions, v1.Condition{
+			Type:    applicationPrivilegeCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Application privilege already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Application privilege already set")
+	}
+
+	return nil
+}
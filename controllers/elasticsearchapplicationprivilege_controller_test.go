@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchApplicationPrivilegeReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-app-privilege-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, privilege, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateApplicationPrivilegeStep(),
+		doUpdateApplicationPrivilegeStep(),
+		doDeleteApplicationPrivilegeStep(),
+	}
+	testCase.PreTest = doMockApplicationPrivilege(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockApplicationPrivilege(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockES.EXPECT().ApplicationPrivilegeGet(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(application, name string) (*elasticsearchhandler.XPackSecurityApplicationPrivilege, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &elasticsearchhandler.XPackSecurityApplicationPrivilege{
+						Actions: []string{"data:read/*"},
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &elasticsearchhandler.XPackSecurityApplicationPrivilege{
+						Actions: []string{"data:read/*"},
+					}
+					return resp, nil
+				} else {
+					resp := &elasticsearchhandler.XPackSecurityApplicationPrivilege{
+						Actions: []string{"data:read/*", "action:login"},
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().ApplicationPrivilegeDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.XPackSecurityApplicationPrivilege) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockES.EXPECT().ApplicationPrivilegeUpdate(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(application, name string, privilege *elasticsearchhandler.XPackSecurityApplicationPrivilege) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockES.EXPECT().ApplicationPrivilegeDelete(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(application, name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateApplicationPrivilegeStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new application privilege %s/%s ===", key.Namespace, key.Name)
+
+			privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchApplicationPrivilegeSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Application: "myapp",
+					Actions:     []string{"data:read/*"},
+				},
+			}
+			if err = c.Create(context.Background(), privilege); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, privilege); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get application privilege: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(privilege.Status.Conditions, applicationPrivilegeCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateApplicationPrivilegeStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update application privilege %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Application privilege is null")
+			}
+			privilege := o.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+			privilege.Spec.Actions = []string{"data:read/*", "action:login"}
+			if err = c.Update(context.Background(), privilege); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, privilege); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get application privilege: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(privilege.Status.Conditions, applicationPrivilegeCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteApplicationPrivilegeStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete application privilege %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Application privilege is null")
+			}
+			privilege := o.(*elkv1alpha1.ElasticsearchApplicationPrivilege)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), privilege, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			privilege := &elkv1alpha1.ElasticsearchApplicationPrivilege{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, privilege); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Application privilege stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -18,7 +18,8 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"sort"
 
 	core "k8s.io/api/core/v1"
 	condition "k8s.io/apimachinery/pkg/api/meta"
@@ -37,8 +38,10 @@ import (
 )
 
 const (
-	ilmFinalizer = "ilm.elk.k8s.webcenter.fr/finalizer"
-	ilmCondition = "UpdateILMPolicy"
+	ilmFinalizer        = "ilm.elk.k8s.webcenter.fr/finalizer"
+	ilmCondition        = "UpdateILMPolicy"
+	ilmHealthyCondition = "PolicyHealthy"
+	ilmRetryAnnotation  = "elk.k8s.webcenter.fr/ilm-retry"
 )
 
 // ElasticsearchILMReconciler reconciles a ElasticsearchILM object
@@ -70,7 +73,8 @@ func (r *ElasticsearchILMReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	ilm := &elkv1alpha1.ElasticsearchILM{}
 	data := map[string]any{}
 
-	return reconciler.Reconcile(ctx, req, ilm, data)
+	res, err := reconciler.Reconcile(ctx, req, ilm, data)
+	return requeueToRefreshStatus(ilm, res, err, waitDurationRefresh)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -116,6 +120,17 @@ func (r *ElasticsearchILMReconciler) Read(ctx context.Context, resource resource
 	}
 
 	data["policy"] = ilmPolicy
+
+	// Read the indices that use the policy
+	var explain *elasticsearchhandler.ILMPolicyExplain
+	if ilmPolicy != nil {
+		explain, err = esHandler.ILMExplain(ilm.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to explain ILM policy from Elasticsearch")
+		}
+	}
+	data["explain"] = explain
+
 	return res, nil
 }
 
@@ -124,11 +139,11 @@ func (r *ElasticsearchILMReconciler) Create(ctx context.Context, resource resour
 
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	ilm := resource.(*elkv1alpha1.ElasticsearchILM)
-	policy := &olivere.XPackIlmGetLifecycleResponse{}
 
 	// Create policy on Elasticsearch
-	if err = json.Unmarshal([]byte(ilm.Spec.Policy), &policy); err != nil {
-		return res, errors.Wrap(err, "Error on Policy format")
+	policy, err := ilm.ToPolicy()
+	if err != nil {
+		return res, err
 	}
 	if err = esHandler.ILMUpdate(ilm.Name, policy); err != nil {
 		return res, errors.Wrap(err, "Error when update policy")
@@ -159,7 +174,6 @@ func (r *ElasticsearchILMReconciler) Delete(ctx context.Context, resource resour
 func (r *ElasticsearchILMReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	ilm := resource.(*elkv1alpha1.ElasticsearchILM)
-	expectedPolicy := &olivere.XPackIlmGetLifecycleResponse{}
 	var currentPolicy *olivere.XPackIlmGetLifecycleResponse
 	var d any
 
@@ -168,7 +182,8 @@ func (r *ElasticsearchILMReconciler) Diff(resource resource.Resource, data map[s
 		return diff, err
 	}
 	currentPolicy = d.(*olivere.XPackIlmGetLifecycleResponse)
-	if err = json.Unmarshal([]byte(ilm.Spec.Policy), expectedPolicy); err != nil {
+	expectedPolicy, err := ilm.ToPolicy()
+	if err != nil {
 		return diff, err
 	}
 
@@ -215,6 +230,13 @@ func (r *ElasticsearchILMReconciler) OnError(ctx context.Context, resource resou
 func (r *ElasticsearchILMReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
 	ilm := resource.(*elkv1alpha1.ElasticsearchILM)
 
+	if d, ok := data["explain"]; ok && d.(*elasticsearchhandler.ILMPolicyExplain) != nil {
+		r.updateUsageStatus(ilm, d.(*elasticsearchhandler.ILMPolicyExplain))
+		if err = r.retryFailedIndices(ctx, ilm, meta.(elasticsearchhandler.ElasticsearchHandler)); err != nil {
+			return err
+		}
+	}
+
 	if diff.NeedCreate {
 		condition.SetStatusCondition(&ilm.Status.Conditions, v1.Condition{
 			Type:    ilmCondition,
@@ -251,3 +273,134 @@ func (r *ElasticsearchILMReconciler) OnSuccess(ctx context.Context, resource res
 
 	return nil
 }
+
+// updateUsageStatus permit to set the usage of policy and the indices stuck on ERROR step on status
+func (r *ElasticsearchILMReconciler) updateUsageStatus(ilm *elkv1alpha1.ElasticsearchILM, explain *elasticsearchhandler.ILMPolicyExplain) {
+	ilm.Status.InUseBy = &elkv1alpha1.ElasticsearchILMInUseBy{
+		Indices:             int64(len(explain.InUseBy.Indices)),
+		DataStreams:         explain.InUseBy.DataStreams,
+		ComposableTemplates: explain.InUseBy.ComposableTemplates,
+	}
+
+	var phases map[string]int64
+	var failedIndices []elkv1alpha1.ElasticsearchILMFailedIndex
+	for _, index := range explain.Indices {
+		if !index.Managed || index.Policy != ilm.Name {
+			continue
+		}
+		if index.Phase != "" {
+			if phases == nil {
+				phases = map[string]int64{}
+			}
+			phases[index.Phase]++
+		}
+		if index.Step == "ERROR" {
+			failedIndices = append(failedIndices, elkv1alpha1.ElasticsearchILMFailedIndex{
+				Index:      index.Index,
+				Phase:      index.Phase,
+				Action:     index.Action,
+				FailedStep: index.FailedStep,
+				Reason:     ilmStepErrorReason(index.StepInfo),
+				RetryCount: index.FailedStepRetryCount,
+			})
+		}
+	}
+	sort.Slice(failedIndices, func(i, j int) bool {
+		return failedIndices[i].Index < failedIndices[j].Index
+	})
+
+	ilm.Status.Phases = phases
+	ilm.Status.FailedIndices = failedIndices
+
+	// Forget the retried indices that are not stuck anymore
+	var retriedIndices []elkv1alpha1.ElasticsearchILMRetriedIndex
+	for _, retried := range ilm.Status.RetriedIndices {
+		if index, ok := explain.Indices[retried.Index]; ok && (index.Step == "ERROR" || index.Step == retried.FailedStep) {
+			retriedIndices = append(retriedIndices, retried)
+		}
+	}
+	ilm.Status.RetriedIndices = retriedIndices
+
+	if len(failedIndices) > 0 {
+		if !condition.IsStatusConditionFalse(ilm.Status.Conditions, ilmHealthyCondition) {
+			r.recorder.Eventf(ilm, core.EventTypeWarning, "StepError", "%d indices stuck on ERROR step", len(failedIndices))
+		}
+		condition.SetStatusCondition(&ilm.Status.Conditions, v1.Condition{
+			Type:    ilmHealthyCondition,
+			Status:  v1.ConditionFalse,
+			Reason:  "StepError",
+			Message: fmt.Sprintf("%d indices stuck on ERROR step", len(failedIndices)),
+		})
+	} else {
+		condition.SetStatusCondition(&ilm.Status.Conditions, v1.Condition{
+			Type:    ilmHealthyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Healthy",
+			Message: "No index stuck on ERROR step",
+		})
+	}
+}
+
+// retryFailedIndices permit to retry the failed step of indices stuck on ERROR step
+// All indices are retried when the retry annotation is set, else it follow the auto retry policy
+func (r *ElasticsearchILMReconciler) retryFailedIndices(ctx context.Context, ilm *elkv1alpha1.ElasticsearchILM, esHandler elasticsearchhandler.ElasticsearchHandler) (err error) {
+	_, forceRetry := ilm.Annotations[ilmRetryAnnotation]
+	if !forceRetry && ilm.Spec.AutoRetry == nil {
+		return nil
+	}
+
+	now := v1.Now()
+	for _, failedIndex := range ilm.Status.FailedIndices {
+		var retried *elkv1alpha1.ElasticsearchILMRetriedIndex
+		for i := range ilm.Status.RetriedIndices {
+			if ilm.Status.RetriedIndices[i].Index == failedIndex.Index && ilm.Status.RetriedIndices[i].FailedStep == failedIndex.FailedStep {
+				retried = &ilm.Status.RetriedIndices[i]
+				break
+			}
+		}
+
+		if !forceRetry && retried != nil {
+			if retried.Attempts >= ilm.Spec.AutoRetry.MaxAttempts {
+				r.log.Debugf("Max retry attempts reached for index %s", failedIndex.Index)
+				continue
+			}
+			if now.Sub(retried.LastRetryTime.Time) < ilm.Spec.AutoRetry.Backoff.Duration {
+				continue
+			}
+		}
+
+		if err = esHandler.ILMRetry(failedIndex.Index); err != nil {
+			return errors.Wrapf(err, "Error when retry failed step of index %s", failedIndex.Index)
+		}
+
+		if retried == nil {
+			ilm.Status.RetriedIndices = append(ilm.Status.RetriedIndices, elkv1alpha1.ElasticsearchILMRetriedIndex{
+				Index:      failedIndex.Index,
+				FailedStep: failedIndex.FailedStep,
+			})
+			retried = &ilm.Status.RetriedIndices[len(ilm.Status.RetriedIndices)-1]
+		}
+		retried.Attempts++
+		retried.LastRetryTime = now
+
+		r.recorder.Eventf(ilm, core.EventTypeNormal, "Retry", "Retry failed step %s of index %s (attempt %d)", failedIndex.FailedStep, failedIndex.Index, retried.Attempts)
+	}
+
+	// The retry annotation is one-shot
+	if forceRetry {
+		if err = removeAnnotation(ctx, r.Client, ilm, ilmRetryAnnotation); err != nil {
+			return errors.Wrap(err, "Error when remove retry annotation")
+		}
+	}
+
+	return nil
+}
+
+// ilmStepErrorReason permit to get the error from step info of failed index
+func ilmStepErrorReason(stepInfo map[string]any) string {
+	reason, _ := stepInfo["reason"].(string)
+	if errorType, ok := stepInfo["type"].(string); ok {
+		return fmt.Sprintf("%s: %s", errorType, reason)
+	}
+	return reason
+}
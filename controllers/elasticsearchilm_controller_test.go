@@ -7,6 +7,7 @@ import (
 	"time"
 
 	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
 	"github.com/disaster37/operator-elk-extra/pkg/helpers"
 	"github.com/disaster37/operator-elk-extra/pkg/mocks"
 	"github.com/disaster37/operator-sdk-extra/pkg/test"
@@ -35,6 +36,7 @@ func (t *ControllerTestSuite) TestElasticsearchILMReconciler() {
 	testCase.Steps = []test.TestStep{
 		doCreateILMStep(),
 		doUpdateILMStep(),
+		doRetryILMStep(),
 		doDeleteILMStep(),
 	}
 	testCase.PreTest = doMockILM(t.mockElasticsearchHandler)
@@ -185,6 +187,45 @@ func doMockILM(mockES *mocks.MockElasticsearchHandler) func(stepName *string, da
 
 		})
 
+		mockES.EXPECT().ILMExplain(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.ILMPolicyExplain, error) {
+			return &elasticsearchhandler.ILMPolicyExplain{
+				InUseBy: elasticsearchhandler.ILMPolicyInUseBy{
+					Indices:     []string{"logs-1", "logs-2"},
+					DataStreams: []string{"logs"},
+				},
+				Indices: map[string]elasticsearchhandler.ILMIndexExplain{
+					"logs-1": {
+						Index:   "logs-1",
+						Managed: true,
+						Policy:  name,
+						Phase:   "hot",
+						Action:  "rollover",
+						Step:    "check-rollover-ready",
+					},
+					"logs-2": {
+						Index:      "logs-2",
+						Managed:    true,
+						Policy:     name,
+						Phase:      "hot",
+						Action:     "rollover",
+						Step:       "ERROR",
+						FailedStep: "check-rollover-ready",
+						StepInfo: map[string]any{
+							"type":   "illegal_argument_exception",
+							"reason": "rollover target [logs] does not point to a write index",
+						},
+					},
+				},
+			}, nil
+		})
+
+		mockES.EXPECT().ILMRetry(gomock.Any()).AnyTimes().DoAndReturn(func(index string) error {
+			if *stepName == "retry" {
+				data["isRetried"] = true
+			}
+			return nil
+		})
+
 		mockES.EXPECT().ILMDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
 			data["isDeleted"] = true
 			return nil
@@ -276,28 +317,26 @@ func doUpdateILMStep() test.TestStep {
 			}
 			ilm := o.(*elkv1alpha1.ElasticsearchILM)
 
-			ilm.Spec.Policy = `{
-				"policy": {
-					"phases": {
-						"warm": {
-							"min_age": "30d",
-							"actions": {
-								"forcemerge": {
-									"max_num_segments": 1
-								}
-							}
+			deleteSearchableSnapshot := true
+			ilm.Spec.Policy = ""
+			ilm.Spec.Phases = &elkv1alpha1.ElasticsearchILMPhases{
+				Warm: &elkv1alpha1.ElasticsearchILMWarmPhase{
+					MinAge: "30d",
+					Actions: elkv1alpha1.ElasticsearchILMWarmActions{
+						Forcemerge: &elkv1alpha1.ElasticsearchILMForcemergeAction{
+							MaxNumSegments: 1,
 						},
-						"delete": {
-							"min_age": "31d",
-							"actions": {
-								"delete": {
-									"delete_searchable_snapshot": true
-								}
-							}
-						}
-					}
-				}
-			}`
+					},
+				},
+				Delete: &elkv1alpha1.ElasticsearchILMDeletePhase{
+					MinAge: "31d",
+					Actions: elkv1alpha1.ElasticsearchILMDeleteActions{
+						Delete: &elkv1alpha1.ElasticsearchILMDeleteAction{
+							DeleteSearchableSnapshot: &deleteSearchableSnapshot,
+						},
+					},
+				},
+			}
 			if err = c.Update(context.Background(), ilm); err != nil {
 				return err
 			}
@@ -318,6 +357,9 @@ func doUpdateILMStep() test.TestStep {
 				if !isUpdated {
 					return errors.New("Not yet updated")
 				}
+				if condition.FindStatusCondition(ilm.Status.Conditions, ilmHealthyCondition) == nil {
+					return errors.New("Not yet explained")
+				}
 				return nil
 			}, time.Second*30, time.Second*1)
 
@@ -325,6 +367,66 @@ func doUpdateILMStep() test.TestStep {
 				return errors.Wrapf(err, "Failed to get ILM")
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(ilm.Status.Conditions, ilmCondition, metav1.ConditionTrue))
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(ilm.Status.Conditions, ilmHealthyCondition, metav1.ConditionFalse))
+			assert.Equal(t, map[string]int64{"hot": 2}, ilm.Status.Phases)
+			assert.Equal(t, 1, len(ilm.Status.FailedIndices))
+			assert.Equal(t, "check-rollover-ready", ilm.Status.FailedIndices[0].FailedStep)
+			assert.Equal(t, int64(2), ilm.Status.InUseBy.Indices)
+
+			return nil
+		},
+	}
+}
+
+func doRetryILMStep() test.TestStep {
+	return test.TestStep{
+		Name: "retry",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Retry ILM policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ILM is null")
+			}
+			ilm := o.(*elkv1alpha1.ElasticsearchILM)
+
+			if ilm.Annotations == nil {
+				ilm.Annotations = map[string]string{}
+			}
+			ilm.Annotations[ilmRetryAnnotation] = "true"
+			if err = c.Update(context.Background(), ilm); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) error {
+			ilm := &elkv1alpha1.ElasticsearchILM{}
+			isRetried := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, ilm); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isRetried"]; ok {
+					isRetried = b.(bool)
+				}
+				if !isRetried {
+					return errors.New("Not yet retried")
+				}
+				if _, ok := ilm.Annotations[ilmRetryAnnotation]; ok {
+					return errors.New("Retry annotation not yet removed")
+				}
+				if len(ilm.Status.RetriedIndices) == 0 {
+					return errors.New("Retried indices not yet reported")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+
+			if err != nil || isTimeout {
+				return errors.Wrapf(err, "Failed to get ILM")
+			}
+			assert.Equal(t, "logs-2", ilm.Status.RetriedIndices[0].Index)
+			assert.Equal(t, int64(1), ilm.Status.RetriedIndices[0].Attempts)
 
 			return nil
 		},
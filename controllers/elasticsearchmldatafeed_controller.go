@@ -0,0 +1,375 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	mlDatafeedFinalizer = "mldatafeed.elk.k8s.webcenter.fr/finalizer"
+	mlDatafeedCondition = "UpdateMLDatafeed"
+)
+
+// ElasticsearchMLDatafeedReconciler reconciles a ElasticsearchMLDatafeed object
+type ElasticsearchMLDatafeedReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmldatafeeds,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmldatafeeds/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmldatafeeds/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The datafeed is reconciled periodically to keep the status up to date with the stats.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchMLDatafeedReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, mlDatafeedFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, datafeed, data)
+	return requeueToRefreshStatus(datafeed, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchMLDatafeedReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchMLDatafeed{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchMLDatafeedReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(datafeed.Status.Conditions, mlDatafeedCondition) == nil {
+		condition.SetStatusCondition(&datafeed.Status.Conditions, v1.Condition{
+			Type:   mlDatafeedCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &datafeed.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current datafeed and its stats
+func (r *ElasticsearchMLDatafeedReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read datafeed from Elasticsearch
+	currentDatafeed, err := esHandler.MLDatafeedGet(datafeed.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get ML datafeed from Elasticsearch")
+	}
+	data["datafeed"] = currentDatafeed
+
+	// Read datafeed stats from Elasticsearch
+	var stats *elasticsearchhandler.MLDatafeedStats
+	if currentDatafeed != nil {
+		stats, err = esHandler.MLDatafeedStats(datafeed.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get ML datafeed stats from Elasticsearch")
+		}
+	}
+	data["stats"] = stats
+
+	return res, nil
+}
+
+// Create add new datafeed and start it if needed
+func (r *ElasticsearchMLDatafeedReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	expectedDatafeed, err := datafeed.ToDatafeed()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert ML datafeed")
+	}
+
+	// Before create datafeed, check if job already exist
+	job, err := esHandler.MLJobGet(datafeed.Spec.JobID)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get ML job to check if exist before create datafeed")
+	}
+	if job == nil {
+		r.log.Warnf("ML job %s not yet exist, skip it", datafeed.Spec.JobID)
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Skip", "ML job %s not yet exist, wait it", datafeed.Spec.JobID)
+		return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+	}
+
+	// Create datafeed on Elasticsearch
+	if err = esHandler.MLDatafeedCreate(datafeed.Name, expectedDatafeed); err != nil {
+		return res, errors.Wrap(err, "Error when create ML datafeed")
+	}
+
+	if datafeed.IsStarted() {
+		return r.start(resource, esHandler)
+	}
+
+	return res, nil
+}
+
+// Update permit to update datafeed from Elasticsearch
+// The datafeed is stopped during the update, because the changes are applied only on start
+func (r *ElasticsearchMLDatafeedReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	expectedDatafeed, err := datafeed.ToDatafeed()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert ML datafeed")
+	}
+
+	var stats *elasticsearchhandler.MLDatafeedStats
+	if d, ok := data["stats"]; ok {
+		stats = d.(*elasticsearchhandler.MLDatafeedStats)
+	}
+	isRunning := isMLDatafeedStarted(stats)
+
+	if needUpdate, ok := data["needUpdate"].(bool); ok && needUpdate {
+		if isRunning {
+			if err = esHandler.MLDatafeedStop(datafeed.Name); err != nil {
+				return res, errors.Wrap(err, "Error when stop ML datafeed")
+			}
+			isRunning = false
+		}
+
+		if err = esHandler.MLDatafeedUpdate(datafeed.Name, expectedDatafeed); err != nil {
+			return res, errors.Wrap(err, "Error when update ML datafeed")
+		}
+	}
+
+	// Align the datafeed state
+	if datafeed.IsStarted() && !isRunning {
+		if res, err = r.start(resource, esHandler); err != nil || res != (ctrl.Result{}) {
+			return res, err
+		}
+		r.recorder.Event(resource, core.EventTypeNormal, "Started", "ML datafeed started")
+	} else if !datafeed.IsStarted() && isRunning {
+		if err = esHandler.MLDatafeedStop(datafeed.Name); err != nil {
+			return res, errors.Wrap(err, "Error when stop ML datafeed")
+		}
+		r.recorder.Event(resource, core.EventTypeNormal, "Stopped", "ML datafeed stopped")
+	}
+
+	return res, nil
+}
+
+// Delete permit to stop and delete datafeed from Elasticsearch
+func (r *ElasticsearchMLDatafeedReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	if err = esHandler.MLDatafeedStop(datafeed.Name); err != nil {
+		return errors.Wrap(err, "Error when stop ML datafeed")
+	}
+
+	if err = esHandler.MLDatafeedDelete(datafeed.Name); err != nil {
+		return errors.Wrap(err, "Error when delete ML datafeed")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected datafeed exist
+// It also check if the datafeed is on the expected state
+func (r *ElasticsearchMLDatafeedReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+	var currentDatafeed *elasticsearchhandler.MLDatafeed
+	var stats *elasticsearchhandler.MLDatafeedStats
+	var d any
+
+	d, err = helper.Get(data, "datafeed")
+	if err != nil {
+		return diff, err
+	}
+	currentDatafeed = d.(*elasticsearchhandler.MLDatafeed)
+
+	d, err = helper.Get(data, "stats")
+	if err != nil {
+		return diff, err
+	}
+	stats = d.(*elasticsearchhandler.MLDatafeedStats)
+
+	expectedDatafeed, err := datafeed.ToDatafeed()
+	if err != nil {
+		return diff, errors.Wrap(err, "Error when convert ML datafeed")
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentDatafeed == nil {
+		diff.NeedCreate = true
+		diff.Diff = "ML datafeed not exist"
+		return diff, nil
+	}
+
+	if currentDatafeed.JobID != expectedDatafeed.JobID {
+		return diff, errors.Errorf("job_id can't be updated on existing ML datafeed (%s -> %s)", currentDatafeed.JobID, expectedDatafeed.JobID)
+	}
+
+	diffStr, err := esHandler.MLDatafeedDiff(currentDatafeed, expectedDatafeed)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		data["needUpdate"] = true
+	}
+
+	if datafeed.IsStarted() != isMLDatafeedStarted(stats) {
+		diff.NeedUpdate = true
+		diff.Diff = fmt.Sprintf("%sML datafeed must be %s\n", diff.Diff, datafeed.Spec.State)
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchMLDatafeedReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&datafeed.Status.Conditions, v1.Condition{
+		Type:    mlDatafeedCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from datafeed stats
+func (r *ElasticsearchMLDatafeedReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	if d, ok := data["stats"]; ok && d.(*elasticsearchhandler.MLDatafeedStats) != nil {
+		datafeed.Status.State = d.(*elasticsearchhandler.MLDatafeedStats).State
+	}
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&datafeed.Status.Conditions, v1.Condition{
+			Type:    mlDatafeedCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "ML datafeed successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&datafeed.Status.Conditions, v1.Condition{
+			Type:    mlDatafeedCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "ML datafeed successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(datafeed.Status.Conditions, mlDatafeedCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&datafeed.Status.Conditions, v1.Condition{
+			Type:    mlDatafeedCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "ML datafeed already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "ML datafeed already set")
+	}
+
+	return nil
+}
+
+// start permit to start the datafeed when the job is opened
+// It wait the job is opened before start the datafeed
+func (r *ElasticsearchMLDatafeedReconciler) start(resource resource.Resource, esHandler elasticsearchhandler.ElasticsearchHandler) (res ctrl.Result, err error) {
+	datafeed := resource.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+	jobStats, err := esHandler.MLJobStats(datafeed.Spec.JobID)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get ML job stats to check if opened before start datafeed")
+	}
+	if jobStats == nil || jobStats.State != "opened" {
+		r.log.Warnf("ML job %s not yet opened, skip start datafeed", datafeed.Spec.JobID)
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Skip", "ML job %s not yet opened, wait it before start datafeed", datafeed.Spec.JobID)
+		return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+	}
+
+	if err = esHandler.MLDatafeedStart(datafeed.Name); err != nil {
+		return res, errors.Wrap(err, "Error when start ML datafeed")
+	}
+
+	return res, nil
+}
+
+// isMLDatafeedStarted permit to know if the datafeed is started or being started
+func isMLDatafeedStarted(stats *elasticsearchhandler.MLDatafeedStats) bool {
+	if stats == nil {
+		return false
+	}
+
+	return stats.State == "started" || stats.State == "starting"
+}
@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const mlDatafeedJobID = "t-mldatafeed-job"
+
+func (t *ControllerTestSuite) TestElasticsearchMLDatafeedReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-mldatafeed-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, datafeed, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateMLDatafeedStep(),
+		doUpdateMLDatafeedStep(),
+		doDeleteMLDatafeedStep(),
+	}
+	testCase.PreTest = doMockMLDatafeed(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockMLDatafeed(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		// Only match the job used by datafeed to not conflict with job test
+		mockES.EXPECT().MLJobGet(gomock.Eq(mlDatafeedJobID)).AnyTimes().Return(&elasticsearchhandler.MLJob{}, nil)
+		mockES.EXPECT().MLJobStats(gomock.Eq(mlDatafeedJobID)).AnyTimes().Return(&elasticsearchhandler.MLJobStats{
+			JobID: mlDatafeedJobID,
+			State: "opened",
+		}, nil)
+
+		mockES.EXPECT().MLDatafeedGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.MLDatafeed, error) {
+			datafeed := &elasticsearchhandler.MLDatafeed{
+				JobID:   mlDatafeedJobID,
+				Indices: []string{"test"},
+			}
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				}
+				return datafeed, nil
+			case "update":
+				if isUpdated {
+					datafeed.Frequency = "150s"
+				}
+				return datafeed, nil
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().MLDatafeedStats(gomock.Any()).AnyTimes().Return(&elasticsearchhandler.MLDatafeedStats{
+			DatafeedID: "test",
+			State:      "started",
+		}, nil)
+
+		mockES.EXPECT().MLDatafeedDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.MLDatafeed) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockES.EXPECT().MLDatafeedCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, datafeed *elasticsearchhandler.MLDatafeed) error {
+			if *stepName == "create" {
+				isCreated = true
+				data["isCreated"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().MLDatafeedUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, datafeed *elasticsearchhandler.MLDatafeed) error {
+			if *stepName == "update" {
+				isUpdated = true
+				data["isUpdated"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().MLDatafeedStart(gomock.Any()).AnyTimes().Return(nil)
+		mockES.EXPECT().MLDatafeedStop(gomock.Any()).AnyTimes().Return(nil)
+
+		mockES.EXPECT().MLDatafeedDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateMLDatafeedStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new ML datafeed %s/%s ===", key.Namespace, key.Name)
+
+			datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchMLDatafeedSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					JobID:   mlDatafeedJobID,
+					Indices: []string{"test"},
+				},
+			}
+			if err = c.Create(context.Background(), datafeed); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, datafeed); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get ML datafeed: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(datafeed.Status.Conditions, mlDatafeedCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateMLDatafeedStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update ML datafeed %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ML datafeed is null")
+			}
+			datafeed := o.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+			datafeed.Spec.Frequency = "150s"
+			if err = c.Update(context.Background(), datafeed); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, datafeed); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get ML datafeed: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(datafeed.Status.Conditions, mlDatafeedCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteMLDatafeedStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete ML datafeed %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ML datafeed is null")
+			}
+			datafeed := o.(*elkv1alpha1.ElasticsearchMLDatafeed)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), datafeed, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			datafeed := &elkv1alpha1.ElasticsearchMLDatafeed{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, datafeed); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("ML datafeed stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
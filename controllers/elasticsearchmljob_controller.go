@@ -0,0 +1,393 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	mlJobFinalizer = "mljob.elk.k8s.webcenter.fr/finalizer"
+	mlJobCondition = "UpdateMLJob"
+)
+
+// ElasticsearchMLJobReconciler reconciles a ElasticsearchMLJob object
+type ElasticsearchMLJobReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmljobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmljobs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmljobs/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The job is reconciled periodically to keep the status up to date with the stats.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchMLJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, mlJobFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	job := &elkv1alpha1.ElasticsearchMLJob{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, job, data)
+	return requeueToRefreshStatus(job, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchMLJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchMLJob{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchMLJobReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(job.Status.Conditions, mlJobCondition) == nil {
+		condition.SetStatusCondition(&job.Status.Conditions, v1.Condition{
+			Type:   mlJobCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &job.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current job and its stats
+func (r *ElasticsearchMLJobReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read job from Elasticsearch
+	currentJob, err := esHandler.MLJobGet(job.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get ML job from Elasticsearch")
+	}
+	data["job"] = currentJob
+
+	// Read job stats from Elasticsearch
+	var stats *elasticsearchhandler.MLJobStats
+	if currentJob != nil {
+		stats, err = esHandler.MLJobStats(job.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get ML job stats from Elasticsearch")
+		}
+	}
+	data["stats"] = stats
+
+	return res, nil
+}
+
+// Create add new job and open it if needed
+func (r *ElasticsearchMLJobReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+
+	expectedJob, err := job.ToJob()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert ML job")
+	}
+
+	// Create job on Elasticsearch
+	if err = esHandler.MLJobCreate(job.Name, expectedJob); err != nil {
+		return res, errors.Wrap(err, "Error when create ML job")
+	}
+
+	if job.IsOpened() {
+		if err = esHandler.MLJobOpen(job.Name); err != nil {
+			return res, errors.Wrap(err, "Error when open ML job")
+		}
+	}
+
+	return res, nil
+}
+
+// Update permit to update job from Elasticsearch
+// It also open or close the job if needed
+func (r *ElasticsearchMLJobReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+
+	expectedJob, err := job.ToJob()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert ML job")
+	}
+
+	var stats *elasticsearchhandler.MLJobStats
+	if d, ok := data["stats"]; ok {
+		stats = d.(*elasticsearchhandler.MLJobStats)
+	}
+
+	if needUpdate, ok := data["needUpdate"].(bool); ok && needUpdate {
+		// Analysis limits can't be sent when the job is opened, even if they don't change
+		if needUpdateLimits, ok := data["needUpdateAnalysisLimits"].(bool); ok && needUpdateLimits {
+			if isMLJobOpened(stats) {
+				return res, errors.New("analysis_limits can only be updated when the job is closed")
+			}
+		} else {
+			expectedJob.AnalysisLimits = nil
+		}
+
+		if err = esHandler.MLJobUpdate(job.Name, expectedJob); err != nil {
+			return res, errors.Wrap(err, "Error when update ML job")
+		}
+	}
+
+	// Align the job state
+	if !isMLJobOnExpectedState(job, stats) {
+		if job.IsOpened() {
+			if err = esHandler.MLJobOpen(job.Name); err != nil {
+				return res, errors.Wrap(err, "Error when open ML job")
+			}
+			r.recorder.Event(resource, core.EventTypeNormal, "Opened", "ML job opened")
+		} else {
+			if err = esHandler.MLJobClose(job.Name); err != nil {
+				return res, errors.Wrap(err, "Error when close ML job")
+			}
+			r.recorder.Event(resource, core.EventTypeNormal, "Closed", "ML job closed")
+		}
+	}
+
+	return res, nil
+}
+
+// Delete permit to close and delete job from Elasticsearch
+// It wait the datafeeds that use the job are deleted before, so they are stopped and deleted by their own resource
+func (r *ElasticsearchMLJobReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+
+	datafeeds := &elkv1alpha1.ElasticsearchMLDatafeedList{}
+	if err = r.List(ctx, datafeeds, client.InNamespace(job.Namespace)); err != nil {
+		return errors.Wrap(err, "Error when list ML datafeeds")
+	}
+	for _, datafeed := range datafeeds.Items {
+		if datafeed.Spec.JobID == job.Name {
+			return errors.Errorf("ML job is still used by datafeed %s, wait it's deleted", datafeed.Name)
+		}
+	}
+
+	// Elasticsearch stop the datafeed not managed by operator before close the job
+	if err = esHandler.MLJobClose(job.Name); err != nil {
+		return errors.Wrap(err, "Error when close ML job")
+	}
+
+	if err = esHandler.MLJobDelete(job.Name); err != nil {
+		return errors.Wrap(err, "Error when delete ML job")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected job exist
+// It also check if the job is on the expected state
+func (r *ElasticsearchMLJobReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+	var currentJob *elasticsearchhandler.MLJob
+	var stats *elasticsearchhandler.MLJobStats
+	var d any
+
+	d, err = helper.Get(data, "job")
+	if err != nil {
+		return diff, err
+	}
+	currentJob = d.(*elasticsearchhandler.MLJob)
+
+	d, err = helper.Get(data, "stats")
+	if err != nil {
+		return diff, err
+	}
+	stats = d.(*elasticsearchhandler.MLJobStats)
+
+	expectedJob, err := job.ToJob()
+	if err != nil {
+		return diff, errors.Wrap(err, "Error when convert ML job")
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentJob == nil {
+		diff.NeedCreate = true
+		diff.Diff = "ML job not exist"
+		return diff, nil
+	}
+
+	diffStr, err := esHandler.MLJobDiff(currentJob, expectedJob)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		// Analysis config, data description and results index can't be updated
+		immutableDiff, err := esHandler.MLJobDiff(
+			&elasticsearchhandler.MLJob{AnalysisConfig: currentJob.AnalysisConfig, DataDescription: currentJob.DataDescription, ResultsIndexName: currentJob.ResultsIndexName},
+			&elasticsearchhandler.MLJob{AnalysisConfig: expectedJob.AnalysisConfig, DataDescription: expectedJob.DataDescription, ResultsIndexName: expectedJob.ResultsIndexName},
+		)
+		if err != nil {
+			return diff, err
+		}
+		if immutableDiff != "" {
+			return diff, errors.Errorf("analysis_config, data_description and results_index_name can't be updated on existing ML job:\n%s", immutableDiff)
+		}
+
+		limitsDiff, err := esHandler.MLJobDiff(
+			&elasticsearchhandler.MLJob{AnalysisLimits: currentJob.AnalysisLimits},
+			&elasticsearchhandler.MLJob{AnalysisLimits: expectedJob.AnalysisLimits},
+		)
+		if err != nil {
+			return diff, err
+		}
+		if limitsDiff != "" {
+			data["needUpdateAnalysisLimits"] = true
+		}
+
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		data["needUpdate"] = true
+	}
+
+	if !isMLJobOnExpectedState(job, stats) {
+		diff.NeedUpdate = true
+		diff.Diff = fmt.Sprintf("%sML job must be %s\n", diff.Diff, job.Spec.State)
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchMLJobReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&job.Status.Conditions, v1.Condition{
+		Type:    mlJobCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from job stats
+func (r *ElasticsearchMLJobReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	job := resource.(*elkv1alpha1.ElasticsearchMLJob)
+
+	if d, ok := data["stats"]; ok && d.(*elasticsearchhandler.MLJobStats) != nil {
+		stats := d.(*elasticsearchhandler.MLJobStats)
+		job.Status.State = stats.State
+		job.Status.ModelMemoryStatus = stats.ModelSizeStats.MemoryStatus
+	}
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&job.Status.Conditions, v1.Condition{
+			Type:    mlJobCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "ML job successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&job.Status.Conditions, v1.Condition{
+			Type:    mlJobCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "ML job successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(job.Status.Conditions, mlJobCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&job.Status.Conditions, v1.Condition{
+			Type:    mlJobCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "ML job already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "ML job already set")
+	}
+
+	return nil
+}
+
+// isMLJobOpened permit to know if the job is opened or being opened
+func isMLJobOpened(stats *elasticsearchhandler.MLJobStats) bool {
+	if stats == nil {
+		return false
+	}
+
+	return stats.State == "opened" || stats.State == "opening"
+}
+
+// isMLJobOnExpectedState permit to know if the job is opened or closed as expected
+func isMLJobOnExpectedState(job *elkv1alpha1.ElasticsearchMLJob, stats *elasticsearchhandler.MLJobStats) bool {
+	if stats == nil {
+		return true
+	}
+
+	if job.IsOpened() {
+		return isMLJobOpened(stats)
+	}
+
+	return stats.State == "closed" || stats.State == "closing"
+}
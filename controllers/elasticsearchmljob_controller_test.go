@@ -0,0 +1,272 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchMLJobReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-mljob-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	job := &elkv1alpha1.ElasticsearchMLJob{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, job, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateMLJobStep(),
+		doUpdateMLJobStep(),
+		doDeleteMLJobStep(),
+	}
+	testCase.PreTest = doMockMLJob(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockMLJob(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockES.EXPECT().MLJobGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.MLJob, error) {
+			job := &elasticsearchhandler.MLJob{
+				Description: "test",
+				AnalysisConfig: map[string]any{
+					"bucket_span": "10m",
+				},
+				DataDescription: map[string]any{
+					"time_field": "timestamp",
+				},
+			}
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				}
+				return job, nil
+			case "update":
+				if isUpdated {
+					job.Description = "test2"
+				}
+				return job, nil
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().MLJobStats(gomock.Any()).AnyTimes().Return(&elasticsearchhandler.MLJobStats{
+			JobID: "test",
+			State: "opened",
+			ModelSizeStats: elasticsearchhandler.MLJobModelSizeStats{
+				MemoryStatus: "ok",
+			},
+		}, nil)
+
+		mockES.EXPECT().MLJobDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.MLJob) (string, error) {
+			// Only the description change, so the partial diffs on other fields are always empty
+			if actual.Description == "" && expected.Description == "" {
+				return "", nil
+			}
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockES.EXPECT().MLJobCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, job *elasticsearchhandler.MLJob) error {
+			if *stepName == "create" {
+				isCreated = true
+				data["isCreated"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().MLJobUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, job *elasticsearchhandler.MLJob) error {
+			if *stepName == "update" {
+				isUpdated = true
+				data["isUpdated"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().MLJobOpen(gomock.Any()).AnyTimes().Return(nil)
+		mockES.EXPECT().MLJobClose(gomock.Any()).AnyTimes().Return(nil)
+
+		mockES.EXPECT().MLJobDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateMLJobStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new ML job %s/%s ===", key.Namespace, key.Name)
+
+			job := &elkv1alpha1.ElasticsearchMLJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchMLJobSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Description:     "test",
+					AnalysisConfig:  `{"bucket_span": "10m"}`,
+					DataDescription: `{"time_field": "timestamp"}`,
+				},
+			}
+			if err = c.Create(context.Background(), job); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			job := &elkv1alpha1.ElasticsearchMLJob{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, job); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get ML job: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(job.Status.Conditions, mlJobCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateMLJobStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update ML job %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ML job is null")
+			}
+			job := o.(*elkv1alpha1.ElasticsearchMLJob)
+
+			job.Spec.Description = "test2"
+			if err = c.Update(context.Background(), job); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			job := &elkv1alpha1.ElasticsearchMLJob{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, job); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get ML job: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(job.Status.Conditions, mlJobCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteMLJobStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete ML job %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ML job is null")
+			}
+			job := o.(*elkv1alpha1.ElasticsearchMLJob)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), job, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			job := &elkv1alpha1.ElasticsearchMLJob{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, job); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("ML job stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	mountedIndexFinalizer = "mounted-index.elk.k8s.webcenter.fr/finalizer"
+	mountedIndexCondition = "UpdateMountedIndex"
+)
+
+// ElasticsearchMountedIndexReconciler reconciles a ElasticsearchMountedIndex object
+type ElasticsearchMountedIndexReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmountedindices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmountedindices/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchmountedindices/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The mounted index is reconciled periodically until all shards are started to keep the status up to date.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchMountedIndexReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, mountedIndexFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	mount := &elkv1alpha1.ElasticsearchMountedIndex{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, mount, data)
+	if mount.Status.State == elkv1alpha1.MountedIndexMounting {
+		return requeueToRefreshStatus(mount, res, err, waitDurationProgress)
+	}
+	return res, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchMountedIndexReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchMountedIndex{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchMountedIndexReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(mount.Status.Conditions, mountedIndexCondition) == nil {
+		condition.SetStatusCondition(&mount.Status.Conditions, v1.Condition{
+			Type:   mountedIndexCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &mount.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current mounted index and its shards recovery
+func (r *ElasticsearchMountedIndexReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read mounted index from Elasticsearch
+	currentMount, err := esHandler.MountedIndexGet(mount.GetIndexName())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get mounted index from Elasticsearch")
+	}
+	data["mount"] = currentMount
+
+	// Read shards recovery from Elasticsearch
+	if currentMount != nil {
+		recovery, err := esHandler.IndexRecoveryGet(mount.GetIndexName())
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get recovery from Elasticsearch")
+		}
+		data["recovery"] = recovery[mount.GetIndexName()]
+	}
+
+	return res, nil
+}
+
+// Create mount the index from snapshot
+func (r *ElasticsearchMountedIndexReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+	expectedMount, err := mount.ToMountedIndex()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert mounted index")
+	}
+
+	if err = esHandler.MountedIndexCreate(mount.Spec.Repository, mount.Spec.Snapshot, mount.GetStorage(), expectedMount); err != nil {
+		return res, errors.Wrap(err, "Error when mount index")
+	}
+
+	return res, nil
+}
+
+// Update do nothing, the mounted index can't be updated
+func (r *ElasticsearchMountedIndexReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return res, nil
+}
+
+// Delete permit to unmount the index
+// The index is only unmounted when the deletion policy is set to Delete
+func (r *ElasticsearchMountedIndexReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+	if !mount.IsDeletedOnRemove() {
+		r.log.Infof("Keep mounted index %s", mount.GetIndexName())
+		return nil
+	}
+
+	if err = esHandler.MountedIndexDelete(mount.GetIndexName()); err != nil {
+		return errors.Wrap(err, "Error when unmount index")
+	}
+
+	return nil
+}
+
+// Diff permit to check if the index need to be mounted
+// It return error if the index is already mounted from an other snapshot, because it can't be changed
+func (r *ElasticsearchMountedIndexReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+	var d any
+
+	d, err = helper.Get(data, "mount")
+	if err != nil {
+		return diff, err
+	}
+	currentMount := d.(*elasticsearchhandler.MountedIndexInfo)
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentMount == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Index not mounted"
+		return diff, nil
+	}
+
+	if currentMount.Repository != mount.Spec.Repository || currentMount.Snapshot != mount.Spec.Snapshot || currentMount.Index != mount.Spec.Index || currentMount.Storage != mount.GetStorage() {
+		return diff, errors.Errorf("Index %s is already mounted from %s/%s/%s with storage %s, it can't be changed", mount.GetIndexName(), currentMount.Repository, currentMount.Snapshot, currentMount.Index, currentMount.Storage)
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchMountedIndexReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&mount.Status.Conditions, v1.Condition{
+		Type:    mountedIndexCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from shards recovery
+func (r *ElasticsearchMountedIndexReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	mount := resource.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+	if diff.NeedCreate {
+		mount.Status.State = elkv1alpha1.MountedIndexMounting
+		mount.Status.ShardsTotal = 0
+		mount.Status.ShardsDone = 0
+		condition.SetStatusCondition(&mount.Status.Conditions, v1.Condition{
+			Type:    mountedIndexCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Index successfully mounted",
+		})
+
+		return nil
+	}
+
+	if d, ok := data["recovery"]; ok {
+		recovery := d.(elasticsearchhandler.IndexRecovery)
+		mount.Status.ShardsTotal = int64(len(recovery.Shards))
+		mount.Status.ShardsDone = 0
+		for _, shard := range recovery.Shards {
+			if shard.Stage == "DONE" {
+				mount.Status.ShardsDone++
+			}
+		}
+
+		if mount.Status.ShardsTotal > 0 && mount.Status.ShardsDone == mount.Status.ShardsTotal {
+			if mount.Status.State != elkv1alpha1.MountedIndexMounted {
+				r.recorder.Event(resource, core.EventTypeNormal, "Completed", "All shards of mounted index are started")
+			}
+			mount.Status.State = elkv1alpha1.MountedIndexMounted
+		} else {
+			mount.Status.State = elkv1alpha1.MountedIndexMounting
+		}
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(mount.Status.Conditions, mountedIndexCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&mount.Status.Conditions, v1.Condition{
+			Type:    mountedIndexCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Index already mounted",
+		})
+	}
+
+	return nil
+}
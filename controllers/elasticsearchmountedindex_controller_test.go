@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchMountedIndexReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-mount-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	mount := &elkv1alpha1.ElasticsearchMountedIndex{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, mount, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateMountedIndexStep(),
+		doUpdateMountedIndexStep(),
+		doDeleteMountedIndexStep(),
+	}
+	testCase.PreTest = doMockMountedIndex(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockMountedIndex(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+
+		mockES.EXPECT().MountedIndexGet(gomock.Eq("t-mount-index")).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.MountedIndexInfo, error) {
+			if !isCreated {
+				return nil, nil
+			}
+
+			resp := &elasticsearchhandler.MountedIndexInfo{
+				Repository: "backup",
+				Snapshot:   "audit",
+				Index:      "audit-2020",
+				Storage:    "shared_cache",
+			}
+			return resp, nil
+		})
+
+		mockES.EXPECT().IndexRecoveryGet(gomock.Eq("t-mount-index")).AnyTimes().DoAndReturn(func(indices ...string) (map[string]elasticsearchhandler.IndexRecovery, error) {
+			if *stepName == "update" {
+				data["isUpdated"] = true
+			}
+
+			resp := map[string]elasticsearchhandler.IndexRecovery{
+				"t-mount-index": {
+					Shards: []elasticsearchhandler.IndexRecoveryShard{
+						{
+							ID:      0,
+							Type:    "SNAPSHOT",
+							Stage:   "DONE",
+							Primary: true,
+						},
+					},
+				},
+			}
+			return resp, nil
+		})
+
+		mockES.EXPECT().MountedIndexCreate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(repository, snapshot, storage string, mount *elasticsearchhandler.MountedIndex) error {
+			isCreated = true
+			data["isCreated"] = true
+			return nil
+		})
+
+		mockES.EXPECT().MountedIndexDelete(gomock.Eq("t-mount-index")).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateMountedIndexStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new mounted index %s/%s ===", key.Namespace, key.Name)
+
+			mount := &elkv1alpha1.ElasticsearchMountedIndex{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchMountedIndexSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Repository:     "backup",
+					Snapshot:       "audit",
+					Index:          "audit-2020",
+					RenamedIndex:   "t-mount-index",
+					Storage:        "shared_cache",
+					DeletionPolicy: elkv1alpha1.DeletionPolicyRetain,
+				},
+			}
+			if err = c.Create(context.Background(), mount); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			mount := &elkv1alpha1.ElasticsearchMountedIndex{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, mount); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get mounted index: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(mount.Status.Conditions, mountedIndexCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateMountedIndexStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update mounted index %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Mounted index is null")
+			}
+			mount := o.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+			mount.Spec.DeletionPolicy = elkv1alpha1.DeletionPolicyDelete
+			if err = c.Update(context.Background(), mount); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			mount := &elkv1alpha1.ElasticsearchMountedIndex{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, mount); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || mount.Status.State != elkv1alpha1.MountedIndexMounted {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get mounted index: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(mount.Status.Conditions, mountedIndexCondition, metav1.ConditionTrue))
+			assert.Equal(t, int64(1), mount.Status.ShardsDone)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteMountedIndexStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete mounted index %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Mounted index is null")
+			}
+			mount := o.(*elkv1alpha1.ElasticsearchMountedIndex)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), mount, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			mount := &elkv1alpha1.ElasticsearchMountedIndex{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, mount); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Mounted index stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	restoreFinalizer = "restore.elk.k8s.webcenter.fr/finalizer"
+	restoreCondition = "UpdateRestore"
+)
+
+// ElasticsearchRestoreReconciler reconciles a ElasticsearchRestore object
+type ElasticsearchRestoreReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// restoreProgress is the progress of restore computed from shards recovery
+type restoreProgress struct {
+	indices      []string
+	shardsDone   int64
+	shardsFailed int64
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchrestores/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The restore is reconciled periodically until all shards are started to keep the status up to date.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, restoreFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	restore := &elkv1alpha1.ElasticsearchRestore{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, restore, data)
+	if restore.Status.State == elkv1alpha1.RestoreRunning {
+		return requeueToRefreshStatus(restore, res, err, waitDurationProgress)
+	}
+	return res, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchRestore{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchRestoreReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(restore.Status.Conditions, restoreCondition) == nil {
+		condition.SetStatusCondition(&restore.Status.Conditions, v1.Condition{
+			Type:   restoreCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &restore.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get the progress of the running restore
+func (r *ElasticsearchRestoreReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	if restore.Status.State != elkv1alpha1.RestoreRunning {
+		return res, nil
+	}
+
+	// Read shards recovery from Elasticsearch
+	recovery, err := esHandler.IndexRecoveryGet()
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get recovery from Elasticsearch")
+	}
+	progress := computeRestoreProgress(recovery, restore.Spec.Repository, restore.Spec.Snapshot)
+
+	// Failed shards are no more listed on recovery, so read the allocation of restored indices
+	if len(progress.indices) > 0 {
+		shards, err := esHandler.IndexShardsGet(progress.indices...)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get shards from Elasticsearch")
+		}
+		progress.addFailedShards(shards)
+	}
+	data["progress"] = progress
+
+	return res, nil
+}
+
+// Create start the restore
+// The restore must never run twice for the same generation, so the status is saved before start it
+func (r *ElasticsearchRestoreReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+
+	expectedRestore, err := restore.ToRestore()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert restore")
+	}
+
+	// Count the shards to restore, so the restore is completed only when all of them are restored
+	snapshotStatus, err := esHandler.SnapshotStatus(restore.Spec.Repository, restore.Spec.Snapshot)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get snapshot status")
+	}
+	if snapshotStatus == nil {
+		return res, errors.Errorf("Snapshot %s/%s not found", restore.Spec.Repository, restore.Spec.Snapshot)
+	}
+
+	original := restore.DeepCopy()
+	restore.Status.ObservedGeneration = restore.Generation
+	restore.Status.State = elkv1alpha1.RestoreRunning
+	restore.Status.Indices = nil
+	restore.Status.ShardsTotal = computeRestoreExpectedShards(snapshotStatus, restore.Spec.Indices)
+	restore.Status.ShardsDone = 0
+	restore.Status.ShardsFailed = 0
+	if err = r.Client.Status().Patch(ctx, restore, client.MergeFrom(original)); err != nil {
+		return res, errors.Wrap(err, "Error when save restore status")
+	}
+
+	if err = esHandler.RestoreStart(restore.Spec.Repository, restore.Spec.Snapshot, expectedRestore); err != nil {
+		// The restore is not started again for this generation, the spec need to be updated to retry it
+		restore.Status.State = elkv1alpha1.RestoreFailed
+		return res, errors.Wrap(err, "Error when start restore")
+	}
+
+	return res, nil
+}
+
+// Update do nothing, a restore is only run one time per generation
+func (r *ElasticsearchRestoreReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return res, nil
+}
+
+// Delete do nothing, the restored indices are kept
+func (r *ElasticsearchRestoreReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	return nil
+}
+
+// Diff permit to check if the restore need to be run for the current generation
+func (r *ElasticsearchRestoreReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if !restore.IsAlreadyRun() {
+		diff.NeedCreate = true
+		diff.Diff = fmt.Sprintf("Restore not yet run for generation %d", restore.Generation)
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchRestoreReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&restore.Status.Conditions, v1.Condition{
+		Type:    restoreCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from restore progress
+func (r *ElasticsearchRestoreReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	restore := resource.(*elkv1alpha1.ElasticsearchRestore)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&restore.Status.Conditions, v1.Condition{
+			Type:    restoreCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Restore successfully started",
+		})
+		r.recorder.Event(resource, core.EventTypeNormal, "Started", "Restore started")
+
+		return nil
+	}
+
+	if d, ok := data["progress"]; ok {
+		progress := d.(*restoreProgress)
+		restore.Status.Indices = progress.indices
+		restore.Status.ShardsDone = progress.shardsDone
+		restore.Status.ShardsFailed = progress.shardsFailed
+
+		if restore.Status.ShardsTotal > 0 && progress.shardsDone+progress.shardsFailed >= restore.Status.ShardsTotal {
+			if progress.shardsFailed > 0 {
+				restore.Status.State = elkv1alpha1.RestoreFailed
+				message := fmt.Sprintf("Restore failed on %d/%d shards", progress.shardsFailed, restore.Status.ShardsTotal)
+				condition.SetStatusCondition(&restore.Status.Conditions, v1.Condition{
+					Type:    restoreCondition,
+					Status:  v1.ConditionFalse,
+					Reason:  "RestoreFailed",
+					Message: message,
+				})
+				r.recorder.Event(resource, core.EventTypeWarning, "Failed", message)
+
+				return nil
+			}
+
+			restore.Status.State = elkv1alpha1.RestoreCompleted
+			r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Restore successfully completed")
+		}
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(restore.Status.Conditions, restoreCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&restore.Status.Conditions, v1.Condition{
+			Type:    restoreCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Restore already run",
+		})
+	}
+
+	return nil
+}
+
+// computeRestoreProgress permit to count the shards recovered from the snapshot
+// A shard is restored when the recovery stage is DONE
+func computeRestoreProgress(recovery map[string]elasticsearchhandler.IndexRecovery, repository, snapshot string) *restoreProgress {
+	progress := &restoreProgress{
+		indices: make([]string, 0),
+	}
+
+	for index, indexRecovery := range recovery {
+		isRestored := false
+		for _, shard := range indexRecovery.Shards {
+			if shard.Type != "SNAPSHOT" || shard.Source == nil || shard.Source.Repository != repository || shard.Source.Snapshot != snapshot {
+				continue
+			}
+			isRestored = true
+			if shard.Stage == "DONE" {
+				progress.shardsDone++
+			}
+		}
+		if isRestored {
+			progress.indices = append(progress.indices, index)
+		}
+	}
+	sort.Strings(progress.indices)
+
+	return progress
+}
+
+// addFailedShards permit to count the restored primary shards that can't be allocated
+// They are not listed on recovery anymore
+func (p *restoreProgress) addFailedShards(shards []elasticsearchhandler.IndexShard) {
+	for _, shard := range shards {
+		if shard.PriRep == "p" && shard.State == "UNASSIGNED" && shard.UnassignedReason == "ALLOCATION_FAILED" {
+			p.shardsFailed++
+		}
+	}
+}
+
+// computeRestoreExpectedShards permit to count the shards of snapshot indices selected by the restore
+// It restore all indices when none is provided. Patterns can use wildcard and exclusion with `-`.
+// Data stream name select also its backing indices
+func computeRestoreExpectedShards(status *elasticsearchhandler.SnapshotStatus, indices []string) (total int64) {
+	isMatch := func(pattern, index string) bool {
+		if ok, _ := path.Match(pattern, index); ok {
+			return true
+		}
+		ok, _ := path.Match(fmt.Sprintf(".ds-%s-*", pattern), index)
+		return ok
+	}
+
+	for index, indexStatus := range status.Indices {
+		isSelected := len(indices) == 0
+		isExcluded := false
+		for _, pattern := range indices {
+			if strings.HasPrefix(pattern, "-") {
+				if isMatch(strings.TrimPrefix(pattern, "-"), index) {
+					isExcluded = true
+				}
+			} else if pattern == "_all" || isMatch(pattern, index) {
+				isSelected = true
+			}
+		}
+		if isSelected && !isExcluded {
+			total += indexStatus.ShardsStats.Total
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,281 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchRestoreReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-restore-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	restore := &elkv1alpha1.ElasticsearchRestore{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, restore, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateRestoreStep(),
+		doUpdateRestoreStep(),
+		doDeleteRestoreStep(),
+	}
+	testCase.PreTest = doMockRestore(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockRestore(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+
+		mockES.EXPECT().RestoreStart(gomock.Eq("t-restore-repo"), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(repository, snapshot string, restore *elasticsearchhandler.Restore) error {
+			switch *stepName {
+			case "create":
+				data["isCreated"] = true
+				return nil
+			case "update":
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockES.EXPECT().IndexRecoveryGet().AnyTimes().DoAndReturn(func(indices ...string) (map[string]elasticsearchhandler.IndexRecovery, error) {
+			resp := map[string]elasticsearchhandler.IndexRecovery{
+				"restored-test": {
+					Shards: []elasticsearchhandler.IndexRecoveryShard{
+						{
+							ID:      0,
+							Type:    "SNAPSHOT",
+							Stage:   "DONE",
+							Primary: true,
+							Source: &elasticsearchhandler.IndexRecoverySource{
+								Repository: "t-restore-repo",
+								Snapshot:   "t-restore-snapshot",
+								Index:      "test",
+							},
+						},
+					},
+				},
+			}
+			return resp, nil
+		})
+
+		mockES.EXPECT().SnapshotStatus(gomock.Eq("t-restore-repo"), gomock.Eq("t-restore-snapshot")).AnyTimes().Return(&elasticsearchhandler.SnapshotStatus{
+			Snapshot:   "t-restore-snapshot",
+			Repository: "t-restore-repo",
+			State:      "SUCCESS",
+			Indices: map[string]elasticsearchhandler.SnapshotIndexStatus{
+				"test": {
+					ShardsStats: elasticsearchhandler.SnapshotShardsStats{
+						Done:  1,
+						Total: 1,
+					},
+				},
+			},
+		}, nil)
+
+		mockES.EXPECT().IndexShardsGet(gomock.Any()).AnyTimes().DoAndReturn(func(indices ...string) ([]elasticsearchhandler.IndexShard, error) {
+			resp := []elasticsearchhandler.IndexShard{
+				{
+					Index:  "restored-test",
+					Shard:  "0",
+					PriRep: "p",
+					State:  "STARTED",
+				},
+			}
+			return resp, nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateRestoreStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new restore %s/%s ===", key.Namespace, key.Name)
+
+			restore := &elkv1alpha1.ElasticsearchRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchRestoreSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Repository:        "t-restore-repo",
+					Snapshot:          "t-restore-snapshot",
+					Indices:           []string{"test"},
+					RenamePattern:     "(.+)",
+					RenameReplacement: "restored-$1",
+				},
+			}
+			if err = c.Create(context.Background(), restore); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			restore := &elkv1alpha1.ElasticsearchRestore{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, restore); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get restore: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(restore.Status.Conditions, restoreCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateRestoreStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update restore %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Restore is null")
+			}
+			restore := o.(*elkv1alpha1.ElasticsearchRestore)
+
+			restore.Spec.RenameReplacement = "restored-again-$1"
+			if err = c.Update(context.Background(), restore); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			restore := &elkv1alpha1.ElasticsearchRestore{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, restore); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || restore.Status.State != elkv1alpha1.RestoreCompleted {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get restore: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(restore.Status.Conditions, restoreCondition, metav1.ConditionTrue))
+			assert.Equal(t, restore.Generation, restore.Status.ObservedGeneration)
+			assert.Equal(t, []string{"restored-test"}, restore.Status.Indices)
+			assert.Equal(t, int64(1), restore.Status.ShardsDone)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteRestoreStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete restore %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Restore is null")
+			}
+			restore := o.(*elkv1alpha1.ElasticsearchRestore)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), restore, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			restore := &elkv1alpha1.ElasticsearchRestore{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, restore); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Restore stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
+
+func (t *ControllerTestSuite) TestComputeRestoreExpectedShards() {
+	status := &elasticsearchhandler.SnapshotStatus{
+		Indices: map[string]elasticsearchhandler.SnapshotIndexStatus{
+			"logs-1": {
+				ShardsStats: elasticsearchhandler.SnapshotShardsStats{Total: 2},
+			},
+			"logs-2": {
+				ShardsStats: elasticsearchhandler.SnapshotShardsStats{Total: 3},
+			},
+			"metrics": {
+				ShardsStats: elasticsearchhandler.SnapshotShardsStats{Total: 1},
+			},
+			".ds-traces-2022.05.12-000001": {
+				ShardsStats: elasticsearchhandler.SnapshotShardsStats{Total: 4},
+			},
+		},
+	}
+
+	// When all indices are restored
+	assert.Equal(t.T(), int64(10), computeRestoreExpectedShards(status, nil))
+
+	// When some indices are restored
+	assert.Equal(t.T(), int64(5), computeRestoreExpectedShards(status, []string{"logs-*"}))
+	assert.Equal(t.T(), int64(3), computeRestoreExpectedShards(status, []string{"logs-*", "-logs-2", "metrics"}))
+
+	// When data stream is restored
+	assert.Equal(t.T(), int64(4), computeRestoreExpectedShards(status, []string{"traces"}))
+}
@@ -18,6 +18,9 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	core "k8s.io/api/core/v1"
 	condition "k8s.io/apimachinery/pkg/api/meta"
@@ -35,8 +38,11 @@ import (
 )
 
 const (
-	slmFinalizer = "slm.elk.k8s.webcenter.fr/finalizer"
-	slmCondition = "UpdateSLMPolicy"
+	slmFinalizer                  = "slm.elk.k8s.webcenter.fr/finalizer"
+	slmCondition                  = "UpdateSLMPolicy"
+	slmHealthyCondition           = "Healthy"
+	slmExecuteAnnotation          = "elk.k8s.webcenter.fr/slm-execute"
+	slmExecuteRetentionAnnotation = "elk.k8s.webcenter.fr/slm-execute-retention"
 )
 
 // ElasticsearchSLMReconciler reconciles a ElasticsearchSLM object
@@ -69,7 +75,8 @@ func (r *ElasticsearchSLMReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	slm := &elkv1alpha1.ElasticsearchSLM{}
 	data := map[string]any{}
 
-	return reconciler.Reconcile(ctx, req, slm, data)
+	res, err := reconciler.Reconcile(ctx, req, slm, data)
+	return requeueToRefreshStatus(slm, res, err, waitDurationRefresh)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -115,6 +122,17 @@ func (r *ElasticsearchSLMReconciler) Read(ctx context.Context, resource resource
 	}
 
 	data["policy"] = slmPolicy
+
+	// Read SLM execution status from Elasticsearch
+	var status *elasticsearchhandler.SnapshotLifecyclePolicyStatus
+	if slmPolicy != nil {
+		status, err = esHandler.SLMGetStatus(slm.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get SLM policy status from Elasticsearch")
+		}
+	}
+	data["status"] = status
+
 	return res, nil
 }
 
@@ -217,6 +235,34 @@ func (r *ElasticsearchSLMReconciler) OnError(ctx context.Context, resource resou
 // OnSuccess permit to set status condition on the right state is everithink is good
 func (r *ElasticsearchSLMReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
 	slm := resource.(*elkv1alpha1.ElasticsearchSLM)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	if d, ok := data["status"]; ok && d.(*elasticsearchhandler.SnapshotLifecyclePolicyStatus) != nil {
+		r.updateExecutionStatus(slm, d.(*elasticsearchhandler.SnapshotLifecyclePolicyStatus))
+	}
+
+	// Take snapshot on demand
+	if _, ok := slm.Annotations[slmExecuteAnnotation]; ok {
+		snapshotName, err := esHandler.SLMExecute(slm.Name)
+		if err != nil {
+			return errors.Wrap(err, "Error when execute SLM policy")
+		}
+		r.recorder.Eventf(resource, core.EventTypeNormal, "Execute", "Snapshot %s started", snapshotName)
+		if err = removeAnnotation(ctx, r.Client, slm, slmExecuteAnnotation); err != nil {
+			return errors.Wrap(err, "Error when remove execute annotation")
+		}
+	}
+
+	// Run retention on demand
+	if _, ok := slm.Annotations[slmExecuteRetentionAnnotation]; ok {
+		if err = esHandler.SLMExecuteRetention(); err != nil {
+			return errors.Wrap(err, "Error when execute SLM retention")
+		}
+		r.recorder.Event(resource, core.EventTypeNormal, "ExecuteRetention", "Snapshot retention started")
+		if err = removeAnnotation(ctx, r.Client, slm, slmExecuteRetentionAnnotation); err != nil {
+			return errors.Wrap(err, "Error when remove execute retention annotation")
+		}
+	}
 
 	if diff.NeedCreate {
 		condition.SetStatusCondition(&slm.Status.Conditions, v1.Condition{
@@ -254,3 +300,77 @@ func (r *ElasticsearchSLMReconciler) OnSuccess(ctx context.Context, resource res
 
 	return nil
 }
+
+// updateExecutionStatus permit to set the last snapshots and the stats of policy on status
+func (r *ElasticsearchSLMReconciler) updateExecutionStatus(slm *elkv1alpha1.ElasticsearchSLM, status *elasticsearchhandler.SnapshotLifecyclePolicyStatus) {
+	slm.Status.LastSuccess = nil
+	if status.LastSuccess != nil {
+		slm.Status.LastSuccess = &elkv1alpha1.ElasticsearchSLMInvocation{
+			SnapshotName: status.LastSuccess.SnapshotName,
+			Time:         v1.NewTime(time.UnixMilli(status.LastSuccess.Time)),
+		}
+	}
+
+	slm.Status.LastFailure = nil
+	if status.LastFailure != nil {
+		slm.Status.LastFailure = &elkv1alpha1.ElasticsearchSLMInvocation{
+			SnapshotName: status.LastFailure.SnapshotName,
+			Time:         v1.NewTime(time.UnixMilli(status.LastFailure.Time)),
+			Reason:       slmFailureReason(status.LastFailure.Details),
+		}
+	}
+
+	slm.Status.NextExecution = nil
+	if status.NextExecutionMillis > 0 {
+		nextExecution := v1.NewTime(time.UnixMilli(status.NextExecutionMillis))
+		slm.Status.NextExecution = &nextExecution
+	}
+
+	slm.Status.Stats = &elkv1alpha1.ElasticsearchSLMStats{
+		SnapshotsTaken:           status.Stats.SnapshotsTaken,
+		SnapshotsFailed:          status.Stats.SnapshotsFailed,
+		SnapshotsDeleted:         status.Stats.SnapshotsDeleted,
+		SnapshotDeletionFailures: status.Stats.SnapshotDeletionFailures,
+	}
+
+	switch {
+	case slm.Status.LastFailure != nil && (slm.Status.LastSuccess == nil || slm.Status.LastSuccess.Time.Before(&slm.Status.LastFailure.Time)):
+		if !condition.IsStatusConditionFalse(slm.Status.Conditions, slmHealthyCondition) {
+			r.recorder.Eventf(slm, core.EventTypeWarning, "SnapshotFailed", "Snapshot %s failed: %s", slm.Status.LastFailure.SnapshotName, slm.Status.LastFailure.Reason)
+		}
+		condition.SetStatusCondition(&slm.Status.Conditions, v1.Condition{
+			Type:    slmHealthyCondition,
+			Status:  v1.ConditionFalse,
+			Reason:  "SnapshotFailed",
+			Message: fmt.Sprintf("Snapshot %s failed: %s", slm.Status.LastFailure.SnapshotName, slm.Status.LastFailure.Reason),
+		})
+	case slm.Status.LastSuccess != nil:
+		condition.SetStatusCondition(&slm.Status.Conditions, v1.Condition{
+			Type:    slmHealthyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "SnapshotSucceeded",
+			Message: fmt.Sprintf("Snapshot %s succeeded", slm.Status.LastSuccess.SnapshotName),
+		})
+	default:
+		condition.SetStatusCondition(&slm.Status.Conditions, v1.Condition{
+			Type:    slmHealthyCondition,
+			Status:  v1.ConditionUnknown,
+			Reason:  "NotYetExecuted",
+			Message: "No snapshot taken yet",
+		})
+	}
+}
+
+// slmFailureReason permit to get the error from details of last failure
+// Details is the JSON serialization of the exception
+func slmFailureReason(details string) string {
+	exception := map[string]any{}
+	if err := json.Unmarshal([]byte(details), &exception); err != nil {
+		return details
+	}
+	reason, _ := exception["reason"].(string)
+	if errorType, ok := exception["type"].(string); ok {
+		return fmt.Sprintf("%s: %s", errorType, reason)
+	}
+	return reason
+}
@@ -36,6 +36,7 @@ func (t *ControllerTestSuite) TestElasticsearchSLMReconciler() {
 	testCase.Steps = []test.TestStep{
 		doCreateSLMStep(),
 		doUpdateSLMStep(),
+		doExecuteSLMStep(),
 		doDeleteSLMStep(),
 	}
 	testCase.PreTest = doMockSLM(t.mockElasticsearchHandler)
@@ -166,6 +167,35 @@ func doMockSLM(mockES *mocks.MockElasticsearchHandler) func(stepName *string, da
 			return nil
 		})
 
+		mockES.EXPECT().SLMGetStatus(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.SnapshotLifecyclePolicyStatus, error) {
+			return &elasticsearchhandler.SnapshotLifecyclePolicyStatus{
+				LastSuccess: &elasticsearchhandler.SnapshotLifecyclePolicyInvocation{
+					SnapshotName: "daily-snap-2022.05.19-abc",
+					Time:         1653010210000,
+				},
+				LastFailure: &elasticsearchhandler.SnapshotLifecyclePolicyInvocation{
+					SnapshotName: "daily-snap-2022.05.20-abc",
+					Time:         1653096610000,
+					Details:      `{"type":"repository_exception","reason":"[my_repository] missing"}`,
+				},
+				NextExecutionMillis: 1653183000000,
+				Stats: elasticsearchhandler.SnapshotLifecyclePolicyStats{
+					SnapshotsTaken:  10,
+					SnapshotsFailed: 1,
+				},
+			}, nil
+		})
+
+		mockES.EXPECT().SLMExecute(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (string, error) {
+			data["isExecuted"] = true
+			return "daily-snap-2022.05.21-abc", nil
+		})
+
+		mockES.EXPECT().SLMExecuteRetention().AnyTimes().DoAndReturn(func() error {
+			data["isRetentionExecuted"] = true
+			return nil
+		})
+
 		mockES.EXPECT().SLMDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
 			data["isDeleted"] = true
 			return nil
@@ -281,6 +311,60 @@ func doUpdateSLMStep() test.TestStep {
 	}
 }
 
+func doExecuteSLMStep() test.TestStep {
+	return test.TestStep{
+		Name: "execute",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Execute SLM policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("SLM is null")
+			}
+			slm := o.(*elkv1alpha1.ElasticsearchSLM)
+
+			if slm.Annotations == nil {
+				slm.Annotations = map[string]string{}
+			}
+			slm.Annotations[slmExecuteAnnotation] = "true"
+			slm.Annotations[slmExecuteRetentionAnnotation] = "true"
+			if err = c.Update(context.Background(), slm); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			slm := &elkv1alpha1.ElasticsearchSLM{}
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, slm); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["isExecuted"]; !ok {
+					return errors.New("Not yet executed")
+				}
+				if _, ok := data["isRetentionExecuted"]; !ok {
+					return errors.New("Retention not yet executed")
+				}
+				if len(slm.Annotations) > 0 {
+					return errors.New("Annotations not yet removed")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get SLM: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(slm.Status.Conditions, slmHealthyCondition, metav1.ConditionFalse))
+			assert.Equal(t, "daily-snap-2022.05.20-abc", slm.Status.LastFailure.SnapshotName)
+			assert.Equal(t, "repository_exception: [my_repository] missing", slm.Status.LastFailure.Reason)
+			assert.Equal(t, int64(10), slm.Status.Stats.SnapshotsTaken)
+			assert.NotNil(t, slm.Status.NextExecution)
+
+			return nil
+		},
+	}
+}
+
 func doDeleteSLMStep() test.TestStep {
 	return test.TestStep{
 		Name: "delete",
@@ -0,0 +1,272 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotFinalizer  = "snapshot.elk.k8s.webcenter.fr/finalizer"
+	snapshotCondition  = "UpdateSnapshot"
+	snapshotInProgress = "IN_PROGRESS"
+)
+
+// ElasticsearchSnapshotReconciler reconciles a ElasticsearchSnapshot object
+type ElasticsearchSnapshotReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshots,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshots/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshots/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The snapshot is reconciled periodically until it's completed to keep the status up to date.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, snapshotFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	snapshot := &elkv1alpha1.ElasticsearchSnapshot{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, snapshot, data)
+	if snapshot.Status.State == snapshotInProgress {
+		return requeueToRefreshStatus(snapshot, res, err, waitDurationProgress)
+	}
+	return res, err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchSnapshot{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchSnapshotReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(snapshot.Status.Conditions, snapshotCondition) == nil {
+		condition.SetStatusCondition(&snapshot.Status.Conditions, v1.Condition{
+			Type:   snapshotCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &snapshot.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current snapshot and its status
+func (r *ElasticsearchSnapshotReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read snapshot from Elasticsearch
+	currentSnapshot, err := esHandler.SnapshotGet(snapshot.Spec.Repository, snapshot.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get snapshot from Elasticsearch")
+	}
+	data["snapshot"] = currentSnapshot
+
+	// Read snapshot status from Elasticsearch
+	var status *elasticsearchhandler.SnapshotStatus
+	if currentSnapshot != nil {
+		status, err = esHandler.SnapshotStatus(snapshot.Spec.Repository, snapshot.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get snapshot status from Elasticsearch")
+		}
+	}
+	data["status"] = status
+
+	return res, nil
+}
+
+// Create start new snapshot
+func (r *ElasticsearchSnapshotReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+
+	expectedSnapshot, err := snapshot.ToSnapshot()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert snapshot")
+	}
+
+	// Create snapshot on Elasticsearch
+	if err = esHandler.SnapshotCreate(snapshot.Spec.Repository, snapshot.Name, expectedSnapshot); err != nil {
+		return res, errors.Wrap(err, "Error when create snapshot")
+	}
+
+	return res, nil
+}
+
+// Update do nothing, the snapshot can't be updated after it's created
+func (r *ElasticsearchSnapshotReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return res, nil
+}
+
+// Delete permit to delete snapshot from Elasticsearch
+// The snapshot is only deleted when the deletion policy is set to Delete
+func (r *ElasticsearchSnapshotReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+
+	if !snapshot.IsDeletedOnRemove() {
+		r.log.Infof("Keep snapshot %s/%s on repository", snapshot.Spec.Repository, snapshot.Name)
+		return nil
+	}
+
+	if err = esHandler.SnapshotDelete(snapshot.Spec.Repository, snapshot.Name); err != nil {
+		return errors.Wrap(err, "Error when delete snapshot")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if the snapshot need to be created
+// A snapshot is never updated, and never created again when it's removed outside of the operator
+func (r *ElasticsearchSnapshotReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+	var d any
+
+	d, err = helper.Get(data, "snapshot")
+	if err != nil {
+		return diff, err
+	}
+	currentSnapshot := d.(*elasticsearchhandler.SnapshotInfo)
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentSnapshot == nil {
+		if snapshot.Status.State != "" {
+			return diff, errors.Errorf("Snapshot %s not found on repository %s, it has been removed outside of the operator", snapshot.Name, snapshot.Spec.Repository)
+		}
+		diff.NeedCreate = true
+		diff.Diff = "Snapshot not exist"
+		return diff, nil
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchSnapshotReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&snapshot.Status.Conditions, v1.Condition{
+		Type:    snapshotCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from snapshot status
+func (r *ElasticsearchSnapshotReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	snapshot := resource.(*elkv1alpha1.ElasticsearchSnapshot)
+
+	if diff.NeedCreate {
+		snapshot.Status.State = snapshotInProgress
+		condition.SetStatusCondition(&snapshot.Status.Conditions, v1.Condition{
+			Type:    snapshotCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Snapshot successfully created",
+		})
+
+		return nil
+	}
+
+	if d, ok := data["snapshot"]; ok && d.(*elasticsearchhandler.SnapshotInfo) != nil {
+		currentSnapshot := d.(*elasticsearchhandler.SnapshotInfo)
+
+		if snapshot.Status.State != currentSnapshot.State && currentSnapshot.State != snapshotInProgress {
+			if currentSnapshot.State == "SUCCESS" {
+				r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Snapshot successfully completed")
+			} else {
+				r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Snapshot completed with state %s", currentSnapshot.State)
+			}
+		}
+
+		snapshot.Status.State = currentSnapshot.State
+		snapshot.Status.Failures = make([]string, 0, len(currentSnapshot.Failures))
+		for _, failure := range currentSnapshot.Failures {
+			snapshot.Status.Failures = append(snapshot.Status.Failures, fmt.Sprintf("%s[%d]: %s", failure.Index, failure.ShardID, failure.Reason))
+		}
+	}
+	if d, ok := data["status"]; ok && d.(*elasticsearchhandler.SnapshotStatus) != nil {
+		status := d.(*elasticsearchhandler.SnapshotStatus)
+		snapshot.Status.ShardsTotal = status.ShardsStats.Total
+		snapshot.Status.ShardsDone = status.ShardsStats.Done
+		snapshot.Status.ShardsFailed = status.ShardsStats.Failed
+		snapshot.Status.SizeInBytes = status.Stats.Total.SizeInBytes
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(snapshot.Status.Conditions, snapshotCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&snapshot.Status.Conditions, v1.Condition{
+			Type:    snapshotCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Snapshot already set",
+		})
+	}
+
+	return nil
+}
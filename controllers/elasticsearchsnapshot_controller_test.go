@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchSnapshotReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-snapshot-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	snapshot := &elkv1alpha1.ElasticsearchSnapshot{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, snapshot, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateSnapshotStep(),
+		doUpdateSnapshotStep(),
+		doDeleteSnapshotStep(),
+	}
+	testCase.PreTest = doMockSnapshot(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockSnapshot(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+
+		mockES.EXPECT().SnapshotGet(gomock.Eq("t-snapshot-repo"), gomock.Any()).AnyTimes().DoAndReturn(func(repository, name string) (*elasticsearchhandler.SnapshotInfo, error) {
+			if !isCreated {
+				return nil, nil
+			}
+
+			resp := &elasticsearchhandler.SnapshotInfo{
+				Snapshot: name,
+				State:    "SUCCESS",
+				Indices:  []string{"test"},
+				Shards: elasticsearchhandler.SnapshotInfoShards{
+					Total:      1,
+					Successful: 1,
+				},
+			}
+			return resp, nil
+		})
+
+		mockES.EXPECT().SnapshotStatus(gomock.Eq("t-snapshot-repo"), gomock.Any()).AnyTimes().DoAndReturn(func(repository, name string) (*elasticsearchhandler.SnapshotStatus, error) {
+			if *stepName == "update" {
+				data["isUpdated"] = true
+			}
+
+			resp := &elasticsearchhandler.SnapshotStatus{
+				Snapshot:   name,
+				Repository: repository,
+				State:      "SUCCESS",
+				ShardsStats: elasticsearchhandler.SnapshotShardsStats{
+					Done:  1,
+					Total: 1,
+				},
+				Stats: elasticsearchhandler.SnapshotStats{
+					Total: elasticsearchhandler.SnapshotFileStats{
+						FileCount:   10,
+						SizeInBytes: 1024,
+					},
+				},
+			}
+			return resp, nil
+		})
+
+		mockES.EXPECT().SnapshotCreate(gomock.Eq("t-snapshot-repo"), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(repository, name string, snapshot *elasticsearchhandler.Snapshot) error {
+			isCreated = true
+			data["isCreated"] = true
+			return nil
+		})
+
+		mockES.EXPECT().SnapshotDelete(gomock.Eq("t-snapshot-repo"), gomock.Any()).AnyTimes().DoAndReturn(func(repository, name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateSnapshotStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new snapshot %s/%s ===", key.Namespace, key.Name)
+
+			snapshot := &elkv1alpha1.ElasticsearchSnapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchSnapshotSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Repository: "t-snapshot-repo",
+					Indices:    []string{"test"},
+				},
+			}
+			if err = c.Create(context.Background(), snapshot); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			snapshot := &elkv1alpha1.ElasticsearchSnapshot{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, snapshot); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get snapshot: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(snapshot.Status.Conditions, snapshotCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateSnapshotStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update snapshot %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Snapshot is null")
+			}
+			snapshot := o.(*elkv1alpha1.ElasticsearchSnapshot)
+
+			snapshot.Spec.DeletionPolicy = elkv1alpha1.DeletionPolicyDelete
+			if err = c.Update(context.Background(), snapshot); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			snapshot := &elkv1alpha1.ElasticsearchSnapshot{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, snapshot); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || snapshot.Status.State != "SUCCESS" {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get snapshot: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(snapshot.Status.Conditions, snapshotCondition, metav1.ConditionTrue))
+			assert.Equal(t, int64(1), snapshot.Status.ShardsDone)
+			assert.Equal(t, int64(1024), snapshot.Status.SizeInBytes)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteSnapshotStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete snapshot %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Snapshot is null")
+			}
+			snapshot := o.(*elkv1alpha1.ElasticsearchSnapshot)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), snapshot, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			snapshot := &elkv1alpha1.ElasticsearchSnapshot{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, snapshot); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Snapshot stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -18,12 +18,16 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	condition "k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -37,8 +41,9 @@ import (
 )
 
 const (
-	repositoryFinalizer = "repository.elk.k8s.webcenter.fr/finalizer"
-	repositoryCondition = "UpdateSnapshotRepository"
+	repositoryFinalizer         = "repository.elk.k8s.webcenter.fr/finalizer"
+	repositoryCondition         = "UpdateSnapshotRepository"
+	repositoryVerifiedCondition = "Verified"
 )
 
 // ElasticsearchSnapshotRepositoryReconciler reconciles a ElasticsearchSnapshotRepository object
@@ -51,6 +56,7 @@ type ElasticsearchSnapshotRepositoryReconciler struct {
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshotrepositories,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshotrepositories/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchsnapshotrepositories/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -71,7 +77,8 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) Reconcile(ctx context.Contex
 	repository := &elkv1alpha1.ElasticsearchSnapshotRepository{}
 	data := map[string]any{}
 
-	return reconciler.Reconcile(ctx, req, repository, data)
+	res, err := reconciler.Reconcile(ctx, req, repository, data)
+	return requeueToRefreshStatus(repository, res, err, waitDurationRefresh)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -116,6 +123,32 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) Read(ctx context.Context, re
 		return res, errors.Wrap(err, "Unable to get snapshot repository from Elasticsearch")
 	}
 
+	// Read settings from Secrets
+	if len(repository.Spec.SecretSettings) > 0 && repository.DeletionTimestamp.IsZero() {
+		secretSettings := make(map[string]any, len(repository.Spec.SecretSettings))
+		for _, setting := range repository.Spec.SecretSettings {
+			secret := &core.Secret{}
+			secretNS := types.NamespacedName{
+				Namespace: repository.Namespace,
+				Name:      setting.SecretKeyRef.Name,
+			}
+			if err = r.Get(ctx, secretNS, secret); err != nil {
+				if k8serrors.IsNotFound(err) {
+					r.log.Warnf("Secret %s not yet exist, try later", setting.SecretKeyRef.Name)
+					r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Secret %s not yet exist", setting.SecretKeyRef.Name)
+					return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+				}
+				return res, errors.Wrapf(err, "Error when get secret %s", setting.SecretKeyRef.Name)
+			}
+			value, ok := secret.Data[setting.SecretKeyRef.Key]
+			if !ok {
+				return res, errors.Errorf("Key %s not found on secret %s", setting.SecretKeyRef.Key, setting.SecretKeyRef.Name)
+			}
+			secretSettings[setting.Name] = string(value)
+		}
+		data["secretSettings"] = secretSettings
+	}
+
 	data["repository"] = currentRepository
 	return res, nil
 }
@@ -125,14 +158,9 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) Create(ctx context.Context,
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	repository := resource.(*elkv1alpha1.ElasticsearchSnapshotRepository)
 
-	settings := map[string]any{}
-	if err = json.Unmarshal([]byte(repository.Spec.Settings), &settings); err != nil {
-		return res, errors.Wrap(err, "Error when decode repository setting")
-	}
-
-	repoObj := &olivere.SnapshotRepositoryMetaData{
-		Type:     repository.Spec.Type,
-		Settings: settings,
+	repoObj, err := r.toRepository(repository, data)
+	if err != nil {
+		return res, err
 	}
 
 	// Create repository on Elasticsearch
@@ -168,13 +196,9 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) Diff(resource resource.Resou
 	var currentRepository *olivere.SnapshotRepositoryMetaData
 	var d any
 
-	settings := map[string]any{}
-	if err = json.Unmarshal([]byte(repository.Spec.Settings), &settings); err != nil {
-		return diff, errors.Wrap(err, "Error when decode repository setting")
-	}
-	expectedRepository := &olivere.SnapshotRepositoryMetaData{
-		Type:     repository.Spec.Type,
-		Settings: settings,
+	expectedRepository, err := r.toRepository(repository, data)
+	if err != nil {
+		return diff, err
 	}
 
 	d, err = helper.Get(data, "repository")
@@ -225,6 +249,28 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) OnError(ctx context.Context,
 // OnSuccess permit to set status condition on the right state is everithink is good
 func (r *ElasticsearchSnapshotRepositoryReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
 	repository := resource.(*elkv1alpha1.ElasticsearchSnapshotRepository)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Verify repository after it's changed, or until it's verified
+	if diff.NeedCreate || diff.NeedUpdate || !condition.IsStatusConditionTrue(repository.Status.Conditions, repositoryVerifiedCondition) {
+		r.verifyRepository(repository, esHandler)
+	}
+
+	// Cleanup repository periodically
+	if repository.Spec.Cleanup != nil && condition.IsStatusConditionTrue(repository.Status.Conditions, repositoryVerifiedCondition) {
+		if repository.Status.LastCleanup == nil || time.Since(repository.Status.LastCleanup.Time.Time) >= repository.Spec.Cleanup.Interval.Duration {
+			result, err := esHandler.SnapshotRepositoryCleanup(repository.Name)
+			if err != nil {
+				return errors.Wrap(err, "Error when cleanup snapshot repository")
+			}
+			repository.Status.LastCleanup = &elkv1alpha1.ElasticsearchSnapshotRepositoryCleanupResult{
+				Time:         v1.Now(),
+				DeletedBytes: result.DeletedBytes,
+				DeletedBlobs: result.DeletedBlobs,
+			}
+			r.recorder.Eventf(resource, core.EventTypeNormal, "Cleanup", "Snapshot repository cleaned up: %d bytes and %d blobs deleted", result.DeletedBytes, result.DeletedBlobs)
+		}
+	}
 
 	if diff.NeedCreate {
 		condition.SetStatusCondition(&repository.Status.Conditions, v1.Condition{
@@ -262,3 +308,37 @@ func (r *ElasticsearchSnapshotRepositoryReconciler) OnSuccess(ctx context.Contex
 
 	return nil
 }
+
+// verifyRepository permit to check that repository is functional on all master and data nodes
+// The result is set on Verified condition, it not fail the reconcile because of repository is already set
+func (r *ElasticsearchSnapshotRepositoryReconciler) verifyRepository(repository *elkv1alpha1.ElasticsearchSnapshotRepository, esHandler elasticsearchhandler.ElasticsearchHandler) {
+	nodes, err := esHandler.SnapshotRepositoryVerify(repository.Name)
+	if err != nil {
+		r.log.Warnf("Snapshot repository %s not verified: %s", repository.Name, err.Error())
+		r.recorder.Eventf(repository, core.EventTypeWarning, "VerificationFailed", "Snapshot repository not verified: %s", err.Error())
+		condition.SetStatusCondition(&repository.Status.Conditions, v1.Condition{
+			Type:    repositoryVerifiedCondition,
+			Status:  v1.ConditionFalse,
+			Reason:  "VerificationFailed",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	condition.SetStatusCondition(&repository.Status.Conditions, v1.Condition{
+		Type:    repositoryVerifiedCondition,
+		Status:  v1.ConditionTrue,
+		Reason:  "Verified",
+		Message: fmt.Sprintf("Snapshot repository verified by nodes %s", strings.Join(nodes, ", ")),
+	})
+}
+
+// toRepository permit to convert the repository with the settings read from Secrets
+func (r *ElasticsearchSnapshotRepositoryReconciler) toRepository(repository *elkv1alpha1.ElasticsearchSnapshotRepository, data map[string]any) (*olivere.SnapshotRepositoryMetaData, error) {
+	var secretSettings map[string]any
+	if d, ok := data["secretSettings"]; ok {
+		secretSettings = d.(map[string]any)
+	}
+
+	return repository.ToRepository(secretSettings)
+}
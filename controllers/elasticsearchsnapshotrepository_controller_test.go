@@ -6,6 +6,7 @@ import (
 	"time"
 
 	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
 	"github.com/disaster37/operator-elk-extra/pkg/helpers"
 	"github.com/disaster37/operator-elk-extra/pkg/mocks"
 	"github.com/disaster37/operator-sdk-extra/pkg/test"
@@ -16,7 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	//core "k8s.io/api/core/v1"
+	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	condition "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -110,6 +111,9 @@ func doMockSnapshotRepo(mockES *mocks.MockElasticsearchHandler) func(stepName *s
 				data["isCreated"] = true
 				return nil
 			case "update":
+				if policy.Settings["url"] != "http://fake2" {
+					return errors.New("Setting url is not read from Secret")
+				}
 				isUpdated = true
 				data["isUpdated"] = true
 				return nil
@@ -118,6 +122,16 @@ func doMockSnapshotRepo(mockES *mocks.MockElasticsearchHandler) func(stepName *s
 			return nil
 		})
 
+		mockES.EXPECT().SnapshotRepositoryVerify(gomock.Any()).AnyTimes().Return([]string{"node-1"}, nil)
+
+		mockES.EXPECT().SnapshotRepositoryCleanup(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.SnapshotRepositoryCleanupResult, error) {
+			data["isCleaned"] = true
+			return &elasticsearchhandler.SnapshotRepositoryCleanupResult{
+				DeletedBytes: 20,
+				DeletedBlobs: 5,
+			}, nil
+		})
+
 		mockES.EXPECT().SnapshotRepositoryDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
 			data["isDeleted"] = true
 			return nil
@@ -193,11 +207,32 @@ func doUpdateSnapshotRepoStep() test.TestStep {
 			}
 			repo := o.(*elkv1alpha1.ElasticsearchSnapshotRepository)
 
-			repo.Spec.Settings = `
+			secret := &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				StringData: map[string]string{
+					"url": "http://fake2",
+				},
+			}
+			if err = c.Create(context.Background(), secret); err != nil {
+				return err
+			}
+
+			repo.Spec.Settings = ""
+			repo.Spec.SecretSettings = []elkv1alpha1.ElasticsearchSnapshotRepositorySecretSetting{
 				{
-					"url" : "http://fake2"
-				}
-			`
+					Name: "url",
+					SecretKeyRef: elkv1alpha1.ElasticsearchSnapshotRepositorySecretKeyRef{
+						Name: key.Name,
+						Key:  "url",
+					},
+				},
+			}
+			repo.Spec.Cleanup = &elkv1alpha1.ElasticsearchSnapshotRepositoryCleanup{
+				Interval: metav1.Duration{Duration: 24 * time.Hour},
+			}
 			if err = c.Update(context.Background(), repo); err != nil {
 				return err
 			}
@@ -218,12 +253,17 @@ func doUpdateSnapshotRepoStep() test.TestStep {
 				if !isUpdated {
 					return errors.New("Not yet updated")
 				}
+				if repo.Status.LastCleanup == nil {
+					return errors.New("Not yet cleaned up")
+				}
 				return nil
 			}, time.Second*30, time.Second*1)
 			if err != nil || isTimeout {
 				t.Fatalf("Failed to get Snapshot repository: %s", err.Error())
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(repo.Status.Conditions, repositoryCondition, metav1.ConditionTrue))
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(repo.Status.Conditions, repositoryVerifiedCondition, metav1.ConditionTrue))
+			assert.Equal(t, int64(20), repo.Status.LastCleanup.DeletedBytes)
 
 			return nil
 		},
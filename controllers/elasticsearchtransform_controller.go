@@ -0,0 +1,403 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	transformFinalizer = "transform.elk.k8s.webcenter.fr/finalizer"
+	transformCondition = "UpdateTransform"
+	transformFailed    = "failed"
+)
+
+// ElasticsearchTransformReconciler reconciles a ElasticsearchTransform object
+type ElasticsearchTransformReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchtransforms,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchtransforms/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=elasticsearchtransforms/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The transform is reconciled periodically to keep the status up to date with the stats.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ElasticsearchTransformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, transformFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	transform := &elkv1alpha1.ElasticsearchTransform{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, transform, data)
+	return requeueToRefreshStatus(transform, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchTransformReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.ElasticsearchTransform{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *ElasticsearchTransformReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(transform.Status.Conditions, transformCondition) == nil {
+		condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+			Type:   transformCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &transform.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current transform and its stats
+func (r *ElasticsearchTransformReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read transform from Elasticsearch
+	currentTransform, err := esHandler.TransformGet(transform.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get transform from Elasticsearch")
+	}
+	data["transform"] = currentTransform
+
+	// Read transform stats from Elasticsearch
+	var stats *elasticsearchhandler.TransformStats
+	if currentTransform != nil {
+		stats, err = esHandler.TransformStats(transform.Name)
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get transform stats from Elasticsearch")
+		}
+	}
+	data["stats"] = stats
+
+	return res, nil
+}
+
+// Create add new transform and start it if needed
+func (r *ElasticsearchTransformReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+
+	expectedTransform, err := transform.ToTransform()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert transform")
+	}
+
+	// Create transform on Elasticsearch
+	if err = esHandler.TransformCreate(transform.Name, expectedTransform); err != nil {
+		return res, errors.Wrap(err, "Error when create transform")
+	}
+
+	if transform.IsStarted() {
+		if err = esHandler.TransformStart(transform.Name); err != nil {
+			return res, errors.Wrap(err, "Error when start transform")
+		}
+	}
+
+	return res, nil
+}
+
+// Update permit to update transform from Elasticsearch
+// When fields that can't be updated have changed, the transform is stopped, deleted and created again
+func (r *ElasticsearchTransformReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+
+	expectedTransform, err := transform.ToTransform()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert transform")
+	}
+
+	if needRecreate, ok := data["needRecreate"].(bool); ok && needRecreate {
+		r.recorder.Event(resource, core.EventTypeNormal, "Recreate", "Transform fields that can't be updated have changed, it will be recreated")
+
+		if err = stopTransform(esHandler, transform.Name, data); err != nil {
+			return res, errors.Wrap(err, "Error when stop transform")
+		}
+		if err = esHandler.TransformDelete(transform.Name); err != nil {
+			return res, errors.Wrap(err, "Error when delete transform")
+		}
+
+		return r.Create(ctx, resource, data, meta)
+	}
+
+	if needUpdate, ok := data["needUpdate"].(bool); ok && needUpdate {
+		if err = esHandler.TransformUpdate(transform.Name, expectedTransform); err != nil {
+			return res, errors.Wrap(err, "Error when update transform")
+		}
+	}
+
+	// Align the transform state
+	var stats *elasticsearchhandler.TransformStats
+	if d, ok := data["stats"]; ok {
+		stats = d.(*elasticsearchhandler.TransformStats)
+	}
+	if !isTransformOnExpectedState(transform, expectedTransform, stats) {
+		if transform.IsStarted() {
+			if err = esHandler.TransformStart(transform.Name); err != nil {
+				return res, errors.Wrap(err, "Error when start transform")
+			}
+			r.recorder.Event(resource, core.EventTypeNormal, "Started", "Transform started")
+		} else {
+			if err = stopTransform(esHandler, transform.Name, data); err != nil {
+				return res, errors.Wrap(err, "Error when stop transform")
+			}
+			r.recorder.Event(resource, core.EventTypeNormal, "Stopped", "Transform stopped")
+		}
+	}
+
+	return res, nil
+}
+
+// Delete permit to stop and delete transform from Elasticsearch
+func (r *ElasticsearchTransformReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+
+	if err = stopTransform(esHandler, transform.Name, data); err != nil {
+		return errors.Wrap(err, "Error when stop transform")
+	}
+
+	if err = esHandler.TransformDelete(transform.Name); err != nil {
+		return errors.Wrap(err, "Error when delete transform")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected transform exist
+// It also check if the transform is on the expected state
+func (r *ElasticsearchTransformReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+	var currentTransform *elasticsearchhandler.Transform
+	var stats *elasticsearchhandler.TransformStats
+	var d any
+
+	d, err = helper.Get(data, "transform")
+	if err != nil {
+		return diff, err
+	}
+	currentTransform = d.(*elasticsearchhandler.Transform)
+
+	d, err = helper.Get(data, "stats")
+	if err != nil {
+		return diff, err
+	}
+	stats = d.(*elasticsearchhandler.TransformStats)
+
+	expectedTransform, err := transform.ToTransform()
+	if err != nil {
+		return diff, errors.Wrap(err, "Error when convert transform")
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentTransform == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Transform not exist"
+		return diff, nil
+	}
+
+	diffStr, err := esHandler.TransformDiff(currentTransform, expectedTransform)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		data["needUpdate"] = true
+
+		// Pivot, latest and sync mode can't be updated
+		diffStr, err = esHandler.TransformDiff(
+			&elasticsearchhandler.Transform{Pivot: currentTransform.Pivot, Latest: currentTransform.Latest},
+			&elasticsearchhandler.Transform{Pivot: expectedTransform.Pivot, Latest: expectedTransform.Latest},
+		)
+		if err != nil {
+			return diff, err
+		}
+		if diffStr != "" || (currentTransform.Sync == nil) != (expectedTransform.Sync == nil) {
+			data["needRecreate"] = true
+		}
+	}
+
+	if !isTransformOnExpectedState(transform, expectedTransform, stats) {
+		diff.NeedUpdate = true
+		diff.Diff = fmt.Sprintf("%sTransform must be %s\n", diff.Diff, transform.Spec.State)
+	}
+
+	return diff, nil
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *ElasticsearchTransformReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+		Type:    transformCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also refresh the status from transform stats
+// A failed transform is reported on condition, it's not started again until it's stopped
+func (r *ElasticsearchTransformReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	transform := resource.(*elkv1alpha1.ElasticsearchTransform)
+
+	if d, ok := data["stats"]; ok && d.(*elasticsearchhandler.TransformStats) != nil {
+		stats := d.(*elasticsearchhandler.TransformStats)
+		transform.Status.State = stats.State
+		transform.Status.Checkpoint = stats.Checkpointing.Last.Checkpoint
+		transform.Status.DocumentsProcessed = stats.Stats.DocumentsProcessed
+		if stats.Health != nil {
+			transform.Status.Health = stats.Health.Status
+		}
+
+		if stats.State == transformFailed && transform.IsStarted() {
+			message := fmt.Sprintf("Transform failed: %s. Set state to stopped then started to restart it", stats.Reason)
+			if !condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, transformCondition, v1.ConditionFalse) {
+				r.recorder.Event(resource, core.EventTypeWarning, "Failed", message)
+			}
+			condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+				Type:    transformCondition,
+				Status:  v1.ConditionFalse,
+				Reason:  "TransformFailed",
+				Message: message,
+			})
+
+			return nil
+		}
+	}
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+			Type:    transformCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Transform successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+			Type:    transformCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Transform successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, transformCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&transform.Status.Conditions, v1.Condition{
+			Type:    transformCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Transform already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Transform already set")
+	}
+
+	return nil
+}
+
+// stopTransform permit to stop the transform
+// The failed transform can only be stopped with force
+func stopTransform(esHandler elasticsearchhandler.ElasticsearchHandler, name string, data map[string]any) (err error) {
+	if stats, ok := data["stats"].(*elasticsearchhandler.TransformStats); ok && stats != nil && stats.State == transformFailed {
+		return esHandler.TransformForceStop(name)
+	}
+
+	return esHandler.TransformStop(name)
+}
+
+// isTransformOnExpectedState permit to know if the transform run or not as expected
+// A batch transform stop itself when it's done, so it's considered as on expected state
+func isTransformOnExpectedState(transform *elkv1alpha1.ElasticsearchTransform, expectedTransform *elasticsearchhandler.Transform, stats *elasticsearchhandler.TransformStats) bool {
+	if stats == nil {
+		return true
+	}
+
+	isRunning := stats.State == "started" || stats.State == "indexing"
+
+	if transform.IsStarted() {
+		// Elasticsearch refuse to start a failed transform, so the failure is only reported
+		if isRunning || stats.State == transformFailed {
+			return true
+		}
+		return expectedTransform.Sync == nil && stats.State == "stopped" && stats.Checkpointing.Last.Checkpoint > 0
+	}
+
+	// A failed transform must be force stopped
+	return !isRunning && stats.State != transformFailed
+}
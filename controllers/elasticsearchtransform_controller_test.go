@@ -0,0 +1,385 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestElasticsearchTransformReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-transform-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	transform := &elkv1alpha1.ElasticsearchTransform{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, transform, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateTransformStep(),
+		doUpdateTransformStep(),
+		doFailedTransformStep(),
+		doDeleteTransformStep(),
+	}
+	testCase.PreTest = doMockTransform(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockTransform(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockES.EXPECT().TransformGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.Transform, error) {
+			transform := &elasticsearchhandler.Transform{
+				Source: &elasticsearchhandler.TransformSource{
+					Index: []string{"test"},
+				},
+				Dest: &elasticsearchhandler.TransformDest{
+					Index: "test-transform",
+				},
+				Latest: &elasticsearchhandler.TransformLatest{
+					UniqueKey: []string{"user"},
+					Sort:      "@timestamp",
+				},
+				Sync: &elasticsearchhandler.TransformSync{
+					Time: &elasticsearchhandler.TransformSyncTime{
+						Field: "@timestamp",
+					},
+				},
+				Frequency: "5m",
+			}
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				}
+				return transform, nil
+			case "update":
+				if isUpdated {
+					transform.Frequency = "10m"
+				}
+				return transform, nil
+			case "failed":
+				transform.Frequency = "10m"
+				return transform, nil
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().TransformStats(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.TransformStats, error) {
+			if *stepName == "failed" {
+				return &elasticsearchhandler.TransformStats{
+					ID:     "test",
+					State:  "failed",
+					Reason: "task encountered irrecoverable failure",
+					Health: &elasticsearchhandler.TransformHealth{
+						Status: "red",
+					},
+				}, nil
+			}
+
+			return &elasticsearchhandler.TransformStats{
+				ID:    "test",
+				State: "started",
+				Health: &elasticsearchhandler.TransformHealth{
+					Status: "green",
+				},
+			}, nil
+		})
+
+		mockES.EXPECT().TransformDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.Transform) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockES.EXPECT().TransformCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, transform *elasticsearchhandler.Transform) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockES.EXPECT().TransformUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, transform *elasticsearchhandler.Transform) error {
+			if *stepName == "update" {
+				isUpdated = true
+				data["isUpdated"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().TransformStart(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			if *stepName == "failed" {
+				data["isStartedWhenFailed"] = true
+			}
+			return nil
+		})
+		mockES.EXPECT().TransformStop(gomock.Any()).AnyTimes().Return(nil)
+		mockES.EXPECT().TransformForceStop(gomock.Any()).AnyTimes().Return(nil)
+
+		mockES.EXPECT().TransformDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateTransformStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new transform %s/%s ===", key.Namespace, key.Name)
+
+			transform := &elkv1alpha1.ElasticsearchTransform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.ElasticsearchTransformSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					Source: elkv1alpha1.ElasticsearchTransformSource{
+						Index: []string{"test"},
+					},
+					Dest: elkv1alpha1.ElasticsearchTransformDest{
+						Index: "test-transform",
+					},
+					Latest: &elkv1alpha1.ElasticsearchTransformLatest{
+						UniqueKey: []string{"user"},
+						Sort:      "@timestamp",
+					},
+					Sync: &elkv1alpha1.ElasticsearchTransformSync{
+						Time: elkv1alpha1.ElasticsearchTransformSyncTime{
+							Field: "@timestamp",
+						},
+					},
+					Frequency: "5m",
+				},
+			}
+			if err = c.Create(context.Background(), transform); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			transform := &elkv1alpha1.ElasticsearchTransform{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get transform: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, transformCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateTransformStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update transform %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+			transform := o.(*elkv1alpha1.ElasticsearchTransform)
+
+			transform.Spec.Frequency = "10m"
+			if err = c.Update(context.Background(), transform); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			transform := &elkv1alpha1.ElasticsearchTransform{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get transform: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, transformCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doFailedTransformStep() test.TestStep {
+	return test.TestStep{
+		Name: "failed",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Failed transform %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+			transform := o.(*elkv1alpha1.ElasticsearchTransform)
+
+			// Trigger new reconcile to read the failed state
+			transform.Labels = map[string]string{"test": "failed"}
+			if err = c.Update(context.Background(), transform); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			transform := &elkv1alpha1.ElasticsearchTransform{}
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if !condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, transformCondition, metav1.ConditionFalse) {
+					return errors.New("Not yet failed")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get transform: %s", err.Error())
+			}
+			assert.Equal(t, "failed", transform.Status.State)
+			assert.Equal(t, "TransformFailed", condition.FindStatusCondition(transform.Status.Conditions, transformCondition).Reason)
+			assert.NotContains(t, data, "isStartedWhenFailed")
+
+			return nil
+		},
+	}
+}
+
+func doDeleteTransformStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete transform %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+			transform := o.(*elkv1alpha1.ElasticsearchTransform)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), transform, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			transform := &elkv1alpha1.ElasticsearchTransform{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, transform); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Transform stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
+
+func (t *ControllerTestSuite) TestIsTransformOnExpectedState() {
+	transform := &elkv1alpha1.ElasticsearchTransform{}
+	expectedTransform := &elasticsearchhandler.Transform{
+		Sync: &elasticsearchhandler.TransformSync{},
+	}
+
+	// When transform must be started and it's running
+	assert.True(t.T(), isTransformOnExpectedState(transform, expectedTransform, &elasticsearchhandler.TransformStats{State: "indexing"}))
+
+	// When transform must be started and it's stopped
+	assert.False(t.T(), isTransformOnExpectedState(transform, expectedTransform, &elasticsearchhandler.TransformStats{State: "stopped"}))
+
+	// When transform must be started and it's failed, it's not started again
+	assert.True(t.T(), isTransformOnExpectedState(transform, expectedTransform, &elasticsearchhandler.TransformStats{State: "failed"}))
+
+	// When transform must be stopped and it's failed
+	transform.Spec.State = elkv1alpha1.TransformStopped
+	assert.False(t.T(), isTransformOnExpectedState(transform, expectedTransform, &elasticsearchhandler.TransformStats{State: "failed"}))
+
+	// When transform must be stopped and it's stopped
+	assert.True(t.T(), isTransformOnExpectedState(transform, expectedTransform, &elasticsearchhandler.TransformStats{State: "stopped"}))
+}
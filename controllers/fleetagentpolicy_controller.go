@@ -0,0 +1,313 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	fleetAgentPolicyFinalizer = "fleet-agent-policy.elk.k8s.webcenter.fr/finalizer"
+	fleetAgentPolicyCondition = "UpdateFleetAgentPolicy"
+)
+
+// FleetAgentPolicyReconciler reconciles a FleetAgentPolicy object
+type FleetAgentPolicyReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetagentpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetagentpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetagentpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *FleetAgentPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, fleetAgentPolicyFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	policy := &elkv1alpha1.FleetAgentPolicy{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, policy, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetAgentPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.FleetAgentPolicy{}).
+		Owns(&core.Secret{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *FleetAgentPolicyReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(policy.Status.Conditions, fleetAgentPolicyCondition) == nil {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:   fleetAgentPolicyCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &policy.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current agent policy
+func (r *FleetAgentPolicyReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read agent policy from Kibana
+	currentPolicy, err := kbHandler.FleetAgentPolicyGet(policy.GetPolicyID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get agent policy from Kibana")
+	}
+
+	data["policy"] = currentPolicy
+	return res, nil
+}
+
+// Create add new agent policy
+func (r *FleetAgentPolicyReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+
+	// Create agent policy on Kibana
+	if err = kbHandler.FleetAgentPolicyCreate(policy.ToAgentPolicy()); err != nil {
+		return res, errors.Wrap(err, "Error when create agent policy")
+	}
+
+	return res, nil
+}
+
+// Update permit to update agent policy from Kibana
+func (r *FleetAgentPolicyReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+
+	// Update agent policy on Kibana
+	if err = kbHandler.FleetAgentPolicyUpdate(policy.ToAgentPolicy()); err != nil {
+		return res, errors.Wrap(err, "Error when update agent policy")
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete agent policy from Kibana
+func (r *FleetAgentPolicyReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+
+	if err = kbHandler.FleetAgentPolicyDelete(policy.GetPolicyID()); err != nil {
+		return errors.Wrap(err, "Error when delete agent policy")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected agent policy exist
+func (r *FleetAgentPolicyReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+	var currentPolicy *kibanahandler.FleetAgentPolicy
+	var d any
+
+	d, err = helper.Get(data, "policy")
+	if err != nil {
+		return diff, err
+	}
+	currentPolicy = d.(*kibanahandler.FleetAgentPolicy)
+	expectedPolicy := policy.ToAgentPolicy()
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentPolicy == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Agent policy not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.FleetAgentPolicyDiff(currentPolicy, expectedPolicy)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *FleetAgentPolicyReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+		Type:    fleetAgentPolicyCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+// It also export the enrollment token on Secret
+func (r *FleetAgentPolicyReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	policy := resource.(*elkv1alpha1.FleetAgentPolicy)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	if err = r.exportEnrollmentToken(ctx, policy, kbHandler); err != nil {
+		return errors.Wrap(err, "Error when export enrollment token")
+	}
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetAgentPolicyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Agent policy successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetAgentPolicyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Agent policy successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetAgentPolicyCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetAgentPolicyCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Agent policy already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Agent policy already set")
+	}
+
+	return nil
+}
+
+// exportEnrollmentToken permit to create or update the Secret that store the enrollment token of agent policy
+// The Secret is owned by the agent policy, so it's deleted with it
+func (r *FleetAgentPolicyReconciler) exportEnrollmentToken(ctx context.Context, policy *elkv1alpha1.FleetAgentPolicy, kbHandler kibanahandler.KibanaHandler) (err error) {
+	token, err := kbHandler.FleetEnrollmentTokenGet(policy.GetPolicyID())
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return errors.Errorf("Agent policy %s has no active enrollment token", policy.GetPolicyID())
+	}
+
+	secret := &core.Secret{}
+	secretNS := types.NamespacedName{
+		Namespace: policy.Namespace,
+		Name:      policy.GetEnrollmentTokenSecretName(),
+	}
+	if err = r.Get(ctx, secretNS, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Error when get Secret %s", secretNS.Name)
+		}
+
+		secret = &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      secretNS.Name,
+				Namespace: secretNS.Namespace,
+			},
+			Type: core.SecretTypeOpaque,
+			Data: map[string][]byte{
+				elkv1alpha1.FleetEnrollmentTokenKey: []byte(token.APIKey),
+			},
+		}
+		if err = ctrl.SetControllerReference(policy, secret, r.Scheme); err != nil {
+			return errors.Wrapf(err, "Error when set owner on Secret %s", secretNS.Name)
+		}
+		if err = r.Client.Create(ctx, secret); err != nil {
+			return errors.Wrapf(err, "Error when create Secret %s", secretNS.Name)
+		}
+		r.recorder.Eventf(policy, core.EventTypeNormal, "Completed", "Enrollment token exported on Secret %s", secretNS.Name)
+	} else if string(secret.Data[elkv1alpha1.FleetEnrollmentTokenKey]) != token.APIKey {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[elkv1alpha1.FleetEnrollmentTokenKey] = []byte(token.APIKey)
+		if err = r.Client.Update(ctx, secret); err != nil {
+			return errors.Wrapf(err, "Error when update Secret %s", secretNS.Name)
+		}
+		r.recorder.Eventf(policy, core.EventTypeNormal, "Completed", "Enrollment token updated on Secret %s", secretNS.Name)
+	}
+
+	policy.Status.EnrollmentTokenID = token.ID
+
+	return nil
+}
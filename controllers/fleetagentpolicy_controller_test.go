@@ -0,0 +1,287 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestFleetAgentPolicyReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-fleet-agent-policy-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	policy := &elkv1alpha1.FleetAgentPolicy{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, policy, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateFleetAgentPolicyStep(),
+		doUpdateFleetAgentPolicyStep(),
+		doDeleteFleetAgentPolicyStep(),
+	}
+	testCase.PreTest = doMockFleetAgentPolicy(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockFleetAgentPolicy(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockKB.EXPECT().FleetAgentPolicyGet(gomock.Any()).AnyTimes().DoAndReturn(func(id string) (*kibanahandler.FleetAgentPolicy, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.FleetAgentPolicy{
+						ID:        id,
+						Name:      "Linux servers",
+						Namespace: "default",
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.FleetAgentPolicy{
+						ID:        id,
+						Name:      "Linux servers",
+						Namespace: "default",
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.FleetAgentPolicy{
+						ID:          id,
+						Name:        "Linux servers",
+						Namespace:   "default",
+						Description: "Agent policy of linux servers",
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().FleetAgentPolicyDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.FleetAgentPolicy) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().FleetAgentPolicyCreate(gomock.Any()).AnyTimes().DoAndReturn(func(policy *kibanahandler.FleetAgentPolicy) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().FleetAgentPolicyUpdate(gomock.Any()).AnyTimes().DoAndReturn(func(policy *kibanahandler.FleetAgentPolicy) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().FleetAgentPolicyDelete(gomock.Any()).AnyTimes().DoAndReturn(func(id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		mockKB.EXPECT().FleetEnrollmentTokenGet(gomock.Any()).AnyTimes().DoAndReturn(func(policyID string) (*kibanahandler.FleetEnrollmentAPIKey, error) {
+			resp := &kibanahandler.FleetEnrollmentAPIKey{
+				ID:       "token-id",
+				APIKey:   "token",
+				PolicyID: policyID,
+				Active:   true,
+			}
+			return resp, nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateFleetAgentPolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new agent policy %s/%s ===", key.Namespace, key.Name)
+
+			policy := &elkv1alpha1.FleetAgentPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.FleetAgentPolicySpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Name: "Linux servers",
+				},
+			}
+			if err = c.Create(context.Background(), policy); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetAgentPolicy{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, policy); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get agent policy: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetAgentPolicyCondition, metav1.ConditionTrue))
+
+			// Enrollment token is exported on Secret
+			secret := &core.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: policy.GetEnrollmentTokenSecretName()}, secret); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, "token", string(secret.Data[elkv1alpha1.FleetEnrollmentTokenKey]))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateFleetAgentPolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update agent policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Policy is null")
+			}
+			policy := o.(*elkv1alpha1.FleetAgentPolicy)
+
+			policy.Spec.Description = "Agent policy of linux servers"
+			if err = c.Update(context.Background(), policy); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetAgentPolicy{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, policy); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get agent policy: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetAgentPolicyCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteFleetAgentPolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete agent policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Policy is null")
+			}
+			policy := o.(*elkv1alpha1.FleetAgentPolicy)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), policy, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetAgentPolicy{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, policy); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Policy stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	fleetPackagePolicyFinalizer = "fleet-package-policy.elk.k8s.webcenter.fr/finalizer"
+	fleetPackagePolicyCondition = "UpdateFleetPackagePolicy"
+)
+
+// FleetPackagePolicyReconciler reconciles a FleetPackagePolicy object
+type FleetPackagePolicyReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetpackagepolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetpackagepolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetpackagepolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=fleetagentpolicies,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *FleetPackagePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, fleetPackagePolicyFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	policy := &elkv1alpha1.FleetPackagePolicy{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, policy, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetPackagePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.FleetPackagePolicy{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *FleetPackagePolicyReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(policy.Status.Conditions, fleetPackagePolicyCondition) == nil {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:   fleetPackagePolicyCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &policy.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current package policy
+// It also resolve the agent policy ID from FleetAgentPolicy resource
+func (r *FleetPackagePolicyReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read package policy from Kibana
+	currentPolicy, err := kbHandler.FleetPackagePolicyGet(policy.GetPackagePolicyID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get package policy from Kibana")
+	}
+	data["policy"] = currentPolicy
+
+	// Resolve agent policy
+	data["policyID"] = policy.Spec.PolicyID
+	if policy.Spec.AgentPolicy != "" && policy.DeletionTimestamp.IsZero() {
+		if policy.Spec.PolicyID != "" {
+			return res, errors.New("agentPolicy and policy_id are mutually exclusive")
+		}
+
+		agentPolicy := &elkv1alpha1.FleetAgentPolicy{}
+		agentPolicyNS := types.NamespacedName{
+			Namespace: policy.Namespace,
+			Name:      policy.Spec.AgentPolicy,
+		}
+		if err = r.Get(ctx, agentPolicyNS, agentPolicy); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.log.Warnf("Agent policy %s not yet exist, try later", policy.Spec.AgentPolicy)
+				r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Agent policy %s not yet exist", policy.Spec.AgentPolicy)
+				return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+			}
+			return res, errors.Wrapf(err, "Error when get agent policy %s", policy.Spec.AgentPolicy)
+		}
+		data["policyID"] = agentPolicy.GetPolicyID()
+	}
+
+	return res, nil
+}
+
+// Create add new package policy
+func (r *FleetPackagePolicyReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+
+	// Create package policy on Kibana
+	expectedPolicy, err := r.toPackagePolicy(policy, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to package policy")
+	}
+	if err = kbHandler.FleetPackagePolicyCreate(expectedPolicy); err != nil {
+		return res, errors.Wrap(err, "Error when create package policy")
+	}
+
+	return res, nil
+}
+
+// Update permit to update package policy from Kibana
+func (r *FleetPackagePolicyReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+
+	// Update package policy on Kibana
+	expectedPolicy, err := r.toPackagePolicy(policy, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to package policy")
+	}
+	if err = kbHandler.FleetPackagePolicyUpdate(expectedPolicy); err != nil {
+		return res, errors.Wrap(err, "Error when update package policy")
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete package policy from Kibana
+func (r *FleetPackagePolicyReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+
+	if err = kbHandler.FleetPackagePolicyDelete(policy.GetPackagePolicyID()); err != nil {
+		return errors.Wrap(err, "Error when delete package policy")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected package policy exist
+func (r *FleetPackagePolicyReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+	var currentPolicy *kibanahandler.FleetPackagePolicy
+	var d any
+
+	d, err = helper.Get(data, "policy")
+	if err != nil {
+		return diff, err
+	}
+	currentPolicy = d.(*kibanahandler.FleetPackagePolicy)
+	expectedPolicy, err := r.toPackagePolicy(policy, data)
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentPolicy == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Package policy not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.FleetPackagePolicyDiff(currentPolicy, expectedPolicy)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// toPackagePolicy permit to convert the package policy with the agent policy ID resolved from FleetAgentPolicy resource
+func (r *FleetPackagePolicyReconciler) toPackagePolicy(policy *elkv1alpha1.FleetPackagePolicy, data map[string]any) (*kibanahandler.FleetPackagePolicy, error) {
+	d, err := helper.Get(data, "policyID")
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.ToPackagePolicy(d.(string))
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *FleetPackagePolicyReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+		Type:    fleetPackagePolicyCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *FleetPackagePolicyReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	policy := resource.(*elkv1alpha1.FleetPackagePolicy)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetPackagePolicyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Package policy successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetPackagePolicyCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Package policy successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetPackagePolicyCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&policy.Status.Conditions, v1.Condition{
+			Type:    fleetPackagePolicyCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Package policy already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Package policy already set")
+	}
+
+	return nil
+}
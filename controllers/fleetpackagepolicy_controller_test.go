@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestFleetPackagePolicyReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-fleet-package-policy-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	policy := &elkv1alpha1.FleetPackagePolicy{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, policy, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateFleetPackagePolicyStep(),
+		doUpdateFleetPackagePolicyStep(),
+		doDeleteFleetPackagePolicyStep(),
+	}
+	testCase.PreTest = doMockFleetPackagePolicy(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockFleetPackagePolicy(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockKB.EXPECT().FleetPackagePolicyGet(gomock.Any()).AnyTimes().DoAndReturn(func(id string) (*kibanahandler.FleetPackagePolicy, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.FleetPackagePolicy{
+						ID:       id,
+						Name:     id,
+						PolicyID: "linux",
+						Package: kibanahandler.FleetPackagePolicyPackage{
+							Name:    "system",
+							Version: "1.20.4",
+						},
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.FleetPackagePolicy{
+						ID:       id,
+						Name:     id,
+						PolicyID: "linux",
+						Package: kibanahandler.FleetPackagePolicyPackage{
+							Name:    "system",
+							Version: "1.20.4",
+						},
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.FleetPackagePolicy{
+						ID:       id,
+						Name:     id,
+						PolicyID: "linux",
+						Package: kibanahandler.FleetPackagePolicyPackage{
+							Name:    "system",
+							Version: "1.21.0",
+						},
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().FleetPackagePolicyDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.FleetPackagePolicy) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().FleetPackagePolicyCreate(gomock.Any()).AnyTimes().DoAndReturn(func(policy *kibanahandler.FleetPackagePolicy) error {
+			switch *stepName {
+			case "create":
+				if policy.PolicyID != "linux" || policy.Inputs["system-logfile"] == nil {
+					return errors.New("Package policy not converted")
+				}
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().FleetPackagePolicyUpdate(gomock.Any()).AnyTimes().DoAndReturn(func(policy *kibanahandler.FleetPackagePolicy) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().FleetPackagePolicyDelete(gomock.Any()).AnyTimes().DoAndReturn(func(id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateFleetPackagePolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new package policy %s/%s ===", key.Namespace, key.Name)
+
+			policy := &elkv1alpha1.FleetPackagePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.FleetPackagePolicySpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					PolicyID: "linux",
+					Package: elkv1alpha1.FleetPackagePolicyPackage{
+						Name:    "system",
+						Version: "1.20.4",
+					},
+					Inputs: &runtime.RawExtension{
+						Raw: []byte(`{"system-logfile": {"enabled": true, "streams": {"system.auth": {"vars": {"paths": ["/var/log/auth.log*"]}}}}}`),
+					},
+				},
+			}
+			if err = c.Create(context.Background(), policy); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetPackagePolicy{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, policy); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get package policy: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetPackagePolicyCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateFleetPackagePolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update package policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Policy is null")
+			}
+			policy := o.(*elkv1alpha1.FleetPackagePolicy)
+
+			policy.Spec.Package.Version = "1.21.0"
+			if err = c.Update(context.Background(), policy); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetPackagePolicy{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, policy); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get package policy: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(policy.Status.Conditions, fleetPackagePolicyCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteFleetPackagePolicyStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete package policy %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Policy is null")
+			}
+			policy := o.(*elkv1alpha1.FleetPackagePolicy)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), policy, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			policy := &elkv1alpha1.FleetPackagePolicy{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, policy); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Policy stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaAlertRuleFinalizer = "kibana-alert-rule.elk.k8s.webcenter.fr/finalizer"
+	kibanaAlertRuleCondition = "UpdateKibanaAlertRule"
+)
+
+// KibanaAlertRuleReconciler reconciles a KibanaAlertRule object
+type KibanaAlertRuleReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaalertrules,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaalertrules/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaalertrules/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaconnectors,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The rule is reconciled periodically to keep the status up to date with the execution status.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaAlertRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaAlertRuleFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rule := &elkv1alpha1.KibanaAlertRule{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, rule, data)
+	return requeueToRefreshStatus(rule, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaAlertRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaAlertRule{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaAlertRuleReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(rule.Status.Conditions, kibanaAlertRuleCondition) == nil {
+		condition.SetStatusCondition(&rule.Status.Conditions, v1.Condition{
+			Type:   kibanaAlertRuleCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &rule.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current rule and update its execution status
+// It also resolve the connector IDs from KibanaConnector resources
+func (r *KibanaAlertRuleReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read rule from Kibana
+	currentRule, err := kbHandler.AlertRuleGet(rule.GetSpace(), rule.GetRuleID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get rule from Kibana")
+	}
+
+	// Update execution status
+	rule.Status.ExecutionStatus = ""
+	rule.Status.LastExecutionDate = ""
+	rule.Status.LastRunOutcome = ""
+	rule.Status.Error = ""
+	if currentRule != nil {
+		rule.Status.ExecutionStatus = currentRule.ExecutionStatus.Status
+		rule.Status.LastExecutionDate = currentRule.ExecutionStatus.LastExecutionDate
+		if currentRule.LastRun != nil {
+			rule.Status.LastRunOutcome = currentRule.LastRun.Outcome
+		}
+		if currentRule.ExecutionStatus.Error != nil {
+			rule.Status.Error = fmt.Sprintf("%s: %s", currentRule.ExecutionStatus.Error.Reason, currentRule.ExecutionStatus.Error.Message)
+		}
+	}
+
+	// Resolve connectors
+	if rule.DeletionTimestamp.IsZero() {
+		connectorIDs := make(map[string]string, len(rule.Spec.Actions))
+		for _, action := range rule.Spec.Actions {
+			connector := &elkv1alpha1.KibanaConnector{}
+			connectorNS := types.NamespacedName{
+				Namespace: rule.Namespace,
+				Name:      action.Connector,
+			}
+			if err = r.Get(ctx, connectorNS, connector); err != nil {
+				if k8serrors.IsNotFound(err) {
+					r.log.Warnf("Connector %s not yet exist, try later", action.Connector)
+					r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Connector %s not yet exist", action.Connector)
+					return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+				}
+				return res, errors.Wrapf(err, "Error when get connector %s", action.Connector)
+			}
+			if connector.GetSpace() != rule.GetSpace() {
+				return res, errors.Errorf("Connector %s is on space %s, but rule is on space %s", action.Connector, connector.GetSpace(), rule.GetSpace())
+			}
+			connectorIDs[action.Connector] = connector.GetConnectorID()
+		}
+		data["connectorIDs"] = connectorIDs
+	}
+
+	if currentRule != nil {
+		data["rule"] = &currentRule.KibanaAlertRule
+	} else {
+		data["rule"] = (*kibanahandler.KibanaAlertRule)(nil)
+	}
+
+	return res, nil
+}
+
+// Create add new rule
+func (r *KibanaAlertRuleReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+
+	// Create rule on Kibana
+	expectedRule, err := r.toAlertRule(rule, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to rule")
+	}
+	if err = kbHandler.AlertRuleCreate(rule.GetSpace(), expectedRule); err != nil {
+		return res, errors.Wrap(err, "Error when create rule")
+	}
+
+	return res, nil
+}
+
+// Update permit to update rule from Kibana
+func (r *KibanaAlertRuleReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+
+	// Update rule on Kibana
+	expectedRule, err := r.toAlertRule(rule, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to rule")
+	}
+	if err = kbHandler.AlertRuleUpdate(rule.GetSpace(), expectedRule); err != nil {
+		return res, errors.Wrap(err, "Error when update rule")
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete rule from Kibana
+func (r *KibanaAlertRuleReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+
+	if err = kbHandler.AlertRuleDelete(rule.GetSpace(), rule.GetRuleID()); err != nil {
+		return errors.Wrap(err, "Error when delete rule")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected rule exist
+func (r *KibanaAlertRuleReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+	var currentRule *kibanahandler.KibanaAlertRule
+	var d any
+
+	d, err = helper.Get(data, "rule")
+	if err != nil {
+		return diff, err
+	}
+	currentRule = d.(*kibanahandler.KibanaAlertRule)
+	expectedRule, err := r.toAlertRule(rule, data)
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentRule == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Rule not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.AlertRuleDiff(currentRule, expectedRule)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// toAlertRule permit to convert the rule with the connector IDs resolved from KibanaConnector resources
+func (r *KibanaAlertRuleReconciler) toAlertRule(rule *elkv1alpha1.KibanaAlertRule, data map[string]any) (*kibanahandler.KibanaAlertRule, error) {
+	d, err := helper.Get(data, "connectorIDs")
+	if err != nil {
+		return nil, err
+	}
+
+	return rule.ToAlertRule(d.(map[string]string))
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaAlertRuleReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&rule.Status.Conditions, v1.Condition{
+		Type:    kibanaAlertRuleCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaAlertRuleReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	rule := resource.(*elkv1alpha1.KibanaAlertRule)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&rule.Status.Conditions, v1.Condition{
+			Type:    kibanaAlertRuleCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Rule successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&rule.Status.Conditions, v1.Condition{
+			Type:    kibanaAlertRuleCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Rule successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(rule.Status.Conditions, kibanaAlertRuleCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&rule.Status.Conditions, v1.Condition{
+			Type:    kibanaAlertRuleCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Rule already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Rule already set")
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaAlertRuleReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-alert-rule-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	rule := &elkv1alpha1.KibanaAlertRule{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, rule, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaAlertRuleStep(),
+		doUpdateKibanaAlertRuleStep(),
+		doDeleteKibanaAlertRuleStep(),
+	}
+	testCase.PreTest = doMockKibanaAlertRule(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaAlertRule(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		// Connector used by rule actions
+		expectedConnector := &kibanahandler.KibanaConnector{
+			ID:              "t-kb-alert-rule-connector",
+			Name:            "t-kb-alert-rule-connector",
+			ConnectorTypeID: ".server-log",
+		}
+		mockKB.EXPECT().ConnectorGet(gomock.Any(), gomock.Eq(expectedConnector.ID)).AnyTimes().Return(expectedConnector, nil)
+		mockKB.EXPECT().ConnectorDiff(gomock.Any(), gomock.Eq(expectedConnector)).AnyTimes().Return("", nil)
+
+		mockKB.EXPECT().AlertRuleGet(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space, id string) (*kibanahandler.KibanaAlertRuleInfo, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.KibanaAlertRuleInfo{
+						KibanaAlertRule: kibanahandler.KibanaAlertRule{
+							ID:         id,
+							Name:       "my-rule",
+							RuleTypeID: ".index-threshold",
+						},
+						ExecutionStatus: kibanahandler.KibanaAlertRuleExecutionStatus{
+							Status: "ok",
+						},
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.KibanaAlertRuleInfo{
+						KibanaAlertRule: kibanahandler.KibanaAlertRule{
+							ID:         id,
+							Name:       "my-rule",
+							RuleTypeID: ".index-threshold",
+						},
+						ExecutionStatus: kibanahandler.KibanaAlertRuleExecutionStatus{
+							Status: "ok",
+						},
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.KibanaAlertRuleInfo{
+						KibanaAlertRule: kibanahandler.KibanaAlertRule{
+							ID:         id,
+							Name:       "my-rule",
+							RuleTypeID: ".index-threshold",
+							Tags:       []string{"cpu"},
+						},
+						ExecutionStatus: kibanahandler.KibanaAlertRuleExecutionStatus{
+							Status: "ok",
+						},
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().AlertRuleDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.KibanaAlertRule) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().AlertRuleCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, rule *kibanahandler.KibanaAlertRule) error {
+			switch *stepName {
+			case "create":
+				if len(rule.Actions) != 1 || rule.Actions[0].ID != expectedConnector.ID {
+					return errors.New("Connector not resolved")
+				}
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().AlertRuleUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, rule *kibanahandler.KibanaAlertRule) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().AlertRuleDelete(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space, id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaAlertRuleStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new rule %s/%s ===", key.Namespace, key.Name)
+
+			// Create connector used by rule
+			connector := &elkv1alpha1.KibanaConnector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "t-kb-alert-rule-connector",
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaConnectorSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					ConnectorTypeID: ".server-log",
+				},
+			}
+			if err = c.Create(context.Background(), connector); err != nil {
+				return err
+			}
+
+			rule := &elkv1alpha1.KibanaAlertRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaAlertRuleSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Name:       "my-rule",
+					RuleTypeID: ".index-threshold",
+					Schedule:   "1m",
+					Params:     `{"index": ["logs-*"], "timeField": "@timestamp", "aggType": "count", "groupBy": "all", "timeWindowSize": 5, "timeWindowUnit": "m", "thresholdComparator": ">", "threshold": [1000]}`,
+					Actions: []elkv1alpha1.KibanaAlertRuleSpecAction{
+						{
+							Group:     "threshold met",
+							Connector: "t-kb-alert-rule-connector",
+							Params:    `{"message": "Too many logs"}`,
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), rule); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			rule := &elkv1alpha1.KibanaAlertRule{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, rule); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get rule: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(rule.Status.Conditions, kibanaAlertRuleCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaAlertRuleStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update rule %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Rule is null")
+			}
+			rule := o.(*elkv1alpha1.KibanaAlertRule)
+
+			rule.Spec.Tags = []string{"cpu"}
+			if err = c.Update(context.Background(), rule); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			rule := &elkv1alpha1.KibanaAlertRule{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, rule); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get rule: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(rule.Status.Conditions, kibanaAlertRuleCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaAlertRuleStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete rule %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Rule is null")
+			}
+			rule := o.(*elkv1alpha1.KibanaAlertRule)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), rule, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			rule := &elkv1alpha1.KibanaAlertRule{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, rule); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Rule stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaConnectorFinalizer = "kibana-connector.elk.k8s.webcenter.fr/finalizer"
+	kibanaConnectorCondition = "UpdateKibanaConnector"
+)
+
+// KibanaConnectorReconciler reconciles a KibanaConnector object
+type KibanaConnectorReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaconnectors,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaconnectors/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaconnectors/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The connector is reconciled periodically to detect changes on Secret.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaConnectorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaConnectorFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	connector := &elkv1alpha1.KibanaConnector{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, connector, data)
+	return requeueToRefreshStatus(connector, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaConnectorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaConnector{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaConnectorReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(connector.Status.Conditions, kibanaConnectorCondition) == nil {
+		condition.SetStatusCondition(&connector.Status.Conditions, v1.Condition{
+			Type:   kibanaConnectorCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &connector.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current connector
+// It also read the secrets from Secret
+func (r *KibanaConnectorReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read connector from Kibana
+	currentConnector, err := kbHandler.ConnectorGet(connector.GetSpace(), connector.GetConnectorID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get connector from Kibana")
+	}
+
+	// Read secrets from Secret if needed
+	if connector.Spec.SecretRef != nil && connector.DeletionTimestamp.IsZero() {
+		secret := &core.Secret{}
+		secretNS := types.NamespacedName{
+			Namespace: connector.Namespace,
+			Name:      connector.Spec.SecretRef.Name,
+		}
+		if err = r.Get(ctx, secretNS, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.log.Warnf("Secret %s not yet exist, try later", connector.Spec.SecretRef.Name)
+				r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Secret %s not yet exist", connector.Spec.SecretRef.Name)
+				return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+			}
+			return res, errors.Wrapf(err, "Error when get secret %s", connector.Spec.SecretRef.Name)
+		}
+		secrets := make(map[string]any, len(secret.Data))
+		for key, value := range secret.Data {
+			secrets[key] = string(value)
+		}
+		data["secrets"] = secrets
+		data["secretResourceVersion"] = secret.ResourceVersion
+	}
+
+	data["connector"] = currentConnector
+	return res, nil
+}
+
+// Create add new connector
+func (r *KibanaConnectorReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+
+	// Create connector on Kibana
+	expectedConnector, err := r.toConnector(connector, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to connector")
+	}
+	if err = kbHandler.ConnectorCreate(connector.GetSpace(), expectedConnector); err != nil {
+		return res, errors.Wrap(err, "Error when create connector")
+	}
+
+	if d, ok := data["secretResourceVersion"]; ok {
+		connector.Status.SecretResourceVersion = d.(string)
+	}
+
+	return res, nil
+}
+
+// Update permit to update connector from Kibana
+func (r *KibanaConnectorReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+
+	// Update connector on Kibana
+	expectedConnector, err := r.toConnector(connector, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to connector")
+	}
+	if err = kbHandler.ConnectorUpdate(connector.GetSpace(), expectedConnector); err != nil {
+		return res, errors.Wrap(err, "Error when update connector")
+	}
+
+	if d, ok := data["secretResourceVersion"]; ok {
+		connector.Status.SecretResourceVersion = d.(string)
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete connector from Kibana
+func (r *KibanaConnectorReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+
+	if err = kbHandler.ConnectorDelete(connector.GetSpace(), connector.GetConnectorID()); err != nil {
+		return errors.Wrap(err, "Error when delete connector")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected connector exist
+// The secrets are compared with the Secret resource version, because of Kibana never return them
+func (r *KibanaConnectorReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+	var currentConnector *kibanahandler.KibanaConnector
+	var d any
+
+	d, err = helper.Get(data, "connector")
+	if err != nil {
+		return diff, err
+	}
+	currentConnector = d.(*kibanahandler.KibanaConnector)
+	expectedConnector, err := r.toConnector(connector, data)
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentConnector == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Connector not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.ConnectorDiff(currentConnector, expectedConnector)
+	if err != nil {
+		return diff, err
+	}
+
+	if d, ok := data["secretResourceVersion"]; ok && d.(string) != connector.Status.SecretResourceVersion {
+		if diffStr != "" {
+			diffStr += "\n"
+		}
+		diffStr += "Secrets changed"
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// toConnector permit to convert the connector with the secrets read from Secret
+func (r *KibanaConnectorReconciler) toConnector(connector *elkv1alpha1.KibanaConnector, data map[string]any) (*kibanahandler.KibanaConnector, error) {
+	var secrets map[string]any
+	if d, ok := data["secrets"]; ok {
+		secrets = d.(map[string]any)
+	}
+
+	return connector.ToConnector(secrets)
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaConnectorReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&connector.Status.Conditions, v1.Condition{
+		Type:    kibanaConnectorCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaConnectorReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	connector := resource.(*elkv1alpha1.KibanaConnector)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&connector.Status.Conditions, v1.Condition{
+			Type:    kibanaConnectorCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Connector successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&connector.Status.Conditions, v1.Condition{
+			Type:    kibanaConnectorCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Connector successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(connector.Status.Conditions, kibanaConnectorCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&connector.Status.Conditions, v1.Condition{
+			Type:    kibanaConnectorCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Connector already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Connector already set")
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaConnectorReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-connector-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	connector := &elkv1alpha1.KibanaConnector{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, connector, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaConnectorStep(),
+		doUpdateKibanaConnectorStep(),
+		doDeleteKibanaConnectorStep(),
+	}
+	testCase.PreTest = doMockKibanaConnector(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaConnector(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockKB.EXPECT().ConnectorGet(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space, id string) (*kibanahandler.KibanaConnector, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.KibanaConnector{
+						ID:              id,
+						Name:            "my-connector",
+						ConnectorTypeID: ".index",
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.KibanaConnector{
+						ID:              id,
+						Name:            "my-connector",
+						ConnectorTypeID: ".index",
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.KibanaConnector{
+						ID:              id,
+						Name:            "my-connector2",
+						ConnectorTypeID: ".index",
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().ConnectorDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.KibanaConnector) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().ConnectorCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, connector *kibanahandler.KibanaConnector) error {
+			switch *stepName {
+			case "create":
+				if connector.Secrets["user"] != "elastic" {
+					return errors.New("Secrets not provided")
+				}
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().ConnectorUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, connector *kibanahandler.KibanaConnector) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().ConnectorDelete(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space, id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaConnectorStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new connector %s/%s ===", key.Namespace, key.Name)
+
+			// Create secret that store the connector credentials
+			secret := &core.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				StringData: map[string]string{
+					"user":     "elastic",
+					"password": "changeme",
+				},
+			}
+			if err = c.Create(context.Background(), secret); err != nil {
+				return err
+			}
+
+			connector := &elkv1alpha1.KibanaConnector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaConnectorSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Name:            "my-connector",
+					ConnectorTypeID: ".index",
+					Config:          `{"index": "alerts"}`,
+					SecretRef: &elkv1alpha1.KibanaConnectorSecretRef{
+						Name: key.Name,
+					},
+				},
+			}
+			if err = c.Create(context.Background(), connector); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			connector := &elkv1alpha1.KibanaConnector{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, connector); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get connector: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(connector.Status.Conditions, kibanaConnectorCondition, metav1.ConditionTrue))
+			assert.NotEmpty(t, connector.Status.SecretResourceVersion)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaConnectorStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update connector %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Connector is null")
+			}
+			connector := o.(*elkv1alpha1.KibanaConnector)
+
+			connector.Spec.Name = "my-connector2"
+			if err = c.Update(context.Background(), connector); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			connector := &elkv1alpha1.KibanaConnector{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, connector); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get connector: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(connector.Status.Conditions, kibanaConnectorCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaConnectorStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete connector %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Connector is null")
+			}
+			connector := o.(*elkv1alpha1.KibanaConnector)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), connector, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			connector := &elkv1alpha1.KibanaConnector{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, connector); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Connector stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
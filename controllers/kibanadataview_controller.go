@@ -0,0 +1,303 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaDataViewFinalizer = "kibana-data-view.elk.k8s.webcenter.fr/finalizer"
+	kibanaDataViewCondition = "UpdateKibanaDataView"
+)
+
+// KibanaDataViewReconciler reconciles a KibanaDataView object
+type KibanaDataViewReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanadataviews,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanadataviews/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanadataviews/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaDataViewReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaDataViewFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	dataView := &elkv1alpha1.KibanaDataView{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, dataView, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaDataViewReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaDataView{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaDataViewReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(dataView.Status.Conditions, kibanaDataViewCondition) == nil {
+		condition.SetStatusCondition(&dataView.Status.Conditions, v1.Condition{
+			Type:   kibanaDataViewCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &dataView.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current data view
+// It also read the default data view of the space if needed
+func (r *KibanaDataViewReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read data view from Kibana
+	currentDataView, err := kbHandler.DataViewGet(dataView.GetSpace(), dataView.GetDataViewID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get data view from Kibana")
+	}
+
+	// The default data view is only needed to check existing data view
+	if dataView.Spec.Default && currentDataView != nil && dataView.DeletionTimestamp.IsZero() {
+		defaultID, err := kbHandler.DataViewGetDefault(dataView.GetSpace())
+		if err != nil {
+			return res, errors.Wrap(err, "Unable to get default data view from Kibana")
+		}
+		data["defaultID"] = defaultID
+	}
+
+	data["dataView"] = currentDataView
+	return res, nil
+}
+
+// Create add new data view
+func (r *KibanaDataViewReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+
+	// Create data view on Kibana
+	expectedDataView, err := dataView.ToDataView()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to data view")
+	}
+	if err = kbHandler.DataViewCreate(dataView.GetSpace(), expectedDataView); err != nil {
+		return res, errors.Wrap(err, "Error when create data view")
+	}
+
+	if dataView.Spec.Default {
+		if err = kbHandler.DataViewSetDefault(dataView.GetSpace(), dataView.GetDataViewID()); err != nil {
+			return res, errors.Wrap(err, "Error when set default data view")
+		}
+	}
+
+	return res, nil
+}
+
+// Update permit to update data view from Kibana
+func (r *KibanaDataViewReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+
+	// Update data view on Kibana
+	expectedDataView, err := dataView.ToDataView()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to data view")
+	}
+	if err = kbHandler.DataViewUpdate(dataView.GetSpace(), expectedDataView); err != nil {
+		return res, errors.Wrap(err, "Error when update data view")
+	}
+
+	if dataView.Spec.Default {
+		d, err := helper.Get(data, "defaultID")
+		if err != nil {
+			return res, err
+		}
+		if d.(string) != dataView.GetDataViewID() {
+			if err = kbHandler.DataViewSetDefault(dataView.GetSpace(), dataView.GetDataViewID()); err != nil {
+				return res, errors.Wrap(err, "Error when set default data view")
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete data view from Kibana
+func (r *KibanaDataViewReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+
+	if err = kbHandler.DataViewDelete(dataView.GetSpace(), dataView.GetDataViewID()); err != nil {
+		return errors.Wrap(err, "Error when delete data view")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected data view exist
+func (r *KibanaDataViewReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+	var currentDataView *kibanahandler.KibanaDataView
+	var d any
+
+	d, err = helper.Get(data, "dataView")
+	if err != nil {
+		return diff, err
+	}
+	currentDataView = d.(*kibanahandler.KibanaDataView)
+	expectedDataView, err := dataView.ToDataView()
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentDataView == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Data view not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.DataViewDiff(currentDataView, expectedDataView)
+	if err != nil {
+		return diff, err
+	}
+
+	if dataView.Spec.Default {
+		d, err = helper.Get(data, "defaultID")
+		if err != nil {
+			return diff, err
+		}
+		defaultID := d.(string)
+		if defaultID != dataView.GetDataViewID() {
+			if diffStr != "" {
+				diffStr += "\n"
+			}
+			diffStr += fmt.Sprintf("Default data view: %s -> %s", defaultID, dataView.GetDataViewID())
+		}
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaDataViewReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&dataView.Status.Conditions, v1.Condition{
+		Type:    kibanaDataViewCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaDataViewReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	dataView := resource.(*elkv1alpha1.KibanaDataView)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&dataView.Status.Conditions, v1.Condition{
+			Type:    kibanaDataViewCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Data view successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&dataView.Status.Conditions, v1.Condition{
+			Type:    kibanaDataViewCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Data view successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(dataView.Status.Conditions, kibanaDataViewCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&dataView.Status.Conditions, v1.Condition{
+			Type:    kibanaDataViewCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Data view already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Data view already set")
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaDataViewReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-data-view-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	dataView := &elkv1alpha1.KibanaDataView{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, dataView, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaDataViewStep(),
+		doUpdateKibanaDataViewStep(),
+		doDeleteKibanaDataViewStep(),
+	}
+	testCase.PreTest = doMockKibanaDataView(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaDataView(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+		defaultID := ""
+
+		mockKB.EXPECT().DataViewGet(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(dataView, id string) (*kibanahandler.KibanaDataView, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.KibanaDataView{
+						ID:    id,
+						Title: "logs-*",
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.KibanaDataView{
+						ID:    id,
+						Title: "logs-*",
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.KibanaDataView{
+						ID:            id,
+						Title:         "logs-*",
+						TimeFieldName: "@timestamp",
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().DataViewDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.KibanaDataView) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().DataViewGetDefault(gomock.Any()).AnyTimes().DoAndReturn(func(space string) (string, error) {
+			return defaultID, nil
+		})
+
+		mockKB.EXPECT().DataViewSetDefault(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(dataView, id string) error {
+			defaultID = id
+			data["isDefault"] = true
+			return nil
+		})
+
+		mockKB.EXPECT().DataViewCreate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, dataView *kibanahandler.KibanaDataView) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().DataViewUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, dataView *kibanahandler.KibanaDataView) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().DataViewDelete(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(dataView, id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaDataViewStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new data view %s/%s ===", key.Namespace, key.Name)
+
+			dataView := &elkv1alpha1.KibanaDataView{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaDataViewSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Title:   "logs-*",
+					Default: true,
+				},
+			}
+			if err = c.Create(context.Background(), dataView); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			dataView := &elkv1alpha1.KibanaDataView{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, dataView); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get data view: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(dataView.Status.Conditions, kibanaDataViewCondition, metav1.ConditionTrue))
+			assert.True(t, data["isDefault"].(bool))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaDataViewStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update data view %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Data view is null")
+			}
+			dataView := o.(*elkv1alpha1.KibanaDataView)
+
+			dataView.Spec.TimeFieldName = "@timestamp"
+			if err = c.Update(context.Background(), dataView); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			dataView := &elkv1alpha1.KibanaDataView{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, dataView); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get data view: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(dataView.Status.Conditions, kibanaDataViewCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaDataViewStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete data view %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Data view is null")
+			}
+			dataView := o.(*elkv1alpha1.KibanaDataView)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), dataView, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			dataView := &elkv1alpha1.KibanaDataView{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, dataView); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Data view stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
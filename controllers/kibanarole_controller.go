@@ -0,0 +1,248 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaRoleFinalizer = "kibana-role.elk.k8s.webcenter.fr/finalizer"
+	kibanaRoleCondition = "UpdateKibanaRole"
+)
+
+// KibanaRoleReconciler reconciles a KibanaRole object
+type KibanaRoleReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaroles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaroles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaroles/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaRoleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaRoleFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	role := &elkv1alpha1.KibanaRole{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, role, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaRoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaRole{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaRoleReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	role := resource.(*elkv1alpha1.KibanaRole)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(role.Status.Conditions, kibanaRoleCondition) == nil {
+		condition.SetStatusCondition(&role.Status.Conditions, v1.Condition{
+			Type:   kibanaRoleCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &role.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current Kibana role
+func (r *KibanaRoleReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	role := resource.(*elkv1alpha1.KibanaRole)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read role from Kibana
+	currentRole, err := kbHandler.RoleGet(role.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get role from Kibana")
+	}
+
+	data["role"] = currentRole
+	return res, nil
+}
+
+// Create add new Kibana role
+func (r *KibanaRoleReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	role := resource.(*elkv1alpha1.KibanaRole)
+
+	// Create role on Kibana
+	expectedRole, err := role.ToRole()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to Kibana role")
+	}
+	if err = kbHandler.RoleUpdate(role.Name, expectedRole); err != nil {
+		return res, errors.Wrap(err, "Error when update Kibana role")
+	}
+
+	return res, nil
+}
+
+// Update permit to update role from Kibana
+func (r *KibanaRoleReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return r.Create(ctx, resource, data, meta)
+}
+
+// Delete permit to delete role from Kibana
+func (r *KibanaRoleReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	role := resource.(*elkv1alpha1.KibanaRole)
+
+	if err = kbHandler.RoleDelete(role.Name); err != nil {
+		return errors.Wrap(err, "Error when delete Kibana role")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected role exist
+func (r *KibanaRoleReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	role := resource.(*elkv1alpha1.KibanaRole)
+	var currentRole *kibanahandler.KibanaRole
+	var d any
+
+	d, err = helper.Get(data, "role")
+	if err != nil {
+		return diff, err
+	}
+	currentRole = d.(*kibanahandler.KibanaRole)
+	expectedRole, err := role.ToRole()
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentRole == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Kibana role not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.RoleDiff(currentRole, expectedRole)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaRoleReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	role := resource.(*elkv1alpha1.KibanaRole)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&role.Status.Conditions, v1.Condition{
+		Type:    kibanaRoleCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaRoleReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	role := resource.(*elkv1alpha1.KibanaRole)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&role.Status.Conditions, v1.Condition{
+			Type:    kibanaRoleCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Kibana role successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&role.Status.Conditions, v1.Condition{
+			Type:    kibanaRoleCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Kibana role successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(role.Status.Conditions, kibanaRoleCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&role.Status.Conditions, v1.Condition{
+			Type:    kibanaRoleCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Kibana role already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Kibana role already set")
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaRoleReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-role-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	role := &elkv1alpha1.KibanaRole{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, role, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaRoleStep(),
+		doUpdateKibanaRoleStep(),
+		doDeleteKibanaRoleStep(),
+	}
+	testCase.PreTest = doMockKibanaRole(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaRole(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockKB.EXPECT().RoleGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*kibanahandler.KibanaRole, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.KibanaRole{
+						Kibana: []kibanahandler.KibanaRoleKibanaAccess{
+							{
+								Base:    []string{"read"},
+								Feature: map[string][]string{},
+								Spaces:  []string{"default"},
+							},
+						},
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.KibanaRole{
+						Kibana: []kibanahandler.KibanaRoleKibanaAccess{
+							{
+								Base:    []string{"read"},
+								Feature: map[string][]string{},
+								Spaces:  []string{"default"},
+							},
+						},
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.KibanaRole{
+						Kibana: []kibanahandler.KibanaRoleKibanaAccess{
+							{
+								Base:    []string{"all"},
+								Feature: map[string][]string{},
+								Spaces:  []string{"default"},
+							},
+						},
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().RoleDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.KibanaRole) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().RoleUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, role *kibanahandler.KibanaRole) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().RoleDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaRoleStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new Kibana role %s/%s ===", key.Namespace, key.Name)
+
+			role := &elkv1alpha1.KibanaRole{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaRoleSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Kibana: []elkv1alpha1.KibanaRoleSpecKibana{
+						{
+							Base:   []string{"read"},
+							Spaces: []string{"default"},
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), role); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			role := &elkv1alpha1.KibanaRole{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, role); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Kibana role: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(role.Status.Conditions, kibanaRoleCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaRoleStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update Kibana role %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Kibana role is null")
+			}
+			role := o.(*elkv1alpha1.KibanaRole)
+
+			role.Spec.Kibana[0].Base = []string{"all"}
+			if err = c.Update(context.Background(), role); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			role := &elkv1alpha1.KibanaRole{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, role); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Kibana role: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(role.Status.Conditions, kibanaRoleCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaRoleStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete Kibana role %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Kibana role is null")
+			}
+			role := o.(*elkv1alpha1.KibanaRole)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), role, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			role := &elkv1alpha1.KibanaRole{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, role); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Kibana role stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
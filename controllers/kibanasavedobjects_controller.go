@@ -0,0 +1,393 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaSavedObjectsFinalizer = "kibana-saved-objects.elk.k8s.webcenter.fr/finalizer"
+	kibanaSavedObjectsCondition = "UpdateKibanaSavedObjects"
+)
+
+// KibanaSavedObjectsReconciler reconciles a KibanaSavedObjects object
+type KibanaSavedObjectsReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanasavedobjects,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanasavedobjects/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanasavedobjects/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The saved objects are reconciled periodically to detect changes on ConfigMaps.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaSavedObjectsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaSavedObjectsFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	savedObjects := &elkv1alpha1.KibanaSavedObjects{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, savedObjects, data)
+	return requeueToRefreshStatus(savedObjects, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaSavedObjectsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaSavedObjects{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaSavedObjectsReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(savedObjects.Status.Conditions, kibanaSavedObjectsCondition) == nil {
+		condition.SetStatusCondition(&savedObjects.Status.Conditions, v1.Condition{
+			Type:   kibanaSavedObjectsCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &savedObjects.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to read the NDJSON content from ConfigMaps and compute its hash
+// ConfigMaps are not read when the resource is being deleted
+func (r *KibanaSavedObjectsReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+
+	if !savedObjects.DeletionTimestamp.IsZero() {
+		return res, nil
+	}
+
+	ndjson := &bytes.Buffer{}
+	for _, ref := range savedObjects.Spec.ConfigMaps {
+		cm := &core.ConfigMap{}
+		cmNS := types.NamespacedName{
+			Namespace: savedObjects.Namespace,
+			Name:      ref.Name,
+		}
+		if err = r.Get(ctx, cmNS, cm); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.log.Warnf("ConfigMap %s not yet exist, try later", ref.Name)
+				r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "ConfigMap %s not yet exist", ref.Name)
+				return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+			}
+			return res, errors.Wrapf(err, "Error when get ConfigMap %s", ref.Name)
+		}
+
+		keys := ref.Keys
+		if len(keys) == 0 {
+			keys = make([]string, 0, len(cm.Data))
+			for key := range cm.Data {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+		}
+
+		for _, key := range keys {
+			content, ok := cm.Data[key]
+			if !ok {
+				return res, errors.Errorf("ConfigMap %s must have a %s key", ref.Name, key)
+			}
+			ndjson.WriteString(content)
+			if len(content) > 0 && content[len(content)-1] != '\n' {
+				ndjson.WriteString("\n")
+			}
+		}
+	}
+
+	data["ndjson"] = ndjson.Bytes()
+	data["hash"] = fmt.Sprintf("%x", sha256.Sum256(append([]byte(savedObjects.GetSpace()+"\n"), ndjson.Bytes()...)))
+
+	return res, nil
+}
+
+// Create import the saved objects on Kibana
+func (r *KibanaSavedObjectsReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+	var d any
+
+	d, err = helper.Get(data, "ndjson")
+	if err != nil {
+		return res, err
+	}
+	ndjson := d.([]byte)
+	d, err = helper.Get(data, "hash")
+	if err != nil {
+		return res, err
+	}
+	hash := d.(string)
+
+	// Import saved objects on Kibana
+	result, err := kbHandler.SavedObjectsImport(savedObjects.GetSpace(), ndjson)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when import saved objects")
+	}
+
+	objects := make([]elkv1alpha1.KibanaSavedObjectStatus, 0, len(result.SuccessResults))
+	for _, object := range result.SuccessResults {
+		id := object.ID
+		if object.DestinationID != "" {
+			id = object.DestinationID
+		}
+		objects = append(objects, elkv1alpha1.KibanaSavedObjectStatus{
+			Type: object.Type,
+			ID:   id,
+		})
+	}
+	var objectErrors []elkv1alpha1.KibanaSavedObjectStatus
+	for _, object := range result.Errors {
+		objectErrors = append(objectErrors, elkv1alpha1.KibanaSavedObjectStatus{
+			Type:  object.Type,
+			ID:    object.ID,
+			Error: object.String(),
+		})
+	}
+
+	// Delete the objects imported before that are not on ConfigMaps anymore
+	if savedObjects.IsDeletedOnRemove() {
+		for _, object := range removedSavedObjects(savedObjects.Status.Objects, objects, objectErrors, savedObjects.Status.Space != savedObjects.GetSpace()) {
+			if err = kbHandler.SavedObjectDelete(savedObjects.Status.Space, object.Type, object.ID); err != nil {
+				return res, errors.Wrapf(err, "Error when delete saved object %s/%s", object.Type, object.ID)
+			}
+			r.log.Infof("Removed saved object %s/%s deleted", object.Type, object.ID)
+		}
+	}
+
+	// Keep the objects imported before that failed this time, so they can always be deleted later
+	objects = append(objects, failedPreviousSavedObjects(savedObjects.Status.Objects, objects, objectErrors, savedObjects.Status.Space != savedObjects.GetSpace())...)
+
+	savedObjects.Status.Space = savedObjects.GetSpace()
+	savedObjects.Status.Objects = objects
+	savedObjects.Status.Errors = objectErrors
+
+	// The hash is only updated when all objects are imported, so the import is retried
+	if len(savedObjects.Status.Errors) > 0 {
+		return res, errors.Errorf("%d saved objects failed to be imported", len(savedObjects.Status.Errors))
+	}
+	savedObjects.Status.Hash = hash
+
+	return res, nil
+}
+
+// Update permit to import again the saved objects on Kibana
+func (r *KibanaSavedObjectsReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return r.Create(ctx, resource, data, meta)
+}
+
+// Delete permit to delete the imported saved objects from Kibana if needed
+func (r *KibanaSavedObjectsReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+
+	if !savedObjects.IsDeletedOnRemove() {
+		return nil
+	}
+
+	for _, object := range savedObjects.Status.Objects {
+		if err = kbHandler.SavedObjectDelete(savedObjects.Status.Space, object.Type, object.ID); err != nil {
+			return errors.Wrapf(err, "Error when delete saved object %s/%s", object.Type, object.ID)
+		}
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if the content of ConfigMaps changed since the last import
+func (r *KibanaSavedObjectsReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+	var d any
+
+	d, err = helper.Get(data, "hash")
+	if err != nil {
+		return diff, err
+	}
+	hash := d.(string)
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if savedObjects.Status.Hash == "" {
+		diff.NeedCreate = true
+		diff.Diff = "Saved objects not yet imported"
+		return diff, nil
+	}
+
+	if savedObjects.Status.Hash != hash {
+		diff.NeedUpdate = true
+		diff.Diff = fmt.Sprintf("Saved objects content changed: %s -> %s", savedObjects.Status.Hash, hash)
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaSavedObjectsReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&savedObjects.Status.Conditions, v1.Condition{
+		Type:    kibanaSavedObjectsCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaSavedObjectsReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	savedObjects := resource.(*elkv1alpha1.KibanaSavedObjects)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&savedObjects.Status.Conditions, v1.Condition{
+			Type:    kibanaSavedObjectsCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: fmt.Sprintf("%d saved objects successfully imported", len(savedObjects.Status.Objects)),
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&savedObjects.Status.Conditions, v1.Condition{
+			Type:    kibanaSavedObjectsCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: fmt.Sprintf("%d saved objects successfully updated", len(savedObjects.Status.Objects)),
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(savedObjects.Status.Conditions, kibanaSavedObjectsCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&savedObjects.Status.Conditions, v1.Condition{
+			Type:    kibanaSavedObjectsCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Saved objects already imported",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Saved objects already imported")
+	}
+
+	return nil
+}
+
+// removedSavedObjects permit to get the previous saved objects that are not imported anymore
+// Objects that failed to be imported are kept, because they are always on ConfigMaps.
+// All previous objects are removed when the space changed
+func removedSavedObjects(previous, imported, failed []elkv1alpha1.KibanaSavedObjectStatus, isSpaceChanged bool) (removed []elkv1alpha1.KibanaSavedObjectStatus) {
+	if isSpaceChanged {
+		return previous
+	}
+
+	expected := make(map[string]struct{}, len(imported)+len(failed))
+	for _, object := range imported {
+		expected[object.Type+"/"+object.ID] = struct{}{}
+	}
+	for _, object := range failed {
+		expected[object.Type+"/"+object.ID] = struct{}{}
+	}
+	for _, object := range previous {
+		if _, ok := expected[object.Type+"/"+object.ID]; !ok {
+			removed = append(removed, object)
+		}
+	}
+
+	return removed
+}
+
+// failedPreviousSavedObjects permit to get the previous saved objects that failed to be imported again
+// There are no previous objects to keep when the space changed, because they are removed
+func failedPreviousSavedObjects(previous, imported, failed []elkv1alpha1.KibanaSavedObjectStatus, isSpaceChanged bool) (kept []elkv1alpha1.KibanaSavedObjectStatus) {
+	if isSpaceChanged {
+		return nil
+	}
+
+	importedObjects := make(map[string]struct{}, len(imported))
+	for _, object := range imported {
+		importedObjects[object.Type+"/"+object.ID] = struct{}{}
+	}
+	failedObjects := make(map[string]struct{}, len(failed))
+	for _, object := range failed {
+		failedObjects[object.Type+"/"+object.ID] = struct{}{}
+	}
+	for _, object := range previous {
+		key := object.Type + "/" + object.ID
+		if _, ok := importedObjects[key]; ok {
+			continue
+		}
+		if _, ok := failedObjects[key]; ok {
+			kept = append(kept, object)
+		}
+	}
+
+	return kept
+}
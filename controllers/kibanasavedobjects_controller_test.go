@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaSavedObjectsReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-saved-objects-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	savedObjects := &elkv1alpha1.KibanaSavedObjects{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, savedObjects, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaSavedObjectsStep(),
+		doUpdateKibanaSavedObjectsStep(),
+		doDeleteKibanaSavedObjectsStep(),
+	}
+	testCase.PreTest = doMockKibanaSavedObjects(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaSavedObjects(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+
+		mockKB.EXPECT().SavedObjectsImport(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(space string, ndjson []byte) (*kibanahandler.SavedObjectsImportResponse, error) {
+			switch *stepName {
+			case "create":
+				data["isCreated"] = true
+			case "update":
+				data["isUpdated"] = true
+			}
+
+			return &kibanahandler.SavedObjectsImportResponse{
+				Success:      true,
+				SuccessCount: 1,
+				SuccessResults: []kibanahandler.SavedObjectImportSuccess{
+					{
+						Type: "dashboard",
+						ID:   "my-dashboard",
+					},
+				},
+			}, nil
+		})
+
+		mockKB.EXPECT().SavedObjectDelete(gomock.Any(), gomock.Eq("dashboard"), gomock.Eq("my-dashboard")).AnyTimes().DoAndReturn(func(space, objectType, id string) error {
+			switch *stepName {
+			case "update":
+				data["isOrphanDeleted"] = space == "default"
+			case "delete":
+				data["isDeleted"] = true
+			}
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaSavedObjectsStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new Kibana saved objects %s/%s ===", key.Namespace, key.Name)
+
+			cm := &core.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Data: map[string]string{
+					"dashboard.ndjson": `{"type":"dashboard","id":"my-dashboard","attributes":{"title":"My dashboard"}}`,
+				},
+			}
+			if err = c.Create(context.Background(), cm); err != nil {
+				return err
+			}
+
+			savedObjects := &elkv1alpha1.KibanaSavedObjects{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaSavedObjectsSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					ConfigMaps: []elkv1alpha1.KibanaSavedObjectsConfigMapRef{
+						{
+							Name: key.Name,
+						},
+					},
+					DeletionPolicy: elkv1alpha1.DeletionPolicyDelete,
+				},
+			}
+			if err = c.Create(context.Background(), savedObjects); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			savedObjects := &elkv1alpha1.KibanaSavedObjects{}
+			isCreated := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, savedObjects); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || savedObjects.Status.Hash == "" {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Kibana saved objects: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(savedObjects.Status.Conditions, kibanaSavedObjectsCondition, metav1.ConditionTrue))
+			assert.Equal(t, "default", savedObjects.Status.Space)
+			assert.Equal(t, []elkv1alpha1.KibanaSavedObjectStatus{{Type: "dashboard", ID: "my-dashboard"}}, savedObjects.Status.Objects)
+			data["hash"] = savedObjects.Status.Hash
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaSavedObjectsStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update Kibana saved objects %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Kibana saved objects is null")
+			}
+			savedObjects := o.(*elkv1alpha1.KibanaSavedObjects)
+
+			savedObjects.Spec.Space = "marketing"
+			if err = c.Update(context.Background(), savedObjects); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			savedObjects := &elkv1alpha1.KibanaSavedObjects{}
+			isUpdated := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, savedObjects); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || savedObjects.Status.Hash == data["hash"] {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Kibana saved objects: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(savedObjects.Status.Conditions, kibanaSavedObjectsCondition, metav1.ConditionTrue))
+			assert.Equal(t, "marketing", savedObjects.Status.Space)
+			assert.True(t, data["isOrphanDeleted"].(bool))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaSavedObjectsStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete Kibana saved objects %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Kibana saved objects is null")
+			}
+			savedObjects := o.(*elkv1alpha1.KibanaSavedObjects)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), savedObjects, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			savedObjects := &elkv1alpha1.KibanaSavedObjects{}
+			isDeleted := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, savedObjects); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Kibana saved objects stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.True(t, data["isDeleted"].(bool))
+
+			return nil
+		},
+	}
+}
+
+func (t *ControllerTestSuite) TestFailedPreviousSavedObjects() {
+	previous := []elkv1alpha1.KibanaSavedObjectStatus{
+		{Type: "dashboard", ID: "dashboard1"},
+		{Type: "visualization", ID: "visualization1"},
+		{Type: "index-pattern", ID: "index-pattern1"},
+	}
+	imported := []elkv1alpha1.KibanaSavedObjectStatus{
+		{Type: "dashboard", ID: "dashboard1"},
+	}
+	failed := []elkv1alpha1.KibanaSavedObjectStatus{
+		{Type: "visualization", ID: "visualization1", Error: "conflict"},
+		{Type: "lens", ID: "lens1", Error: "missing references"},
+	}
+
+	// When some objects imported before failed
+	assert.Equal(t.T(), []elkv1alpha1.KibanaSavedObjectStatus{
+		{Type: "visualization", ID: "visualization1"},
+	}, failedPreviousSavedObjects(previous, imported, failed, false))
+
+	// When no objects failed
+	assert.Empty(t.T(), failedPreviousSavedObjects(previous, imported, nil, false))
+
+	// When space changed
+	assert.Empty(t.T(), failedPreviousSavedObjects(previous, imported, failed, true))
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	kibanaSpaceFinalizer = "kibana-space.elk.k8s.webcenter.fr/finalizer"
+	kibanaSpaceCondition = "UpdateKibanaSpace"
+)
+
+// KibanaSpaceReconciler reconciles a KibanaSpace object
+type KibanaSpaceReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaspaces,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaspaces/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=kibanaspaces/finalizers,verbs=update
+//+kubebuilder:rbac:groups="kibana.k8s.elastic.co",resources=kibanas,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *KibanaSpaceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	reconciler, err := controller.NewStdReconciler(r.Client, kibanaSpaceFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	space := &elkv1alpha1.KibanaSpace{}
+	data := map[string]any{}
+
+	return reconciler.Reconcile(ctx, req, space, data)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KibanaSpaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.KibanaSpace{}).
+		Complete(r)
+}
+
+// Configure permit to init Kibana handler
+// It also permit to init condition
+func (r *KibanaSpaceReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	space := resource.(*elkv1alpha1.KibanaSpace)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(space.Status.Conditions, kibanaSpaceCondition) == nil {
+		condition.SetStatusCondition(&space.Status.Conditions, v1.Condition{
+			Type:   kibanaSpaceCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get kibana handler / client
+	meta, err = GetKibanaHandler(ctx, &space.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init kibana handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current space
+func (r *KibanaSpaceReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	space := resource.(*elkv1alpha1.KibanaSpace)
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+
+	// Read space from Kibana
+	currentSpace, err := kbHandler.SpaceGet(space.GetSpaceID())
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get space from Kibana")
+	}
+
+	data["space"] = currentSpace
+	return res, nil
+}
+
+// Create add new space
+func (r *KibanaSpaceReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	space := resource.(*elkv1alpha1.KibanaSpace)
+
+	// Create space on Kibana
+	if err = kbHandler.SpaceCreate(space.ToSpace()); err != nil {
+		return res, errors.Wrap(err, "Error when create space")
+	}
+
+	return res, nil
+}
+
+// Update permit to update space from Kibana
+func (r *KibanaSpaceReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	space := resource.(*elkv1alpha1.KibanaSpace)
+
+	// Update space on Kibana
+	if err = kbHandler.SpaceUpdate(space.ToSpace()); err != nil {
+		return res, errors.Wrap(err, "Error when update space")
+	}
+
+	return res, nil
+}
+
+// Delete permit to delete space from Kibana
+func (r *KibanaSpaceReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	space := resource.(*elkv1alpha1.KibanaSpace)
+
+	if err = kbHandler.SpaceDelete(space.GetSpaceID()); err != nil {
+		return errors.Wrap(err, "Error when delete space")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected space exist
+func (r *KibanaSpaceReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	kbHandler := meta.(kibanahandler.KibanaHandler)
+	space := resource.(*elkv1alpha1.KibanaSpace)
+	var currentSpace *kibanahandler.KibanaSpace
+	var d any
+
+	d, err = helper.Get(data, "space")
+	if err != nil {
+		return diff, err
+	}
+	currentSpace = d.(*kibanahandler.KibanaSpace)
+	expectedSpace := space.ToSpace()
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentSpace == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Space not exist"
+		return diff, nil
+	}
+
+	diffStr, err := kbHandler.SpaceDiff(currentSpace, expectedSpace)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *KibanaSpaceReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	space := resource.(*elkv1alpha1.KibanaSpace)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&space.Status.Conditions, v1.Condition{
+		Type:    kibanaSpaceCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *KibanaSpaceReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	space := resource.(*elkv1alpha1.KibanaSpace)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&space.Status.Conditions, v1.Condition{
+			Type:    kibanaSpaceCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Space successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&space.Status.Conditions, v1.Condition{
+			Type:    kibanaSpaceCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Space successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(space.Status.Conditions, kibanaSpaceCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&space.Status.Conditions, v1.Condition{
+			Type:    kibanaSpaceCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Space already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Space already set")
+	}
+
+	return nil
+}
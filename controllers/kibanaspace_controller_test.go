@@ -0,0 +1,266 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestKibanaSpaceReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-kb-space-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	space := &elkv1alpha1.KibanaSpace{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, space, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateKibanaSpaceStep(),
+		doUpdateKibanaSpaceStep(),
+		doDeleteKibanaSpaceStep(),
+	}
+	testCase.PreTest = doMockKibanaSpace(t.mockKibanaHandler)
+
+	testCase.Run()
+}
+
+func doMockKibanaSpace(mockKB *mocks.MockKibanaHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockKB.EXPECT().SpaceGet(gomock.Any()).AnyTimes().DoAndReturn(func(id string) (*kibanahandler.KibanaSpace, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &kibanahandler.KibanaSpace{
+						ID:   id,
+						Name: "Marketing",
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &kibanahandler.KibanaSpace{
+						ID:   id,
+						Name: "Marketing",
+					}
+					return resp, nil
+				} else {
+					resp := &kibanahandler.KibanaSpace{
+						ID:          id,
+						Name:        "Marketing",
+						Description: "This is the Marketing Space",
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockKB.EXPECT().SpaceDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *kibanahandler.KibanaSpace) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockKB.EXPECT().SpaceCreate(gomock.Any()).AnyTimes().DoAndReturn(func(space *kibanahandler.KibanaSpace) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().SpaceUpdate(gomock.Any()).AnyTimes().DoAndReturn(func(space *kibanahandler.KibanaSpace) error {
+			switch *stepName {
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockKB.EXPECT().SpaceDelete(gomock.Any()).AnyTimes().DoAndReturn(func(id string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateKibanaSpaceStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new space %s/%s ===", key.Namespace, key.Name)
+
+			space := &elkv1alpha1.KibanaSpace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.KibanaSpaceSpec{
+					KibanaRefSpec: elkv1alpha1.KibanaRefSpec{
+						Name: "test",
+					},
+					Name: "Marketing",
+				},
+			}
+			if err = c.Create(context.Background(), space); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			space := &elkv1alpha1.KibanaSpace{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, space); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get space: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(space.Status.Conditions, kibanaSpaceCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateKibanaSpaceStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update space %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Space is null")
+			}
+			space := o.(*elkv1alpha1.KibanaSpace)
+
+			space.Spec.Description = "This is the Marketing Space"
+			if err = c.Update(context.Background(), space); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			space := &elkv1alpha1.KibanaSpace{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, space); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get space: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(space.Status.Conditions, kibanaSpaceCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteKibanaSpaceStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete space %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Space is null")
+			}
+			space := o.(*elkv1alpha1.KibanaSpace)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), space, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			space := &elkv1alpha1.KibanaSpace{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, space); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Space stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-sdk-extra/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+const (
+	logstashPipelineFinalizer = "logstash-pipeline.elk.k8s.webcenter.fr/finalizer"
+	logstashPipelineCondition = "UpdateLogstashPipeline"
+)
+
+// LogstashPipelineReconciler reconciles a LogstashPipeline object
+type LogstashPipelineReconciler struct {
+	Reconciler
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=logstashpipelines,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=logstashpipelines/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=logstashpipelines/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// The pipeline is reconciled periodically to detect changes on ConfigMap.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *LogstashPipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reconciler, err := controller.NewStdReconciler(r.Client, logstashPipelineFinalizer, r.reconciler, r.log, r.recorder, waitDurationWhenError)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	pipeline := &elkv1alpha1.LogstashPipeline{}
+	data := map[string]any{}
+
+	res, err := reconciler.Reconcile(ctx, req, pipeline, data)
+	return requeueToRefreshStatus(pipeline, res, err, waitDurationRefresh)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LogstashPipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elkv1alpha1.LogstashPipeline{}).
+		Complete(r)
+}
+
+// Configure permit to init Elasticsearch handler
+// It also permit to init condition
+func (r *LogstashPipelineReconciler) Configure(ctx context.Context, req ctrl.Request, resource resource.Resource) (meta any, err error) {
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+
+	// Init condition status if not exist
+	if condition.FindStatusCondition(pipeline.Status.Conditions, logstashPipelineCondition) == nil {
+		condition.SetStatusCondition(&pipeline.Status.Conditions, v1.Condition{
+			Type:   logstashPipelineCondition,
+			Status: v1.ConditionFalse,
+			Reason: "Initialize",
+		})
+	}
+
+	// Get elasticsearch handler / client
+	meta, err = GetElasticsearchHandler(ctx, &pipeline.Spec, r.Client, r.dinamicClient, req, r.log)
+	if err != nil {
+		r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "Unable to init elasticsearch handler: %s", err.Error())
+		return nil, err
+	}
+
+	return meta, err
+}
+
+// Read permit to get current pipeline
+// It also read the pipeline configuration from ConfigMap if needed
+func (r *LogstashPipelineReconciler) Read(ctx context.Context, resource resource.Resource, data map[string]any, meta any) (res ctrl.Result, err error) {
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+
+	// Read pipeline from Elasticsearch
+	currentPipeline, err := esHandler.LogstashPipelineGet(pipeline.Name)
+	if err != nil {
+		return res, errors.Wrap(err, "Unable to get Logstash pipeline from Elasticsearch")
+	}
+	data["pipeline"] = currentPipeline
+
+	// Read pipeline configuration from ConfigMap
+	data["content"] = ""
+	if pipeline.Spec.ConfigMapRef != nil && pipeline.DeletionTimestamp.IsZero() {
+		cm := &core.ConfigMap{}
+		cmNS := types.NamespacedName{
+			Namespace: pipeline.Namespace,
+			Name:      pipeline.Spec.ConfigMapRef.Name,
+		}
+		if err = r.Get(ctx, cmNS, cm); err != nil {
+			if k8serrors.IsNotFound(err) {
+				r.log.Warnf("ConfigMap %s not yet exist, try later", pipeline.Spec.ConfigMapRef.Name)
+				r.recorder.Eventf(resource, core.EventTypeWarning, "Failed", "ConfigMap %s not yet exist", pipeline.Spec.ConfigMapRef.Name)
+				return ctrl.Result{RequeueAfter: waitDurationWhenError}, nil
+			}
+			return res, errors.Wrapf(err, "Error when get ConfigMap %s", pipeline.Spec.ConfigMapRef.Name)
+		}
+		content, ok := cm.Data[pipeline.Spec.ConfigMapRef.Key]
+		if !ok {
+			return res, errors.Errorf("ConfigMap %s must have a %s key", pipeline.Spec.ConfigMapRef.Name, pipeline.Spec.ConfigMapRef.Key)
+		}
+		data["content"] = content
+	}
+
+	return res, nil
+}
+
+// Create add new pipeline
+func (r *LogstashPipelineReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+
+	// Create pipeline on Elasticsearch
+	expectedPipeline, err := r.toPipeline(pipeline, data)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when convert to Logstash pipeline")
+	}
+	if err = esHandler.LogstashPipelineUpdate(pipeline.Name, expectedPipeline); err != nil {
+		return res, errors.Wrap(err, "Error when update Logstash pipeline")
+	}
+
+	return res, nil
+}
+
+// Update permit to update pipeline from Elasticsearch
+func (r *LogstashPipelineReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
+	return r.Create(ctx, resource, data, meta)
+}
+
+// Delete permit to delete pipeline from Elasticsearch
+func (r *LogstashPipelineReconciler) Delete(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+
+	if err = esHandler.LogstashPipelineDelete(pipeline.Name); err != nil {
+		return errors.Wrap(err, "Error when delete Logstash pipeline")
+	}
+
+	return nil
+
+}
+
+// Diff permit to check if diff between actual and expected pipeline exist
+func (r *LogstashPipelineReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
+	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+	var currentPipeline *elasticsearchhandler.LogstashPipeline
+	var d any
+
+	d, err = helper.Get(data, "pipeline")
+	if err != nil {
+		return diff, err
+	}
+	currentPipeline = d.(*elasticsearchhandler.LogstashPipeline)
+	expectedPipeline, err := r.toPipeline(pipeline, data)
+	if err != nil {
+		return diff, err
+	}
+
+	diff = controller.Diff{
+		NeedCreate: false,
+		NeedUpdate: false,
+	}
+
+	if currentPipeline == nil {
+		diff.NeedCreate = true
+		diff.Diff = "Logstash pipeline not exist"
+		return diff, nil
+	}
+
+	diffStr, err := esHandler.LogstashPipelineDiff(currentPipeline, expectedPipeline)
+	if err != nil {
+		return diff, err
+	}
+
+	if diffStr != "" {
+		diff.NeedUpdate = true
+		diff.Diff = diffStr
+		return diff, nil
+	}
+
+	return
+}
+
+// toPipeline permit to convert the pipeline with the configuration read from ConfigMap
+func (r *LogstashPipelineReconciler) toPipeline(pipeline *elkv1alpha1.LogstashPipeline, data map[string]any) (*elasticsearchhandler.LogstashPipeline, error) {
+	d, err := helper.Get(data, "content")
+	if err != nil {
+		return nil, err
+	}
+
+	return pipeline.ToPipeline(d.(string))
+}
+
+// OnError permit to set status condition on the right state and record error
+func (r *LogstashPipelineReconciler) OnError(ctx context.Context, resource resource.Resource, data map[string]any, meta any, err error) {
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+	r.log.Error(err)
+	r.recorder.Event(resource, core.EventTypeWarning, "Failed", err.Error())
+
+	condition.SetStatusCondition(&pipeline.Status.Conditions, v1.Condition{
+		Type:    logstashPipelineCondition,
+		Status:  v1.ConditionFalse,
+		Reason:  "Failed",
+		Message: err.Error(),
+	})
+}
+
+// OnSuccess permit to set status condition on the right state is everithink is good
+func (r *LogstashPipelineReconciler) OnSuccess(ctx context.Context, resource resource.Resource, data map[string]any, meta any, diff controller.Diff) (err error) {
+	pipeline := resource.(*elkv1alpha1.LogstashPipeline)
+
+	if diff.NeedCreate {
+		condition.SetStatusCondition(&pipeline.Status.Conditions, v1.Condition{
+			Type:    logstashPipelineCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Logstash pipeline successfully created",
+		})
+
+		return nil
+	}
+
+	if diff.NeedUpdate {
+		condition.SetStatusCondition(&pipeline.Status.Conditions, v1.Condition{
+			Type:    logstashPipelineCondition,
+			Status:  v1.ConditionTrue,
+			Reason:  "Success",
+			Message: "Logstash pipeline successfully updated",
+		})
+
+		return nil
+	}
+
+	// Update condition status if needed
+	if condition.IsStatusConditionPresentAndEqual(pipeline.Status.Conditions, logstashPipelineCondition, v1.ConditionFalse) {
+		condition.SetStatusCondition(&pipeline.Status.Conditions, v1.Condition{
+			Type:    logstashPipelineCondition,
+			Reason:  "Success",
+			Status:  v1.ConditionTrue,
+			Message: "Logstash pipeline already set",
+		})
+
+		r.recorder.Event(resource, core.EventTypeNormal, "Completed", "Logstash pipeline already set")
+	}
+
+	return nil
+}
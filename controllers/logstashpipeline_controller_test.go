@@ -0,0 +1,277 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/disaster37/operator-elk-extra/pkg/mocks"
+	"github.com/disaster37/operator-sdk-extra/pkg/test"
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func (t *ControllerTestSuite) TestLogstashPipelineReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-logstash-pipeline-" + helpers.RandomString(10),
+		Namespace: "default",
+	}
+	pipeline := &elkv1alpha1.LogstashPipeline{}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase(t.T(), t.k8sClient, key, pipeline, 5*time.Second, data)
+	testCase.Steps = []test.TestStep{
+		doCreateLogstashPipelineStep(),
+		doUpdateLogstashPipelineStep(),
+		doDeleteLogstashPipelineStep(),
+	}
+	testCase.PreTest = doMockLogstashPipeline(t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockLogstashPipeline(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+
+		mockES.EXPECT().LogstashPipelineGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.LogstashPipeline, error) {
+
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &elasticsearchhandler.LogstashPipeline{
+						Pipeline: "input { beats {} }\n output {}",
+					}
+					return resp, nil
+				}
+			case "update":
+				if !isUpdated {
+					resp := &elasticsearchhandler.LogstashPipeline{
+						Pipeline: "input { beats {} }\n output {}",
+					}
+					return resp, nil
+				} else {
+					resp := &elasticsearchhandler.LogstashPipeline{
+						Description: "Beats pipeline",
+						Pipeline:    "input { beats {} }\n output {}",
+					}
+					return resp, nil
+				}
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().LogstashPipelineDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.LogstashPipeline) (string, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "update":
+				if !isUpdated {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			}
+
+			return "", nil
+		})
+
+		mockES.EXPECT().LogstashPipelineUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, pipeline *elasticsearchhandler.LogstashPipeline) error {
+			switch *stepName {
+			case "create":
+				if pipeline.Pipeline != "input { beats {} }\n output {}" {
+					return errors.New("Pipeline not read from ConfigMap")
+				}
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			case "update":
+				isUpdated = true
+				data["isUpdated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockES.EXPECT().LogstashPipelineDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateLogstashPipelineStep() test.TestStep {
+	return test.TestStep{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Add new pipeline %s/%s ===", key.Namespace, key.Name)
+
+			// Create ConfigMap that store the pipeline configuration
+			cm := &core.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Data: map[string]string{
+					"pipeline.conf": "input { beats {} }\n output {}",
+				},
+			}
+			if err = c.Create(context.Background(), cm); err != nil {
+				return err
+			}
+
+			pipeline := &elkv1alpha1.LogstashPipeline{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elkv1alpha1.LogstashPipelineSpec{
+					ElasticsearchRefSpec: elkv1alpha1.ElasticsearchRefSpec{
+						Name: "test",
+					},
+					ConfigMapRef: &elkv1alpha1.LogstashPipelineConfigMapRef{
+						Name: key.Name,
+						Key:  "pipeline.conf",
+					},
+				},
+			}
+			if err = c.Create(context.Background(), pipeline); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			pipeline := &elkv1alpha1.LogstashPipeline{}
+			isCreated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, pipeline); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get pipeline: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(pipeline.Status.Conditions, logstashPipelineCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateLogstashPipelineStep() test.TestStep {
+	return test.TestStep{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update pipeline %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Pipeline is null")
+			}
+			pipeline := o.(*elkv1alpha1.LogstashPipeline)
+
+			pipeline.Spec.Description = "Beats pipeline"
+			if err = c.Update(context.Background(), pipeline); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			pipeline := &elkv1alpha1.LogstashPipeline{}
+			isUpdated := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, pipeline); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get pipeline: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(pipeline.Status.Conditions, logstashPipelineCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteLogstashPipelineStep() test.TestStep {
+	return test.TestStep{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Delete pipeline %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Pipeline is null")
+			}
+			pipeline := o.(*elkv1alpha1.LogstashPipeline)
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), pipeline, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			pipeline := &elkv1alpha1.LogstashPipeline{}
+			isDeleted := true
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, pipeline); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Pipeline stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/pkg/resource"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/pkg/errors"
 )
 
@@ -157,14 +156,14 @@ func (r *RoleMappingReconciler) Delete(ctx context.Context, resource resource.Re
 func (r *RoleMappingReconciler) Diff(resource resource.Resource, data map[string]interface{}, meta interface{}) (diff controller.Diff, err error) {
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	roleMapping := resource.(*elkv1alpha1.RoleMapping)
-	var currentRoleMapping *olivere.XPackSecurityRoleMapping
+	var currentRoleMapping *elasticsearchhandler.XPackSecurityRoleMapping
 	var d any
 
 	d, err = helper.Get(data, "roleMapping")
 	if err != nil {
 		return diff, err
 	}
-	currentRoleMapping = d.(*olivere.XPackSecurityRoleMapping)
+	currentRoleMapping = d.(*elasticsearchhandler.XPackSecurityRoleMapping)
 	expectedRoleMapping, err := roleMapping.ToRoleMapping()
 	if err != nil {
 		return diff, err
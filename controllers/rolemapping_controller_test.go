@@ -6,11 +6,11 @@ import (
 	"time"
 
 	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
+	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
 	"github.com/disaster37/operator-elk-extra/pkg/helpers"
 	"github.com/disaster37/operator-elk-extra/pkg/mocks"
 	"github.com/disaster37/operator-sdk-extra/pkg/test"
 	"github.com/golang/mock/gomock"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -47,14 +47,14 @@ func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *st
 		isCreated := false
 		isUpdated := false
 
-		mockES.EXPECT().RoleMappingGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.XPackSecurityRoleMapping, error) {
+		mockES.EXPECT().RoleMappingGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*elasticsearchhandler.XPackSecurityRoleMapping, error) {
 
 			switch *stepName {
 			case "create":
 				if !isCreated {
 					return nil, nil
 				} else {
-					resp := &olivere.XPackSecurityRoleMapping{
+					resp := &elasticsearchhandler.XPackSecurityRoleMapping{
 						Enabled: true,
 						Roles:   []string{"superuser"},
 						Rules: map[string]any{
@@ -65,7 +65,7 @@ func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *st
 				}
 			case "update":
 				if !isUpdated {
-					resp := &olivere.XPackSecurityRoleMapping{
+					resp := &elasticsearchhandler.XPackSecurityRoleMapping{
 						Enabled: true,
 						Roles:   []string{"superuser"},
 						Rules: map[string]any{
@@ -74,7 +74,7 @@ func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *st
 					}
 					return resp, nil
 				} else {
-					resp := &olivere.XPackSecurityRoleMapping{
+					resp := &elasticsearchhandler.XPackSecurityRoleMapping{
 						Enabled: false,
 						Roles:   []string{"superuser"},
 						Rules: map[string]any{
@@ -88,7 +88,7 @@ func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *st
 			return nil, nil
 		})
 
-		mockES.EXPECT().RoleMappingDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *olivere.XPackSecurityRoleMapping) (string, error) {
+		mockES.EXPECT().RoleMappingDiff(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected *elasticsearchhandler.XPackSecurityRoleMapping) (string, error) {
 			switch *stepName {
 			case "create":
 				if !isCreated {
@@ -107,13 +107,16 @@ func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *st
 			return "", nil
 		})
 
-		mockES.EXPECT().RoleMappingUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, policy *olivere.XPackSecurityRoleMapping) error {
+		mockES.EXPECT().RoleMappingUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, policy *elasticsearchhandler.XPackSecurityRoleMapping) error {
 			switch *stepName {
 			case "create":
 				isCreated = true
 				data["isCreated"] = true
 				return nil
 			case "update":
+				if len(policy.RoleTemplates) != 1 || policy.Rules["all"] == nil {
+					return errors.New("Role templates or typed rules not converted")
+				}
 				isUpdated = true
 				data["isUpdated"] = true
 				return nil
@@ -196,6 +199,30 @@ func doUpdateRoleMappingStep() test.TestStep {
 			rm := o.(*elkv1alpha1.RoleMapping)
 
 			rm.Spec.Enabled = false
+			rm.Spec.Roles = nil
+			rm.Spec.RoleTemplates = []elkv1alpha1.RoleMappingRoleTemplate{
+				{
+					Source: "{{#tojson}}groups{{/tojson}}",
+					Format: "json",
+				},
+			}
+			rm.Spec.Rules = ""
+			rm.Spec.TypedRules = &elkv1alpha1.RoleMappingRule{
+				All: []elkv1alpha1.RoleMappingRule{
+					{
+						Field: elkv1alpha1.RoleMappingFieldExpression{
+							"realm.name": []string{"saml1"},
+						},
+					},
+					{
+						Except: &elkv1alpha1.RoleMappingRule{
+							Field: elkv1alpha1.RoleMappingFieldExpression{
+								"groups": []string{"guests"},
+							},
+						},
+					},
+				},
+			}
 			if err = c.Update(context.Background(), rm); err != nil {
 				return err
 			}
@@ -48,6 +48,7 @@ type ControllerTestSuite struct {
 	cfg                      *rest.Config
 	mockCtrl                 *gomock.Controller
 	mockElasticsearchHandler *mocks.MockElasticsearchHandler
+	mockKibanaHandler        *mocks.MockKibanaHandler
 }
 
 func TestControllerSuite(t *testing.T) {
@@ -58,6 +59,7 @@ func (t *ControllerTestSuite) SetupSuite() {
 
 	t.mockCtrl = gomock.NewController(t.T())
 	t.mockElasticsearchHandler = mocks.NewMockElasticsearchHandler(t.mockCtrl)
+	t.mockKibanaHandler = mocks.NewMockKibanaHandler(t.mockCtrl)
 
 	logf.SetLogger(zap.New(zap.UseDevMode(true)))
 	logrus.SetLevel(logrus.DebugLevel)
@@ -243,6 +245,214 @@ func (t *ControllerTestSuite) SetupSuite() {
 		panic(err)
 	}
 
+	transformReconciler := &ElasticsearchTransformReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	transformReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "transformController",
+	}))
+	transformReconciler.SetRecorder(k8sManager.GetEventRecorderFor("transform-controller"))
+	transformReconciler.SetReconsiler(mock.NewMockReconciler(transformReconciler, t.mockElasticsearchHandler))
+	if err = transformReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	mlJobReconciler := &ElasticsearchMLJobReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	mlJobReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "mlJobController",
+	}))
+	mlJobReconciler.SetRecorder(k8sManager.GetEventRecorderFor("mljob-controller"))
+	mlJobReconciler.SetReconsiler(mock.NewMockReconciler(mlJobReconciler, t.mockElasticsearchHandler))
+	if err = mlJobReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	mlDatafeedReconciler := &ElasticsearchMLDatafeedReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	mlDatafeedReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "mlDatafeedController",
+	}))
+	mlDatafeedReconciler.SetRecorder(k8sManager.GetEventRecorderFor("mldatafeed-controller"))
+	mlDatafeedReconciler.SetReconsiler(mock.NewMockReconciler(mlDatafeedReconciler, t.mockElasticsearchHandler))
+	if err = mlDatafeedReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	applicationPrivilegeReconciler := &ElasticsearchApplicationPrivilegeReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	applicationPrivilegeReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "applicationPrivilegeController",
+	}))
+	applicationPrivilegeReconciler.SetRecorder(k8sManager.GetEventRecorderFor("application-privilege-controller"))
+	applicationPrivilegeReconciler.SetReconsiler(mock.NewMockReconciler(applicationPrivilegeReconciler, t.mockElasticsearchHandler))
+	if err = applicationPrivilegeReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	snapshotReconciler := &ElasticsearchSnapshotReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	snapshotReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "snapshotController",
+	}))
+	snapshotReconciler.SetRecorder(k8sManager.GetEventRecorderFor("snapshot-controller"))
+	snapshotReconciler.SetReconsiler(mock.NewMockReconciler(snapshotReconciler, t.mockElasticsearchHandler))
+	if err = snapshotReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	restoreReconciler := &ElasticsearchRestoreReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	restoreReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "restoreController",
+	}))
+	restoreReconciler.SetRecorder(k8sManager.GetEventRecorderFor("restore-controller"))
+	restoreReconciler.SetReconsiler(mock.NewMockReconciler(restoreReconciler, t.mockElasticsearchHandler))
+	if err = restoreReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	mountedIndexReconciler := &ElasticsearchMountedIndexReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	mountedIndexReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "mountedIndexController",
+	}))
+	mountedIndexReconciler.SetRecorder(k8sManager.GetEventRecorderFor("mounted-index-controller"))
+	mountedIndexReconciler.SetReconsiler(mock.NewMockReconciler(mountedIndexReconciler, t.mockElasticsearchHandler))
+	if err = mountedIndexReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaSpaceReconciler := &KibanaSpaceReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaSpaceReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaSpaceController",
+	}))
+	kibanaSpaceReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-space-controller"))
+	kibanaSpaceReconciler.SetReconsiler(mock.NewMockReconciler(kibanaSpaceReconciler, t.mockKibanaHandler))
+	if err = kibanaSpaceReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaRoleReconciler := &KibanaRoleReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaRoleReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaRoleController",
+	}))
+	kibanaRoleReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-role-controller"))
+	kibanaRoleReconciler.SetReconsiler(mock.NewMockReconciler(kibanaRoleReconciler, t.mockKibanaHandler))
+	if err = kibanaRoleReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaSavedObjectsReconciler := &KibanaSavedObjectsReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaSavedObjectsReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaSavedObjectsController",
+	}))
+	kibanaSavedObjectsReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-saved-objects-controller"))
+	kibanaSavedObjectsReconciler.SetReconsiler(mock.NewMockReconciler(kibanaSavedObjectsReconciler, t.mockKibanaHandler))
+	if err = kibanaSavedObjectsReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaDataViewReconciler := &KibanaDataViewReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaDataViewReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaDataViewController",
+	}))
+	kibanaDataViewReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-data-view-controller"))
+	kibanaDataViewReconciler.SetReconsiler(mock.NewMockReconciler(kibanaDataViewReconciler, t.mockKibanaHandler))
+	if err = kibanaDataViewReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaConnectorReconciler := &KibanaConnectorReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaConnectorReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaConnectorController",
+	}))
+	kibanaConnectorReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-connector-controller"))
+	kibanaConnectorReconciler.SetReconsiler(mock.NewMockReconciler(kibanaConnectorReconciler, t.mockKibanaHandler))
+	if err = kibanaConnectorReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	kibanaAlertRuleReconciler := &KibanaAlertRuleReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	kibanaAlertRuleReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "kibanaAlertRuleController",
+	}))
+	kibanaAlertRuleReconciler.SetRecorder(k8sManager.GetEventRecorderFor("kibana-alert-rule-controller"))
+	kibanaAlertRuleReconciler.SetReconsiler(mock.NewMockReconciler(kibanaAlertRuleReconciler, t.mockKibanaHandler))
+	if err = kibanaAlertRuleReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	logstashPipelineReconciler := &LogstashPipelineReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	logstashPipelineReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "logstashPipelineController",
+	}))
+	logstashPipelineReconciler.SetRecorder(k8sManager.GetEventRecorderFor("logstash-pipeline-controller"))
+	logstashPipelineReconciler.SetReconsiler(mock.NewMockReconciler(logstashPipelineReconciler, t.mockElasticsearchHandler))
+	if err = logstashPipelineReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	fleetAgentPolicyReconciler := &FleetAgentPolicyReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	fleetAgentPolicyReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "fleetAgentPolicyController",
+	}))
+	fleetAgentPolicyReconciler.SetRecorder(k8sManager.GetEventRecorderFor("fleet-agent-policy-controller"))
+	fleetAgentPolicyReconciler.SetReconsiler(mock.NewMockReconciler(fleetAgentPolicyReconciler, t.mockKibanaHandler))
+	if err = fleetAgentPolicyReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	fleetPackagePolicyReconciler := &FleetPackagePolicyReconciler{
+		Client: k8sClient,
+		Scheme: scheme.Scheme,
+	}
+	fleetPackagePolicyReconciler.SetLogger(logrus.WithFields(logrus.Fields{
+		"type": "fleetPackagePolicyController",
+	}))
+	fleetPackagePolicyReconciler.SetRecorder(k8sManager.GetEventRecorderFor("fleet-package-policy-controller"))
+	fleetPackagePolicyReconciler.SetReconsiler(mock.NewMockReconciler(fleetPackagePolicyReconciler, t.mockKibanaHandler))
+	if err = fleetPackagePolicyReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
 	go func() {
 		err = k8sManager.Start(ctrl.SetupSignalHandler())
 		if err != nil {
@@ -17,9 +17,15 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
 
-	"golang.org/x/crypto/bcrypt"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	condition "k8s.io/apimachinery/pkg/api/meta"
@@ -31,6 +37,7 @@ import (
 
 	elkv1alpha1 "github.com/disaster37/operator-elk-extra/api/v1alpha1"
 	"github.com/disaster37/operator-elk-extra/pkg/elasticsearchhandler"
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
 	"github.com/disaster37/operator-sdk-extra/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/pkg/resource"
@@ -39,8 +46,11 @@ import (
 )
 
 const (
-	userFinalizer = "user.elk.k8s.webcenter.fr/finalizer"
-	userCondition = "UpdateUser"
+	userFinalizer              = "user.elk.k8s.webcenter.fr/finalizer"
+	userCondition              = "UpdateUser"
+	userDefaultPasswordLength  = 32
+	userDefaultPasswordCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	userRotationAnnotation     = "elk.k8s.webcenter.fr/last-rotation-time"
 )
 
 // UserReconciler reconciles a User object
@@ -48,11 +58,15 @@ type UserReconciler struct {
 	Reconciler
 	client.Client
 	Scheme *runtime.Scheme
+
+	// FingerprintKey is the key used to compute the password fingerprint
+	FingerprintKey []byte
 }
 
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=users,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=users/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=elk.k8s.webcenter.fr,resources=users/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -72,13 +86,20 @@ func (r *UserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	user := &elkv1alpha1.User{}
 	data := map[string]any{}
 
-	return reconciler.Reconcile(ctx, req, user, data)
+	res, err := reconciler.Reconcile(ctx, req, user, data)
+	if user.Spec.Rotation == nil {
+		return res, err
+	}
+
+	// Reconcile again when the next rotation is expected
+	return requeueToRefreshStatus(user, res, err, nextRotationDelay(user))
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *UserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&elkv1alpha1.User{}).
+		Owns(&core.Secret{}).
 		Complete(r)
 }
 
@@ -112,6 +133,11 @@ func (r *UserReconciler) Read(ctx context.Context, resource resource.Resource, d
 	user := resource.(*elkv1alpha1.User)
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 
+	// Check the spec before reading or writing any Secret
+	if _, err = user.ToUser(); err != nil {
+		return res, errors.Wrap(err, "Error when convert user")
+	}
+
 	// Read user from Elasticsearch
 	currentUser, err := esHandler.UserGet(user.Name)
 	if err != nil {
@@ -135,11 +161,31 @@ func (r *UserReconciler) Read(ctx context.Context, resource resource.Resource, d
 		}
 		passwordB, ok := secret.Data[user.Spec.Secret.Key]
 		if !ok {
-			return res, errors.Wrapf(err, "Secret %s must have a %s key", user.Spec.Secret.Name, user.Spec.Secret.Key)
+			return res, errors.Errorf("Secret %s must have a %s key", user.Spec.Secret.Name, user.Spec.Secret.Key)
 		}
 		data["password"] = string(passwordB)
 	}
 
+	// Read or generate password from owned secret if needed
+	if user.Spec.GeneratePassword != nil && user.DeletionTimestamp.IsZero() {
+		password, err := r.generatePassword(ctx, user)
+		if err != nil {
+			return res, errors.Wrap(err, "Error when generate password")
+		}
+		data["password"] = password
+	}
+
+	// Rotate password if needed
+	if user.Spec.Rotation != nil && user.DeletionTimestamp.IsZero() {
+		password, err := r.rotatePassword(ctx, user)
+		if err != nil {
+			return res, errors.Wrap(err, "Error when rotate password")
+		}
+		if password != "" {
+			data["password"] = password
+		}
+	}
+
 	data["user"] = currentUser
 	return res, nil
 }
@@ -148,34 +194,28 @@ func (r *UserReconciler) Read(ctx context.Context, resource resource.Resource, d
 func (r *UserReconciler) Create(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	user := resource.(*elkv1alpha1.User)
-	var d any
-	var passwordHash string
+
+	// Reserved users are built-in, they can't be created
+	if user.Spec.Reserved {
+		return res, errors.Errorf("Reserved user %s not exist", user.Name)
+	}
 
 	// Create user on Elasticsearch
 	expectedUser, err := user.ToUser()
 	if err != nil {
 		return res, errors.Wrap(err, "Error when convert user")
 	}
-
-	if user.Spec.Secret != nil {
-		d, err = helper.Get(data, "password")
-		if err != nil {
-			return res, err
-		}
-		expectedUser.Password = d.(string)
-		passwordHash, err = HashPassword(expectedUser.Password)
-		if err != nil {
-			return res, errors.Wrap(err, "Error when hash password")
-		}
-	} else {
-		passwordHash = user.Spec.PasswordHash
+	password, passwordHash, err := passwordCredential(user, data)
+	if err != nil {
+		return res, err
 	}
+	expectedUser.Password = password
 
 	if err = esHandler.UserCreate(user.Name, expectedUser); err != nil {
 		return res, errors.Wrap(err, "Error when create user")
 	}
 
-	user.Status.PasswordHash = passwordHash
+	user.Status.PasswordFingerprint = r.passwordFingerprint(user, password, passwordHash)
 
 	return res, nil
 }
@@ -184,9 +224,11 @@ func (r *UserReconciler) Create(ctx context.Context, resource resource.Resource,
 func (r *UserReconciler) Update(ctx context.Context, resource resource.Resource, data map[string]interface{}, meta interface{}) (res ctrl.Result, err error) {
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	user := resource.(*elkv1alpha1.User)
-	var d any
-	var passwordHash string
-	isUpdatePasssword := false
+
+	// Reserved users can only change password and enabled state
+	if user.Spec.Reserved {
+		return res, r.updateReservedUser(user, data, esHandler)
+	}
 
 	// Create user on Elasticsearch
 	expectedUser, err := user.ToUser()
@@ -194,35 +236,29 @@ func (r *UserReconciler) Update(ctx context.Context, resource resource.Resource,
 		return res, errors.Wrap(err, "Error when convert user")
 	}
 
-	if user.Spec.Secret != nil {
-		d, err = helper.Get(data, "password")
-		if err != nil {
-			return res, err
-		}
-		password := d.(string)
-		if !CheckPasswordHash(password, user.Status.PasswordHash) {
-			expectedUser.Password = password
-			expectedUser.PasswordHash = ""
-			passwordHash, err = HashPassword(expectedUser.Password)
-			if err != nil {
-				return res, errors.Wrap(err, "Error when hash password")
-			}
-			isUpdatePasssword = true
-		}
+	// Change the password only if needed
+	isPasswordChanged, err := r.isPasswordChanged(user, data)
+	if err != nil {
+		return res, err
+	}
+	password, passwordHash, err := passwordCredential(user, data)
+	if err != nil {
+		return res, err
+	}
+	if isPasswordChanged {
+		expectedUser.Password = password
+		expectedUser.PasswordHash = passwordHash
 	} else {
-		if user.Spec.PasswordHash == user.Status.PasswordHash {
-			expectedUser.PasswordHash = ""
-			passwordHash = user.Spec.PasswordHash
-			isUpdatePasssword = true
-		}
+		expectedUser.Password = ""
+		expectedUser.PasswordHash = ""
 	}
 
 	if err = esHandler.UserUpdate(user.Name, expectedUser); err != nil {
 		return res, errors.Wrap(err, "Error when update user")
 	}
 
-	if isUpdatePasssword {
-		user.Status.PasswordHash = passwordHash
+	if isPasswordChanged {
+		user.Status.PasswordFingerprint = r.passwordFingerprint(user, password, passwordHash)
 	}
 
 	return res, nil
@@ -233,6 +269,12 @@ func (r *UserReconciler) Delete(ctx context.Context, resource resource.Resource,
 	esHandler := meta.(elasticsearchhandler.ElasticsearchHandler)
 	user := resource.(*elkv1alpha1.User)
 
+	// Reserved users are built-in, they are never deleted
+	if user.Spec.Reserved {
+		r.log.Infof("User %s is reserved, it's not deleted", user.Name)
+		return nil
+	}
+
 	if err = esHandler.UserDelete(user.Name); err != nil {
 		return errors.Wrap(err, "Error when delete user")
 	}
@@ -265,34 +307,37 @@ func (r *UserReconciler) Diff(resource resource.Resource, data map[string]interf
 		return diff, nil
 	}
 
+	if user.Spec.Reserved {
+		return r.diffReservedUser(user, currentUserTmp, data)
+	}
+
 	currentUser := &olivere.XPackSecurityPutUserRequest{
 		Enabled:  currentUserTmp.Enabled,
 		Email:    currentUserTmp.Email,
 		FullName: currentUserTmp.Fullname,
 		Metadata: currentUserTmp.Metadata,
 		Roles:    currentUserTmp.Roles,
-		Password: user.Status.PasswordHash,
 	}
 
-	if user.Spec.Secret != nil {
-		d, err = helper.Get(data, "password")
-		if err != nil {
-			return diff, err
-		}
-		password := d.(string)
+	// Elasticsearch not return the password, so it's compared with the fingerprint
+	expectedUser.Password = ""
+	expectedUser.PasswordHash = ""
 
-		// Check if password change, bcrypt generate hash different each time
-		if !CheckPasswordHash(password, user.Status.PasswordHash) {
-			expectedUser.Password = "XXX"
-		} else {
-			expectedUser.Password = user.Status.PasswordHash
-		}
+	diffStr, err := esHandler.UserDiff(currentUser, expectedUser)
+	if err != nil {
+		return diff, err
 	}
 
-	diffStr, err := esHandler.UserDiff(currentUser, expectedUser)
+	isPasswordChanged, err := r.isPasswordChanged(user, data)
 	if err != nil {
 		return diff, err
 	}
+	if isPasswordChanged {
+		if diffStr != "" {
+			diffStr += "\n"
+		}
+		diffStr += "password changed"
+	}
 
 	if diffStr != "" {
 		diff.NeedUpdate = true
@@ -358,12 +403,306 @@ func (r *UserReconciler) OnSuccess(ctx context.Context, resource resource.Resour
 	return nil
 }
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+// diffReservedUser permit to check if the password or the enabled state of reserved user need to be updated
+func (r *UserReconciler) diffReservedUser(user *elkv1alpha1.User, currentUser *olivere.XPackSecurityUser, data map[string]any) (diff controller.Diff, err error) {
+	diffs := []string{}
+
+	if currentUser.Enabled != user.Spec.Enabled {
+		diffs = append(diffs, fmt.Sprintf("enabled: %t => %t", currentUser.Enabled, user.Spec.Enabled))
+	}
+
+	isPasswordChanged, err := r.isPasswordChanged(user, data)
+	if err != nil {
+		return diff, err
+	}
+	if isPasswordChanged {
+		diffs = append(diffs, "password changed")
+	}
+
+	if len(diffs) > 0 {
+		diff.NeedUpdate = true
+		diff.Diff = strings.Join(diffs, "\n")
+	}
+
+	return diff, nil
 }
 
-func CheckPasswordHash(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// updateReservedUser permit to update the password and the enabled state of reserved user
+// It only use change password and enable / disable APIs
+func (r *UserReconciler) updateReservedUser(user *elkv1alpha1.User, data map[string]any, esHandler elasticsearchhandler.ElasticsearchHandler) (err error) {
+	d, err := helper.Get(data, "user")
+	if err != nil {
+		return err
+	}
+	currentUser := d.(*olivere.XPackSecurityUser)
+
+	isPasswordChanged, err := r.isPasswordChanged(user, data)
+	if err != nil {
+		return err
+	}
+	if isPasswordChanged {
+		password, passwordHash, err := passwordCredential(user, data)
+		if err != nil {
+			return err
+		}
+		if err = esHandler.UserChangePassword(user.Name, password, passwordHash); err != nil {
+			return errors.Wrap(err, "Error when change password")
+		}
+		user.Status.PasswordFingerprint = r.passwordFingerprint(user, password, passwordHash)
+	}
+
+	if currentUser.Enabled != user.Spec.Enabled {
+		if user.Spec.Enabled {
+			err = esHandler.UserEnable(user.Name)
+		} else {
+			err = esHandler.UserDisable(user.Name)
+		}
+		if err != nil {
+			return errors.Wrap(err, "Error when change enabled state")
+		}
+	}
+
+	return nil
+}
+
+// passwordCredential permit to get the password or the password hash expected on user
+func passwordCredential(user *elkv1alpha1.User, data map[string]any) (password string, passwordHash string, err error) {
+	if user.IsPasswordFromSecret() {
+		d, err := helper.Get(data, "password")
+		if err != nil {
+			return "", "", err
+		}
+		return d.(string), "", nil
+	}
+
+	return "", user.Spec.PasswordHash, nil
+}
+
+// passwordFingerprint permit to compute the keyed fingerprint of the password set on user
+// It use HMAC-SHA256 with the operator key, so the password change is detected cheaply and the fingerprint can't be used to guess the password
+func (r *UserReconciler) passwordFingerprint(user *elkv1alpha1.User, password string, passwordHash string) string {
+	if password == "" && passwordHash == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, r.FingerprintKey)
+	mac.Write([]byte(user.Name))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	mac.Write([]byte{0})
+	mac.Write([]byte(passwordHash))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isPasswordChanged permit to know if the expected password is not yet set on user
+func (r *UserReconciler) isPasswordChanged(user *elkv1alpha1.User, data map[string]any) (bool, error) {
+	password, passwordHash, err := passwordCredential(user, data)
+	if err != nil {
+		return false, err
+	}
+
+	return r.passwordFingerprint(user, password, passwordHash) != user.Status.PasswordFingerprint, nil
+}
+
+// generatePassword permit to get the generated password from the Secret owned by user
+// It generate a new password and create the Secret if needed. The Secret store also the username and the Elasticsearch URL
+func (r *UserReconciler) generatePassword(ctx context.Context, user *elkv1alpha1.User) (password string, err error) {
+	hosts, _, _, err := getElasticsearchHosts(&user.Spec, r.dinamicClient, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: user.Namespace, Name: user.Name}}, r.log)
+	if err != nil {
+		return "", err
+	}
+	expectedData := map[string][]byte{
+		elkv1alpha1.UserSecretUsernameKey: []byte(user.Name),
+		elkv1alpha1.UserSecretURLKey:      []byte(hosts[0]),
+	}
+
+	secret := &core.Secret{}
+	secretNS := types.NamespacedName{
+		Namespace: user.Namespace,
+		Name:      user.GetGeneratedPasswordSecretName(),
+	}
+	if err = r.Get(ctx, secretNS, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "Error when get Secret %s", secretNS.Name)
+		}
+
+		password, err = helpers.RandomPassword(int(user.Spec.GeneratePassword.Length), user.Spec.GeneratePassword.Charset)
+		if err != nil {
+			return "", err
+		}
+		expectedData[elkv1alpha1.UserSecretPasswordKey] = []byte(password)
+		secret = &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      secretNS.Name,
+				Namespace: secretNS.Namespace,
+			},
+			Type: core.SecretTypeOpaque,
+			Data: expectedData,
+		}
+		if err = ctrl.SetControllerReference(user, secret, r.Scheme); err != nil {
+			return "", errors.Wrapf(err, "Error when set owner on Secret %s", secretNS.Name)
+		}
+		if err = r.Client.Create(ctx, secret); err != nil {
+			return "", errors.Wrapf(err, "Error when create Secret %s", secretNS.Name)
+		}
+		r.recorder.Eventf(user, core.EventTypeNormal, "Completed", "Password generated on Secret %s", secretNS.Name)
+
+		return password, nil
+	}
+
+	// Generate password if the key is missing and keep username and URL up to date
+	password = string(secret.Data[elkv1alpha1.UserSecretPasswordKey])
+	if password == "" {
+		password, err = helpers.RandomPassword(int(user.Spec.GeneratePassword.Length), user.Spec.GeneratePassword.Charset)
+		if err != nil {
+			return "", err
+		}
+	}
+	expectedData[elkv1alpha1.UserSecretPasswordKey] = []byte(password)
+
+	isUpdated := false
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for key, value := range expectedData {
+		if !bytes.Equal(secret.Data[key], value) {
+			secret.Data[key] = value
+			isUpdated = true
+		}
+	}
+	if isUpdated {
+		if err = r.Client.Update(ctx, secret); err != nil {
+			return "", errors.Wrapf(err, "Error when update Secret %s", secretNS.Name)
+		}
+		r.recorder.Eventf(user, core.EventTypeNormal, "Completed", "Credentials updated on Secret %s", secretNS.Name)
+	}
+
+	return password, nil
+}
+
+// rotatePassword permit to generate a new password and store it on the Secret read by user when the interval is elapsed
+// It return an empty password when the rotation is not needed.
+// The rotation time is stored as annotation on the Secret with the new password, so it's not lost if the status can't be updated.
+// The previous credentials are kept on previous Secret if needed, so applications can fallback on them during the handover
+func (r *UserReconciler) rotatePassword(ctx context.Context, user *elkv1alpha1.User) (password string, err error) {
+	secretNS := types.NamespacedName{
+		Namespace: user.Namespace,
+		Name:      user.GetGeneratedPasswordSecretName(),
+	}
+	key := elkv1alpha1.UserSecretPasswordKey
+	if user.Spec.Secret != nil {
+		secretNS.Name = user.Spec.Secret.Name
+		key = user.Spec.Secret.Key
+	}
+	secret := &core.Secret{}
+	if err = r.Get(ctx, secretNS, secret); err != nil {
+		return "", errors.Wrapf(err, "Error when get Secret %s", secretNS.Name)
+	}
+
+	if lastRotation, ok := secret.Annotations[userRotationAnnotation]; ok {
+		lastRotationTime, err := time.Parse(time.RFC3339, lastRotation)
+		if err != nil {
+			return "", errors.Wrapf(err, "Error when parse annotation %s on Secret %s", userRotationAnnotation, secretNS.Name)
+		}
+		if user.Status.LastRotationTime == nil || user.Status.LastRotationTime.Time.Before(lastRotationTime) {
+			user.Status.LastRotationTime = &v1.Time{Time: lastRotationTime}
+		}
+	}
+	if user.Status.LastRotationTime == nil {
+		now := v1.Now()
+		user.Status.LastRotationTime = &now
+		return "", nil
+	}
+	if time.Since(user.Status.LastRotationTime.Time) < user.Spec.Rotation.Interval.Duration {
+		return "", nil
+	}
+
+	length := userDefaultPasswordLength
+	charset := userDefaultPasswordCharset
+	if user.Spec.GeneratePassword != nil {
+		length = int(user.Spec.GeneratePassword.Length)
+		charset = user.Spec.GeneratePassword.Charset
+	}
+	password, err = helpers.RandomPassword(length, charset)
+	if err != nil {
+		return "", err
+	}
+
+	if user.Spec.Rotation.PreviousSecretName != "" {
+		if err = r.keepPreviousCredentials(ctx, user, secret); err != nil {
+			return "", err
+		}
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[key] = []byte(password)
+	now := v1.NewTime(time.Now().Truncate(time.Second))
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[userRotationAnnotation] = now.UTC().Format(time.RFC3339)
+	if err = r.Client.Update(ctx, secret); err != nil {
+		return "", errors.Wrapf(err, "Error when update Secret %s", secretNS.Name)
+	}
+
+	user.Status.LastRotationTime = &now
+	r.recorder.Eventf(user, core.EventTypeNormal, "Rotated", "Password rotated on Secret %s", secretNS.Name)
+
+	return password, nil
+}
+
+// keepPreviousCredentials permit to copy the current credentials on the previous Secret before the rotation
+// The previous Secret is owned by user when it's created by operator
+func (r *UserReconciler) keepPreviousCredentials(ctx context.Context, user *elkv1alpha1.User, current *core.Secret) (err error) {
+	secret := &core.Secret{}
+	secretNS := types.NamespacedName{
+		Namespace: user.Namespace,
+		Name:      user.Spec.Rotation.PreviousSecretName,
+	}
+	if err = r.Get(ctx, secretNS, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "Error when get Secret %s", secretNS.Name)
+		}
+
+		secret = &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      secretNS.Name,
+				Namespace: secretNS.Namespace,
+			},
+			Type: core.SecretTypeOpaque,
+			Data: current.DeepCopy().Data,
+		}
+		if err = ctrl.SetControllerReference(user, secret, r.Scheme); err != nil {
+			return errors.Wrapf(err, "Error when set owner on Secret %s", secretNS.Name)
+		}
+		if err = r.Client.Create(ctx, secret); err != nil {
+			return errors.Wrapf(err, "Error when create Secret %s", secretNS.Name)
+		}
+
+		return nil
+	}
+
+	secret.Data = current.DeepCopy().Data
+	if err = r.Client.Update(ctx, secret); err != nil {
+		return errors.Wrapf(err, "Error when update Secret %s", secretNS.Name)
+	}
+
+	return nil
+}
+
+// nextRotationDelay permit to get the duration before the next password rotation
+func nextRotationDelay(user *elkv1alpha1.User) time.Duration {
+	if user.Status.LastRotationTime == nil {
+		return waitDurationProgress
+	}
+
+	delay := time.Until(user.Status.LastRotationTime.Add(user.Spec.Rotation.Interval.Duration))
+	if delay < time.Second {
+		return time.Second
+	}
+
+	return delay
 }
@@ -16,7 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	//core "k8s.io/api/core/v1"
+	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	condition "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +36,9 @@ func (t *ControllerTestSuite) TestUserReconciler() {
 		doCreateUserStep(),
 		doUpdateUserStep(),
 		doUpdateUserPasswordHashStep(),
+		doUpdateUserGeneratePasswordStep(),
+		doUpdateUserRotatePasswordStep(),
+		doUpdateUserReservedStep(),
 		doDeleteUserStep(),
 	}
 	testCase.PreTest = doMockUser(t.mockElasticsearchHandler)
@@ -48,6 +51,8 @@ func doMockUser(mockES *mocks.MockElasticsearchHandler) func(stepName *string, d
 		isCreated := false
 		isUpdated := false
 		isUpdatedPasswordHash := false
+		isGeneratedPassword := false
+		isEnabled := false
 
 		mockES.EXPECT().UserGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.XPackSecurityUser, error) {
 			switch *stepName {
@@ -75,12 +80,18 @@ func doMockUser(mockES *mocks.MockElasticsearchHandler) func(stepName *string, d
 					}
 					return resp, nil
 				}
-			case "update_password_hash":
+			case "update_password_hash", "generate_password", "rotate_password":
 				resp := &olivere.XPackSecurityUser{
 					Enabled: false,
 					Roles:   []string{"superuser"},
 				}
 				return resp, nil
+			case "reserved":
+				resp := &olivere.XPackSecurityUser{
+					Enabled: isEnabled,
+					Roles:   []string{"superuser"},
+				}
+				return resp, nil
 
 			}
 
@@ -107,6 +118,14 @@ func doMockUser(mockES *mocks.MockElasticsearchHandler) func(stepName *string, d
 				} else {
 					return "", nil
 				}
+			case "generate_password":
+				if !isGeneratedPassword {
+					return "fake change", nil
+				} else {
+					return "", nil
+				}
+			case "rotate_password":
+				return "fake change", nil
 			}
 
 			return "", nil
@@ -122,6 +141,18 @@ func doMockUser(mockES *mocks.MockElasticsearchHandler) func(stepName *string, d
 				isUpdatedPasswordHash = true
 				data["isUpdatedPasswordHash"] = true
 				return nil
+			case "generate_password":
+				if policy.Password == "" {
+					return errors.New("Generated password not set")
+				}
+				isGeneratedPassword = true
+				data["generatedPassword"] = policy.Password
+				return nil
+			case "rotate_password":
+				if policy.Password != "" && policy.Password != data["generatedPassword"] {
+					data["rotatedPassword"] = policy.Password
+				}
+				return nil
 			}
 
 			return nil
@@ -134,6 +165,24 @@ func doMockUser(mockES *mocks.MockElasticsearchHandler) func(stepName *string, d
 			return nil
 		})
 
+		mockES.EXPECT().UserChangePassword(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, password string, passwordHash string) error {
+			if *stepName == "reserved" && password == "new-password" {
+				data["isChangedPassword"] = true
+			}
+			return nil
+		})
+
+		mockES.EXPECT().UserEnable(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			isEnabled = true
+			data["isEnabled"] = true
+			return nil
+		})
+
+		mockES.EXPECT().UserDisable(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			isEnabled = false
+			return nil
+		})
+
 		mockES.EXPECT().UserDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
 			data["isDeleted"] = true
 			return nil
@@ -283,6 +332,185 @@ func doUpdateUserPasswordHashStep() test.TestStep {
 	}
 }
 
+func doUpdateUserGeneratePasswordStep() test.TestStep {
+	return test.TestStep{
+		Name: "generate_password",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update user (generate password) %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("User is null")
+			}
+			user := o.(*elkv1alpha1.User)
+
+			user.Spec.ElasticsearchRefSpec = elkv1alpha1.ElasticsearchRefSpec{
+				Addresses:  []string{"https://elasticsearch.domain.local:9200"},
+				SecretName: "elasticsearch-credentials",
+			}
+			user.Spec.PasswordHash = ""
+			user.Spec.GeneratePassword = &elkv1alpha1.UserGeneratePassword{
+				Length:  16,
+				Charset: "abcdef0123456789",
+			}
+			if err = c.Update(context.Background(), user); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			user := &elkv1alpha1.User{}
+			password := ""
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, user); err != nil {
+					t.Fatal(err)
+				}
+				if p, ok := data["generatedPassword"]; ok {
+					password = p.(string)
+				}
+				if password == "" {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get User: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(user.Status.Conditions, userCondition, metav1.ConditionTrue))
+
+			// Check the Secret that store credentials
+			secret := &core.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: user.GetGeneratedPasswordSecretName()}, secret); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, key.Name, string(secret.Data[elkv1alpha1.UserSecretUsernameKey]))
+			assert.Equal(t, "https://elasticsearch.domain.local:9200", string(secret.Data[elkv1alpha1.UserSecretURLKey]))
+			assert.Equal(t, password, string(secret.Data[elkv1alpha1.UserSecretPasswordKey]))
+			assert.Len(t, password, 16)
+			assert.Equal(t, user.Name, secret.OwnerReferences[0].Name)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateUserRotatePasswordStep() test.TestStep {
+	return test.TestStep{
+		Name: "rotate_password",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update user (rotate password) %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("User is null")
+			}
+			user := o.(*elkv1alpha1.User)
+
+			user.Spec.Rotation = &elkv1alpha1.UserRotation{
+				Interval:           metav1.Duration{Duration: 1 * time.Second},
+				PreviousSecretName: key.Name + "-previous",
+			}
+			if err = c.Update(context.Background(), user); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			user := &elkv1alpha1.User{}
+			isRotated := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, user); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["rotatedPassword"]; ok {
+					isRotated = true
+				}
+				if !isRotated {
+					return errors.New("Not yet rotated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get User: %s", err.Error())
+			}
+			assert.NotNil(t, user.Status.LastRotationTime)
+
+			// Check the Secrets that store the credentials
+			secret := &core.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: user.GetGeneratedPasswordSecretName()}, secret); err != nil {
+				t.Fatal(err)
+			}
+			assert.NotEqual(t, data["generatedPassword"], string(secret.Data[elkv1alpha1.UserSecretPasswordKey]))
+			assert.NotEmpty(t, secret.Annotations[userRotationAnnotation])
+			previousSecret := &core.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: key.Name + "-previous"}, previousSecret); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, key.Name, string(previousSecret.Data[elkv1alpha1.UserSecretUsernameKey]))
+
+			return nil
+		},
+	}
+}
+
+func doUpdateUserReservedStep() test.TestStep {
+	return test.TestStep{
+		Name: "reserved",
+		Do: func(c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			logrus.Infof("=== Update user (reserved) %s/%s ===", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("User is null")
+			}
+			user := o.(*elkv1alpha1.User)
+
+			user.Spec.Reserved = true
+			user.Spec.Enabled = true
+			user.Spec.Rotation = nil
+			if err = c.Update(context.Background(), user); err != nil {
+				return err
+			}
+
+			// Change the password on Secret
+			secret := &core.Secret{}
+			if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: user.GetGeneratedPasswordSecretName()}, secret); err != nil {
+				return err
+			}
+			secret.Data[elkv1alpha1.UserSecretPasswordKey] = []byte("new-password")
+			if err = c.Update(context.Background(), secret); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o client.Object, data map[string]any) (err error) {
+			user := &elkv1alpha1.User{}
+			isUpdated := false
+
+			isTimeout, err := RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, user); err != nil {
+					t.Fatal(err)
+				}
+				_, isEnabled := data["isEnabled"]
+				_, isChangedPassword := data["isChangedPassword"]
+				isUpdated = isEnabled && isChangedPassword
+				if !isUpdated {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get User: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(user.Status.Conditions, userCondition, metav1.ConditionTrue))
+
+			return nil
+		},
+	}
+}
+
 func doDeleteUserStep() test.TestStep {
 	return test.TestStep{
 		Name: "delete",
@@ -321,7 +549,63 @@ func doDeleteUserStep() test.TestStep {
 			}
 			assert.True(t, isDeleted)
 
+			// The user is reserved, so it's not deleted on Elasticsearch
+			_, isDeletedOnES := data["isDeleted"]
+			assert.False(t, isDeletedOnES)
+
 			return nil
 		},
 	}
 }
+
+func (t *ControllerTestSuite) TestUserPasswordFingerprint() {
+	r := &UserReconciler{
+		FingerprintKey: []byte("key"),
+	}
+	user := &elkv1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+		Spec: elkv1alpha1.UserSpec{
+			Secret: &elkv1alpha1.UserSecret{
+				Name: "test",
+				Key:  "password",
+			},
+		},
+	}
+	data := map[string]any{
+		"password": "password",
+	}
+
+	// When password not yet set
+	isChanged, err := r.isPasswordChanged(user, data)
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), isChanged)
+
+	// When password is already set
+	user.Status.PasswordFingerprint = r.passwordFingerprint(user, "password", "")
+	assert.NotContains(t.T(), user.Status.PasswordFingerprint, "password")
+	isChanged, err = r.isPasswordChanged(user, data)
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), isChanged)
+
+	// When password change
+	data["password"] = "password2"
+	isChanged, err = r.isPasswordChanged(user, data)
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), isChanged)
+
+	// When the key change
+	data["password"] = "password"
+	r.FingerprintKey = []byte("key2")
+	isChanged, err = r.isPasswordChanged(user, data)
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), isChanged)
+
+	// When no password
+	user.Spec.Secret = nil
+	user.Status.PasswordFingerprint = ""
+	isChanged, err = r.isPasswordChanged(user, data)
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), isChanged)
+}
@@ -1,14 +1,29 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"os"
 	osruntime "runtime"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"go.uber.org/zap/zapcore"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	userFingerprintKeySecretName = "operator-elk-extra-user-fingerprint-key"
+	userFingerprintKeySecretKey  = "key"
+	serviceAccountNamespaceFile  = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 )
 
 func getZapLogLevel() zapcore.Level {
@@ -77,3 +92,73 @@ func getKubeClientTimeout() (timeout time.Duration, err error) {
 
 	return timeout, nil
 }
+
+func getOperatorNamespace() (ns string, err error) {
+	operatorNamespaceEnvVar := "OPERATOR_NAMESPACE"
+	if ns, found := os.LookupEnv(operatorNamespaceEnvVar); found && ns != "" {
+		return ns, nil
+	}
+
+	b, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "%s must be set when operator not run inside a pod", operatorNamespaceEnvVar)
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// getUserFingerprintKey permit to get the key used to compute the user password fingerprints
+// It use USER_FINGERPRINT_KEY if set, else it read the key from the operator Secret and generate it on first start
+func getUserFingerprintKey(ctx context.Context, reader client.Reader, writer client.Writer, log *logrus.Logger) (key []byte, err error) {
+	if key, found := os.LookupEnv("USER_FINGERPRINT_KEY"); found && key != "" {
+		return []byte(key), nil
+	}
+
+	namespace, err := getOperatorNamespace()
+	if err != nil {
+		return nil, err
+	}
+	log.Warnf("USER_FINGERPRINT_KEY is not set, use the key stored on Secret %s/%s", namespace, userFingerprintKeySecretName)
+
+	secret := &core.Secret{}
+	secretNS := types.NamespacedName{
+		Namespace: namespace,
+		Name:      userFingerprintKeySecretName,
+	}
+	if err = reader.Get(ctx, secretNS, secret); err == nil {
+		if key = secret.Data[userFingerprintKeySecretKey]; len(key) == 0 {
+			return nil, errors.Errorf("Secret %s must have a %s key", userFingerprintKeySecretName, userFingerprintKeySecretKey)
+		}
+		return key, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "Error when get Secret %s", userFingerprintKeySecretName)
+	}
+
+	// Generate the key one time, so it stay the same after operator restart
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return nil, err
+	}
+	key = []byte(hex.EncodeToString(b))
+	secret = &core.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretNS.Name,
+			Namespace: secretNS.Namespace,
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{
+			userFingerprintKeySecretKey: key,
+		},
+	}
+	if err = writer.Create(ctx, secret); err != nil {
+		// Another operator instance can create it at the same time
+		if k8serrors.IsAlreadyExists(err) {
+			return getUserFingerprintKey(ctx, reader, writer, log)
+		}
+		return nil, errors.Wrapf(err, "Error when create Secret %s", userFingerprintKeySecretName)
+	}
+	log.Infof("User fingerprint key generated on Secret %s/%s", namespace, userFingerprintKeySecretName)
+
+	return key, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -278,9 +279,16 @@ func main() {
 	}
 
 	// User controller
+	// The fingerprint key must be stable, else all user passwords are set again after operator restart
+	userFingerprintKey, err := getUserFingerprintKey(context.Background(), mgr.GetAPIReader(), mgr.GetClient(), log)
+	if err != nil {
+		setupLog.Error(err, "unable to get user fingerprint key")
+		os.Exit(1)
+	}
 	userController := &controllers.UserReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		FingerprintKey: userFingerprintKey,
 	}
 	userController.SetLogger(log.WithFields(logrus.Fields{
 		"type": "UserController",
@@ -309,6 +317,262 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Transform controller
+	transformController := &controllers.ElasticsearchTransformReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	transformController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "TransformController",
+	}))
+	transformController.SetRecorder(mgr.GetEventRecorderFor("transform-controller"))
+	transformController.SetReconsiler(transformController)
+	transformController.SetDinamicClient(dinamicClient)
+	if err = transformController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Transform")
+		os.Exit(1)
+	}
+
+	// ML job controller
+	mlJobController := &controllers.ElasticsearchMLJobReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	mlJobController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "MLJobController",
+	}))
+	mlJobController.SetRecorder(mgr.GetEventRecorderFor("mljob-controller"))
+	mlJobController.SetReconsiler(mlJobController)
+	mlJobController.SetDinamicClient(dinamicClient)
+	if err = mlJobController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MLJob")
+		os.Exit(1)
+	}
+
+	// ML datafeed controller
+	mlDatafeedController := &controllers.ElasticsearchMLDatafeedReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	mlDatafeedController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "MLDatafeedController",
+	}))
+	mlDatafeedController.SetRecorder(mgr.GetEventRecorderFor("mldatafeed-controller"))
+	mlDatafeedController.SetReconsiler(mlDatafeedController)
+	mlDatafeedController.SetDinamicClient(dinamicClient)
+	if err = mlDatafeedController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MLDatafeed")
+		os.Exit(1)
+	}
+
+	// Application privilege controller
+	applicationPrivilegeController := &controllers.ElasticsearchApplicationPrivilegeReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	applicationPrivilegeController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "ApplicationPrivilegeController",
+	}))
+	applicationPrivilegeController.SetRecorder(mgr.GetEventRecorderFor("application-privilege-controller"))
+	applicationPrivilegeController.SetReconsiler(applicationPrivilegeController)
+	applicationPrivilegeController.SetDinamicClient(dinamicClient)
+	if err = applicationPrivilegeController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApplicationPrivilege")
+		os.Exit(1)
+	}
+
+	// Snapshot controller
+	snapshotController := &controllers.ElasticsearchSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	snapshotController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "SnapshotController",
+	}))
+	snapshotController.SetRecorder(mgr.GetEventRecorderFor("snapshot-controller"))
+	snapshotController.SetReconsiler(snapshotController)
+	snapshotController.SetDinamicClient(dinamicClient)
+	if err = snapshotController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Snapshot")
+		os.Exit(1)
+	}
+
+	// Restore controller
+	restoreController := &controllers.ElasticsearchRestoreReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	restoreController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "RestoreController",
+	}))
+	restoreController.SetRecorder(mgr.GetEventRecorderFor("restore-controller"))
+	restoreController.SetReconsiler(restoreController)
+	restoreController.SetDinamicClient(dinamicClient)
+	if err = restoreController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Restore")
+		os.Exit(1)
+	}
+
+	// Mounted index controller
+	mountedIndexController := &controllers.ElasticsearchMountedIndexReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	mountedIndexController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "MountedIndexController",
+	}))
+	mountedIndexController.SetRecorder(mgr.GetEventRecorderFor("mounted-index-controller"))
+	mountedIndexController.SetReconsiler(mountedIndexController)
+	mountedIndexController.SetDinamicClient(dinamicClient)
+	if err = mountedIndexController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MountedIndex")
+		os.Exit(1)
+	}
+
+	// Kibana space controller
+	kibanaSpaceController := &controllers.KibanaSpaceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaSpaceController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaSpaceController",
+	}))
+	kibanaSpaceController.SetRecorder(mgr.GetEventRecorderFor("kibana-space-controller"))
+	kibanaSpaceController.SetReconsiler(kibanaSpaceController)
+	kibanaSpaceController.SetDinamicClient(dinamicClient)
+	if err = kibanaSpaceController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaSpace")
+		os.Exit(1)
+	}
+
+	// Kibana role controller
+	kibanaRoleController := &controllers.KibanaRoleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaRoleController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaRoleController",
+	}))
+	kibanaRoleController.SetRecorder(mgr.GetEventRecorderFor("kibana-role-controller"))
+	kibanaRoleController.SetReconsiler(kibanaRoleController)
+	kibanaRoleController.SetDinamicClient(dinamicClient)
+	if err = kibanaRoleController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaRole")
+		os.Exit(1)
+	}
+
+	// Kibana saved objects controller
+	kibanaSavedObjectsController := &controllers.KibanaSavedObjectsReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaSavedObjectsController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaSavedObjectsController",
+	}))
+	kibanaSavedObjectsController.SetRecorder(mgr.GetEventRecorderFor("kibana-saved-objects-controller"))
+	kibanaSavedObjectsController.SetReconsiler(kibanaSavedObjectsController)
+	kibanaSavedObjectsController.SetDinamicClient(dinamicClient)
+	if err = kibanaSavedObjectsController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaSavedObjects")
+		os.Exit(1)
+	}
+
+	// Kibana data view controller
+	kibanaDataViewController := &controllers.KibanaDataViewReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaDataViewController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaDataViewController",
+	}))
+	kibanaDataViewController.SetRecorder(mgr.GetEventRecorderFor("kibana-data-view-controller"))
+	kibanaDataViewController.SetReconsiler(kibanaDataViewController)
+	kibanaDataViewController.SetDinamicClient(dinamicClient)
+	if err = kibanaDataViewController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaDataView")
+		os.Exit(1)
+	}
+
+	// Kibana connector controller
+	kibanaConnectorController := &controllers.KibanaConnectorReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaConnectorController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaConnectorController",
+	}))
+	kibanaConnectorController.SetRecorder(mgr.GetEventRecorderFor("kibana-connector-controller"))
+	kibanaConnectorController.SetReconsiler(kibanaConnectorController)
+	kibanaConnectorController.SetDinamicClient(dinamicClient)
+	if err = kibanaConnectorController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaConnector")
+		os.Exit(1)
+	}
+
+	// Kibana alert rule controller
+	kibanaAlertRuleController := &controllers.KibanaAlertRuleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	kibanaAlertRuleController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "KibanaAlertRuleController",
+	}))
+	kibanaAlertRuleController.SetRecorder(mgr.GetEventRecorderFor("kibana-alert-rule-controller"))
+	kibanaAlertRuleController.SetReconsiler(kibanaAlertRuleController)
+	kibanaAlertRuleController.SetDinamicClient(dinamicClient)
+	if err = kibanaAlertRuleController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaAlertRule")
+		os.Exit(1)
+	}
+
+	// Logstash pipeline controller
+	logstashPipelineController := &controllers.LogstashPipelineReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	logstashPipelineController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "LogstashPipelineController",
+	}))
+	logstashPipelineController.SetRecorder(mgr.GetEventRecorderFor("logstash-pipeline-controller"))
+	logstashPipelineController.SetReconsiler(logstashPipelineController)
+	logstashPipelineController.SetDinamicClient(dinamicClient)
+	if err = logstashPipelineController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LogstashPipeline")
+		os.Exit(1)
+	}
+
+	// Fleet agent policy controller
+	fleetAgentPolicyController := &controllers.FleetAgentPolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	fleetAgentPolicyController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "FleetAgentPolicyController",
+	}))
+	fleetAgentPolicyController.SetRecorder(mgr.GetEventRecorderFor("fleet-agent-policy-controller"))
+	fleetAgentPolicyController.SetReconsiler(fleetAgentPolicyController)
+	fleetAgentPolicyController.SetDinamicClient(dinamicClient)
+	if err = fleetAgentPolicyController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FleetAgentPolicy")
+		os.Exit(1)
+	}
+
+	// Fleet package policy controller
+	fleetPackagePolicyController := &controllers.FleetPackagePolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+	fleetPackagePolicyController.SetLogger(log.WithFields(logrus.Fields{
+		"type": "FleetPackagePolicyController",
+	}))
+	fleetPackagePolicyController.SetRecorder(mgr.GetEventRecorderFor("fleet-package-policy-controller"))
+	fleetPackagePolicyController.SetReconsiler(fleetPackagePolicyController)
+	fleetPackagePolicyController.SetDinamicClient(dinamicClient)
+	if err = fleetPackagePolicyController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FleetPackagePolicy")
+		os.Exit(1)
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,121 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// XPackSecurityApplicationPrivilege is the application privilege object
+type XPackSecurityApplicationPrivilege struct {
+	Actions  []string       `json:"actions"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ApplicationPrivilegeUpdate permit to create or update application privilege
+func (h *ElasticsearchHandlerImpl) ApplicationPrivilegeUpdate(application, name string, privilege *XPackSecurityApplicationPrivilege) (err error) {
+
+	payload := map[string]map[string]*XPackSecurityApplicationPrivilege{
+		application: {
+			name: privilege,
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.Security.PutPrivileges(
+		bytes.NewReader(data),
+		h.client.API.Security.PutPrivileges.WithContext(context.Background()),
+		h.client.API.Security.PutPrivileges.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add application privilege %s/%s: %s\ndata: %s", application, name, res.String(), string(data))
+	}
+
+	return nil
+}
+
+// ApplicationPrivilegeDelete permit to delete application privilege
+func (h *ElasticsearchHandlerImpl) ApplicationPrivilegeDelete(application, name string) (err error) {
+
+	res, err := h.client.API.Security.DeletePrivileges(
+		name,
+		application,
+		h.client.API.Security.DeletePrivileges.WithContext(context.Background()),
+		h.client.API.Security.DeletePrivileges.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete application privilege %s/%s: %s", application, name, res.String())
+	}
+
+	h.log.Infof("Deleted application privilege %s/%s successfully", application, name)
+
+	return nil
+}
+
+// ApplicationPrivilegeGet permit to get application privilege
+func (h *ElasticsearchHandlerImpl) ApplicationPrivilegeGet(application, name string) (privilege *XPackSecurityApplicationPrivilege, err error) {
+
+	res, err := h.client.API.Security.GetPrivileges(
+		h.client.API.Security.GetPrivileges.WithContext(context.Background()),
+		h.client.API.Security.GetPrivileges.WithPretty(),
+		h.client.API.Security.GetPrivileges.WithApplication(application),
+		h.client.API.Security.GetPrivileges.WithName(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get application privilege %s/%s: %s", application, name, res.String())
+
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get application privilege %s/%s successfully:\n%s", application, name, string(b))
+	privilegeResp := make(map[string]map[string]XPackSecurityApplicationPrivilege)
+	err = json.Unmarshal(b, &privilegeResp)
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, ok := privilegeResp[application][name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &tmp, nil
+}
+
+// ApplicationPrivilegeDiff permit to check if 2 application privileges are the same
+func (h *ElasticsearchHandlerImpl) ApplicationPrivilegeDiff(actual, expected *XPackSecurityApplicationPrivilege) (diff string, err error) {
+	return standartDiff(actual, expected, h.log, nil)
+}
@@ -0,0 +1,139 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlApplicationPrivilege = fmt.Sprintf("%s/_security/privilege/myapp/read", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestApplicationPrivilegeGet() {
+
+	rawResp := `
+	{
+		"myapp": {
+			"read": {
+				"application": "myapp",
+				"name": "read",
+				"actions": [
+					"data:read/*",
+					"action:login"
+				],
+				"metadata": {
+					"description": "Read access to myapp"
+				}
+			}
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlApplicationPrivilege, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	privilege, err := t.esHandler.ApplicationPrivilegeGet("myapp", "read")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), []string{"data:read/*", "action:login"}, privilege.Actions)
+	assert.Equal(t.T(), "Read access to myapp", privilege.Metadata["description"])
+
+	// When privilege not exist
+	httpmock.RegisterResponder("GET", urlApplicationPrivilege, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, `{}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	privilege, err = t.esHandler.ApplicationPrivilegeGet("myapp", "read")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), privilege)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlApplicationPrivilege, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.ApplicationPrivilegeGet("myapp", "read")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestApplicationPrivilegeDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlApplicationPrivilege, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"myapp": {"read": {"found": true}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.ApplicationPrivilegeDelete("myapp", "read")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlApplicationPrivilege, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.ApplicationPrivilegeDelete("myapp", "read")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestApplicationPrivilegeUpdate() {
+	privilege := &XPackSecurityApplicationPrivilege{
+		Actions: []string{"data:read/*", "action:login"},
+	}
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_security/privilege/", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"myapp": {"read": {"created": true}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.ApplicationPrivilegeUpdate("myapp", "read", privilege)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_security/privilege/", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.ApplicationPrivilegeUpdate("myapp", "read", privilege)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestApplicationPrivilegeDiff() {
+	var actual, expected *XPackSecurityApplicationPrivilege
+
+	expected = &XPackSecurityApplicationPrivilege{
+		Actions: []string{"data:read/*", "action:login"},
+	}
+
+	// When privilege not exist yet
+	actual = nil
+	diff, err := t.esHandler.ApplicationPrivilegeDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When privilege is the same
+	actual = &XPackSecurityApplicationPrivilege{
+		Actions:  []string{"data:read/*", "action:login"},
+		Metadata: map[string]any{},
+	}
+	diff, err = t.esHandler.ApplicationPrivilegeDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When privilege is not the same
+	expected.Actions = []string{"data:read/*"}
+	diff, err = t.esHandler.ApplicationPrivilegeDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -19,18 +19,43 @@ type ElasticsearchHandler interface {
 	ILMDelete(name string) (err error)
 	ILMGet(name string) (policy *olivere.XPackIlmGetLifecycleResponse, err error)
 	ILMDiff(actual, expected *olivere.XPackIlmGetLifecycleResponse) (diff string, err error)
+	ILMExplain(name string) (explain *ILMPolicyExplain, err error)
+	ILMRetry(index string) (err error)
 
 	// SLM scope
 	SLMUpdate(name string, policy *SnapshotLifecyclePolicySpec) (err error)
 	SLMDelete(name string) (err error)
 	SLMGet(name string) (policy *SnapshotLifecyclePolicySpec, err error)
 	SLMDiff(actual, expected *SnapshotLifecyclePolicySpec) (diff string, err error)
+	SLMGetStatus(name string) (status *SnapshotLifecyclePolicyStatus, err error)
+	SLMExecute(name string) (snapshotName string, err error)
+	SLMExecuteRetention() (err error)
 
 	// Snapshot repository scope
 	SnapshotRepositoryUpdate(name string, repository *olivere.SnapshotRepositoryMetaData) (err error)
 	SnapshotRepositoryDelete(name string) (err error)
 	SnapshotRepositoryGet(name string) (repository *olivere.SnapshotRepositoryMetaData, err error)
 	SnapshotRepositoryDiff(actual, expected *olivere.SnapshotRepositoryMetaData) (diff string, err error)
+	SnapshotRepositoryVerify(name string) (nodes []string, err error)
+	SnapshotRepositoryCleanup(name string) (result *SnapshotRepositoryCleanupResult, err error)
+
+	// Snapshot scope
+	SnapshotCreate(repository, name string, snapshot *Snapshot) (err error)
+	SnapshotDelete(repository, name string) (err error)
+	SnapshotGet(repository, name string) (snapshot *SnapshotInfo, err error)
+	SnapshotStatus(repository, name string) (status *SnapshotStatus, err error)
+
+	// Restore scope
+	RestoreStart(repository, snapshot string, restore *Restore) (err error)
+
+	// Mounted index scope
+	MountedIndexCreate(repository, snapshot, storage string, mount *MountedIndex) (err error)
+	MountedIndexDelete(name string) (err error)
+	MountedIndexGet(name string) (mount *MountedIndexInfo, err error)
+
+	// Recovery scope
+	IndexRecoveryGet(indices ...string) (recovery map[string]IndexRecovery, err error)
+	IndexShardsGet(indices ...string) (shards []IndexShard, err error)
 
 	// Role scope
 	RoleUpdate(name string, role *XPackSecurityRole) (err error)
@@ -38,11 +63,17 @@ type ElasticsearchHandler interface {
 	RoleGet(name string) (role *XPackSecurityRole, err error)
 	RoleDiff(actual, expected *XPackSecurityRole) (diff string, err error)
 
+	// Application privilege scope
+	ApplicationPrivilegeUpdate(application, name string, privilege *XPackSecurityApplicationPrivilege) (err error)
+	ApplicationPrivilegeDelete(application, name string) (err error)
+	ApplicationPrivilegeGet(application, name string) (privilege *XPackSecurityApplicationPrivilege, err error)
+	ApplicationPrivilegeDiff(actual, expected *XPackSecurityApplicationPrivilege) (diff string, err error)
+
 	// Role mapping scope
-	RoleMappingUpdate(name string, roleMapping *olivere.XPackSecurityRoleMapping) (err error)
+	RoleMappingUpdate(name string, roleMapping *XPackSecurityRoleMapping) (err error)
 	RoleMappingDelete(name string) (err error)
-	RoleMappingGet(name string) (roleMapping *olivere.XPackSecurityRoleMapping, err error)
-	RoleMappingDiff(actual, expected *olivere.XPackSecurityRoleMapping) (diff string, err error)
+	RoleMappingGet(name string) (roleMapping *XPackSecurityRoleMapping, err error)
+	RoleMappingDiff(actual, expected *XPackSecurityRoleMapping) (diff string, err error)
 
 	// User scope
 	UserCreate(name string, user *olivere.XPackSecurityPutUserRequest) (err error)
@@ -50,6 +81,9 @@ type ElasticsearchHandler interface {
 	UserDelete(name string) (err error)
 	UserGet(name string) (user *olivere.XPackSecurityUser, err error)
 	UserDiff(actual, expected *olivere.XPackSecurityPutUserRequest) (diff string, err error)
+	UserChangePassword(name string, password string, passwordHash string) (err error)
+	UserEnable(name string) (err error)
+	UserDisable(name string) (err error)
 
 	// Component template scope
 	ComponentTemplateUpdate(name string, component *olivere.IndicesGetComponentTemplateData) (err error)
@@ -69,6 +103,43 @@ type ElasticsearchHandler interface {
 	WatchGet(name string) (watch *olivere.XPackWatch, err error)
 	WatchDiff(actual, expected *olivere.XPackWatch) (diff string, err error)
 
+	// Transform scope
+	TransformCreate(name string, transform *Transform) (err error)
+	TransformUpdate(name string, transform *Transform) (err error)
+	TransformDelete(name string) (err error)
+	TransformGet(name string) (transform *Transform, err error)
+	TransformStart(name string) (err error)
+	TransformStop(name string) (err error)
+	TransformForceStop(name string) (err error)
+	TransformStats(name string) (stats *TransformStats, err error)
+	TransformDiff(actual, expected *Transform) (diff string, err error)
+
+	// ML job scope
+	MLJobCreate(name string, job *MLJob) (err error)
+	MLJobUpdate(name string, job *MLJob) (err error)
+	MLJobDelete(name string) (err error)
+	MLJobGet(name string) (job *MLJob, err error)
+	MLJobOpen(name string) (err error)
+	MLJobClose(name string) (err error)
+	MLJobStats(name string) (stats *MLJobStats, err error)
+	MLJobDiff(actual, expected *MLJob) (diff string, err error)
+
+	// ML datafeed scope
+	MLDatafeedCreate(name string, datafeed *MLDatafeed) (err error)
+	MLDatafeedUpdate(name string, datafeed *MLDatafeed) (err error)
+	MLDatafeedDelete(name string) (err error)
+	MLDatafeedGet(name string) (datafeed *MLDatafeed, err error)
+	MLDatafeedStart(name string) (err error)
+	MLDatafeedStop(name string) (err error)
+	MLDatafeedStats(name string) (stats *MLDatafeedStats, err error)
+	MLDatafeedDiff(actual, expected *MLDatafeed) (diff string, err error)
+
+	// Logstash pipeline scope
+	LogstashPipelineUpdate(name string, pipeline *LogstashPipeline) (err error)
+	LogstashPipelineDelete(name string) (err error)
+	LogstashPipelineGet(name string) (pipeline *LogstashPipeline, err error)
+	LogstashPipelineDiff(actual, expected *LogstashPipeline) (diff string, err error)
+
 	SetLogger(log *logrus.Entry)
 }
 
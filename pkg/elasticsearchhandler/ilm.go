@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"strings"
 
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/pkg/errors"
@@ -14,6 +15,46 @@ var ignoreILMPolicyDiff = map[string]any{
 	"phases.delete.actions.delete.delete_searchable_snapshot": true,
 }
 
+// ilmExplainBatchSize is the max number of indices explained by one call, to avoid too long URL
+const ilmExplainBatchSize = 100
+
+// ILMPolicyExplain is the usage of ILM policy and the ILM state of indices that use it
+type ILMPolicyExplain struct {
+	InUseBy ILMPolicyInUseBy           `json:"in_use_by"`
+	Indices map[string]ILMIndexExplain `json:"indices"`
+}
+
+// ILMPolicyInUseBy is the objects that use the ILM policy
+type ILMPolicyInUseBy struct {
+	Indices             []string `json:"indices"`
+	DataStreams         []string `json:"data_streams"`
+	ComposableTemplates []string `json:"composable_templates"`
+}
+
+// ILMIndexExplain is the ILM state of index
+type ILMIndexExplain struct {
+	Index                string         `json:"index"`
+	Managed              bool           `json:"managed"`
+	Policy               string         `json:"policy,omitempty"`
+	Phase                string         `json:"phase,omitempty"`
+	Action               string         `json:"action,omitempty"`
+	Step                 string         `json:"step,omitempty"`
+	FailedStep           string         `json:"failed_step,omitempty"`
+	IsAutoRetryableError bool           `json:"is_auto_retryable_error,omitempty"`
+	FailedStepRetryCount int64          `json:"failed_step_retry_count,omitempty"`
+	StepInfo             map[string]any `json:"step_info,omitempty"`
+}
+
+// ilmPolicyInUseByResponse is the in_use_by part of get lifecycle policy API
+type ilmPolicyInUseByResponse struct {
+	InUseBy ILMPolicyInUseBy `json:"in_use_by"`
+}
+
+// ilmExplainResponse is the response of explain lifecycle API
+type ilmExplainResponse struct {
+	Indices map[string]ILMIndexExplain `json:"indices"`
+}
+
 // ILMUpdate permit to update or create policy
 func (h *ElasticsearchHandlerImpl) ILMUpdate(name string, policy *olivere.XPackIlmGetLifecycleResponse) (err error) {
 
@@ -118,3 +159,128 @@ func (h *ElasticsearchHandlerImpl) ILMDiff(actual, expected *olivere.XPackIlmGet
 	}
 	return standartDiff(actualPolicy, expectedPolicy, h.log, ignoreILMPolicyDiff)
 }
+
+// ILMExplain permit to get the objects that use the policy and the ILM state of their indices
+// It return nil if policy not exist
+func (h *ElasticsearchHandlerImpl) ILMExplain(name string) (explain *ILMPolicyExplain, err error) {
+
+	res, err := h.client.API.ILM.GetLifecycle(
+		h.client.API.ILM.GetLifecycle.WithContext(context.Background()),
+		h.client.API.ILM.GetLifecycle.WithPretty(),
+		h.client.API.ILM.GetLifecycle.WithPolicy(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get lifecycle policy %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	policyResp := make(map[string]*ilmPolicyInUseByResponse)
+	if err = json.Unmarshal(b, &policyResp); err != nil {
+		return nil, err
+	}
+	if policyResp[name] == nil {
+		return nil, nil
+	}
+
+	explain = &ILMPolicyExplain{
+		InUseBy: policyResp[name].InUseBy,
+		Indices: map[string]ILMIndexExplain{},
+	}
+
+	for start := 0; start < len(explain.InUseBy.Indices); start += ilmExplainBatchSize {
+		end := start + ilmExplainBatchSize
+		if end > len(explain.InUseBy.Indices) {
+			end = len(explain.InUseBy.Indices)
+		}
+		indices, err := h.ilmExplainIndices(name, explain.InUseBy.Indices[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for index, indexExplain := range indices {
+			explain.Indices[index] = indexExplain
+		}
+	}
+
+	h.log.Debugf("Explain lifecycle policy %s successfully: %d indices", name, len(explain.Indices))
+
+	return explain, nil
+}
+
+// ilmExplainIndices permit to get the ILM state of some indices
+func (h *ElasticsearchHandlerImpl) ilmExplainIndices(name string, indices []string) (explains map[string]ILMIndexExplain, err error) {
+
+	res, err := h.client.API.ILM.ExplainLifecycle(
+		strings.Join(indices, ","),
+		h.client.API.ILM.ExplainLifecycle.WithContext(context.Background()),
+		h.client.API.ILM.ExplainLifecycle.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		// Index can be deleted between the 2 calls, so explain the batch index by index to keep the existing ones
+		if res.StatusCode == 404 {
+			h.log.Debugf("Some indices not found when explain lifecycle policy %s: %s", name, res.String())
+			if len(indices) == 1 {
+				return nil, nil
+			}
+			explains = map[string]ILMIndexExplain{}
+			for _, index := range indices {
+				indexExplains, err := h.ilmExplainIndices(name, []string{index})
+				if err != nil {
+					return nil, err
+				}
+				for explainedIndex, indexExplain := range indexExplains {
+					explains[explainedIndex] = indexExplain
+				}
+			}
+			return explains, nil
+		}
+		return nil, errors.Errorf("Error when explain lifecycle policy %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	explainResp := &ilmExplainResponse{}
+	if err = json.Unmarshal(b, explainResp); err != nil {
+		return nil, err
+	}
+
+	return explainResp.Indices, nil
+}
+
+// ILMRetry permit to retry the failed step of index stuck on ERROR step
+func (h *ElasticsearchHandlerImpl) ILMRetry(index string) (err error) {
+
+	res, err := h.client.API.ILM.Retry(
+		index,
+		h.client.API.ILM.Retry.WithContext(context.Background()),
+		h.client.API.ILM.Retry.WithPretty(),
+	)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when retry lifecycle step of index %s: %s", index, res.String())
+	}
+
+	h.log.Infof("Retry lifecycle step of index %s successfully", index)
+
+	return nil
+}
@@ -222,3 +222,152 @@ func (t *ElasticsearchHandlerTestSuite) TestILMDiff() {
 	assert.NotEmpty(t.T(), diff)
 
 }
+
+func (t *ElasticsearchHandlerTestSuite) TestILMExplain() {
+
+	rawPolicy := `
+{
+	"test" : {
+		"policy": {
+			"phases": {
+				"delete": {
+					"min_age": "31d",
+					"actions": {
+						"delete": {}
+					}
+				}
+			}
+		},
+		"in_use_by": {
+			"indices": ["logs-1", "logs-2"],
+			"data_streams": ["logs"],
+			"composable_templates": ["logs"]
+		}
+	}
+}
+	`
+
+	rawExplain := `
+{
+	"indices": {
+		"logs-1": {
+			"index": "logs-1",
+			"managed": true,
+			"policy": "test",
+			"phase": "hot",
+			"action": "rollover",
+			"step": "check-rollover-ready"
+		},
+		"logs-2": {
+			"index": "logs-2",
+			"managed": true,
+			"policy": "test",
+			"phase": "hot",
+			"action": "rollover",
+			"step": "ERROR",
+			"failed_step": "check-rollover-ready",
+			"is_auto_retryable_error": true,
+			"failed_step_retry_count": 2,
+			"step_info": {
+				"type": "illegal_argument_exception",
+				"reason": "rollover target [logs] does not point to a write index"
+			}
+		}
+	}
+}
+	`
+
+	httpmock.RegisterResponder("GET", urlILM, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawPolicy)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/logs-1,logs-2/_ilm/explain", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawExplain)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	explain, err := t.esHandler.ILMExplain("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), []string{"logs"}, explain.InUseBy.DataStreams)
+	assert.Equal(t.T(), 2, len(explain.Indices))
+	assert.Equal(t.T(), "ERROR", explain.Indices["logs-2"].Step)
+	assert.Equal(t.T(), "check-rollover-ready", explain.Indices["logs-2"].FailedStep)
+	assert.Equal(t.T(), int64(2), explain.Indices["logs-2"].FailedStepRetryCount)
+
+	// When index deleted between the 2 calls
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/logs-1,logs-2/_ilm/explain", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, `{}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/logs-1/_ilm/explain", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, `{}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/logs-2/_ilm/explain", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"indices": {"logs-2": {"index": "logs-2", "managed": true, "policy": "test", "phase": "hot", "step": "ERROR"}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	explain, err = t.esHandler.ILMExplain("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), 1, len(explain.Indices))
+	assert.Equal(t.T(), "ERROR", explain.Indices["logs-2"].Step)
+
+	// When policy not exist
+	httpmock.RegisterResponder("GET", urlILM, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, `{}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	explain, err = t.esHandler.ILMExplain("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), explain)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlILM, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawPolicy)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/logs-1,logs-2/_ilm/explain", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.ILMExplain("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestILMRetry() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/logs-2/_ilm/retry", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.ILMRetry("logs-2")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When index is not on ERROR step
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/logs-2/_ilm/retry", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(400, `{"error": {"type": "illegal_argument_exception"}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	err = t.esHandler.ILMRetry("logs-2")
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/logs-2/_ilm/retry", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.ILMRetry("logs-2")
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,164 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// logstashPipelineUsername is the username stored on pipeline managed by operator
+	logstashPipelineUsername = "operator-elk-extra"
+)
+
+// LogstashPipeline is the centrally managed Logstash pipeline object
+type LogstashPipeline struct {
+	Description      string         `json:"description"`
+	LastModified     string         `json:"last_modified,omitempty"`
+	Pipeline         string         `json:"pipeline"`
+	PipelineMetadata map[string]any `json:"pipeline_metadata,omitempty"`
+	PipelineSettings map[string]any `json:"pipeline_settings"`
+	Username         string         `json:"username,omitempty"`
+}
+
+// LogstashPipelineUpdate permit to create or update the pipeline
+// It fill the fields required by API when they are not provided
+func (h *ElasticsearchHandlerImpl) LogstashPipelineUpdate(name string, pipeline *LogstashPipeline) (err error) {
+
+	payload := *pipeline
+	if payload.LastModified == "" {
+		payload.LastModified = time.Now().UTC().Format(time.RFC3339)
+	}
+	if payload.PipelineMetadata == nil {
+		payload.PipelineMetadata = map[string]any{
+			"type":    "logstash_pipeline",
+			"version": 1,
+		}
+	}
+	if payload.PipelineSettings == nil {
+		payload.PipelineSettings = map[string]any{}
+	}
+	if payload.Username == "" {
+		payload.Username = logstashPipelineUsername
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.LogstashPutPipeline(
+		name,
+		bytes.NewReader(b),
+		h.client.API.LogstashPutPipeline.WithContext(context.Background()),
+		h.client.API.LogstashPutPipeline.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add Logstash pipeline %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// LogstashPipelineDelete permit to delete the pipeline
+func (h *ElasticsearchHandlerImpl) LogstashPipelineDelete(name string) (err error) {
+
+	res, err := h.client.API.LogstashDeletePipeline(
+		name,
+		h.client.API.LogstashDeletePipeline.WithContext(context.Background()),
+		h.client.API.LogstashDeletePipeline.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete Logstash pipeline %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Deleted Logstash pipeline %s successfully", name)
+
+	return nil
+}
+
+// LogstashPipelineGet permit to get the pipeline
+func (h *ElasticsearchHandlerImpl) LogstashPipelineGet(name string) (pipeline *LogstashPipeline, err error) {
+
+	res, err := h.client.API.LogstashGetPipeline(
+		name,
+		h.client.API.LogstashGetPipeline.WithContext(context.Background()),
+		h.client.API.LogstashGetPipeline.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get Logstash pipeline %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get Logstash pipeline %s successfully:\n%s", name, string(b))
+
+	pipelineResp := map[string]LogstashPipeline{}
+	if err = json.Unmarshal(b, &pipelineResp); err != nil {
+		return nil, err
+	}
+
+	p, ok := pipelineResp[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+// LogstashPipelineDiff permit to check if 2 pipelines are the same
+// It ignore the fields managed by Elasticsearch
+func (h *ElasticsearchHandlerImpl) LogstashPipelineDiff(actual, expected *LogstashPipeline) (diff string, err error) {
+	if actual != nil {
+		tmp := *actual
+		tmp.LastModified = ""
+		tmp.PipelineMetadata = nil
+		tmp.Username = ""
+		if len(tmp.PipelineSettings) == 0 {
+			tmp.PipelineSettings = nil
+		}
+		actual = &tmp
+	}
+	if expected != nil {
+		tmp := *expected
+		tmp.LastModified = ""
+		tmp.PipelineMetadata = nil
+		tmp.Username = ""
+		if len(tmp.PipelineSettings) == 0 {
+			tmp.PipelineSettings = nil
+		}
+		expected = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
@@ -0,0 +1,188 @@
+package elasticsearchhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlLogstashPipeline = fmt.Sprintf("%s/_logstash/pipeline/test", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestLogstashPipelineGet() {
+
+	rawResp := `
+	{
+		"test": {
+			"description": "Sample pipeline for illustration purposes",
+			"last_modified": "2021-01-02T02:50:51.250Z",
+			"pipeline_metadata": {
+				"type": "logstash_pipeline",
+				"version": "1"
+			},
+			"username": "elastic",
+			"pipeline": "input {}\n filter { grok {} }\n output {}",
+			"pipeline_settings": {
+				"pipeline.workers": 1,
+				"pipeline.batch.size": 125,
+				"pipeline.batch.delay": 50,
+				"queue.type": "memory",
+				"queue.max_bytes": "1gb",
+				"queue.checkpoint.writes": 1024
+			}
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlLogstashPipeline, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	pipeline, err := t.esHandler.LogstashPipelineGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "Sample pipeline for illustration purposes", pipeline.Description)
+	assert.Equal(t.T(), "input {}\n filter { grok {} }\n output {}", pipeline.Pipeline)
+	assert.Equal(t.T(), float64(1), pipeline.PipelineSettings["pipeline.workers"])
+
+	// When pipeline not exist
+	httpmock.RegisterResponder("GET", urlLogstashPipeline, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "{}")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	pipeline, err = t.esHandler.LogstashPipelineGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), pipeline)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlLogstashPipeline, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.LogstashPipelineGet("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestLogstashPipelineDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlLogstashPipeline, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.LogstashPipelineDelete("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlLogstashPipeline, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.LogstashPipelineDelete("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestLogstashPipelineUpdate() {
+	pipeline := &LogstashPipeline{
+		Description: "Sample pipeline",
+		Pipeline:    "input {}\n filter { grok {} }\n output {}",
+		PipelineSettings: map[string]any{
+			"pipeline.workers": 1,
+		},
+	}
+	payload := &LogstashPipeline{}
+
+	httpmock.RegisterResponder("PUT", urlLogstashPipeline, func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(b, payload); err != nil {
+			return nil, err
+		}
+		resp := httpmock.NewStringResponse(201, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.LogstashPipelineUpdate("test", pipeline)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// The fields required by API are filled
+	assert.NotEmpty(t.T(), payload.LastModified)
+	assert.NotEmpty(t.T(), payload.Username)
+	assert.Equal(t.T(), "logstash_pipeline", payload.PipelineMetadata["type"])
+	assert.Empty(t.T(), pipeline.LastModified)
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlLogstashPipeline, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.LogstashPipelineUpdate("test", pipeline)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestLogstashPipelineDiff() {
+	var actual, expected *LogstashPipeline
+
+	expected = &LogstashPipeline{
+		Description: "Sample pipeline",
+		Pipeline:    "input {}\n filter { mutate { add_field => { \"host\" => \"${HOSTNAME}\" } } }\n output {}",
+		PipelineSettings: map[string]any{
+			"pipeline.workers":    int64(1),
+			"pipeline.batch.size": int64(125),
+		},
+	}
+
+	// When pipeline not exist yet
+	actual = nil
+	diff, err := t.esHandler.LogstashPipelineDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When pipeline is the same
+	actual = &LogstashPipeline{
+		Description:  "Sample pipeline",
+		LastModified: "2021-01-02T02:50:51.250Z",
+		Pipeline:     "input {}\n filter { mutate { add_field => { \"host\" => \"${HOSTNAME}\" } } }\n output {}",
+		PipelineMetadata: map[string]any{
+			"type":    "logstash_pipeline",
+			"version": "1",
+		},
+		PipelineSettings: map[string]any{
+			"pipeline.workers":    float64(1),
+			"pipeline.batch.size": float64(125),
+		},
+		Username: "elastic",
+	}
+	diff, err = t.esHandler.LogstashPipelineDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When pipeline is not the same
+	expected.PipelineSettings["pipeline.workers"] = int64(2)
+	diff, err = t.esHandler.LogstashPipelineDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	expected.PipelineSettings["pipeline.workers"] = int64(1)
+	expected.Pipeline = "input {}\n output {}"
+	diff, err = t.esHandler.LogstashPipelineDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -0,0 +1,286 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// MLDatafeed is the datafeed object
+type MLDatafeed struct {
+	JobID                  string         `json:"job_id,omitempty"`
+	Indices                []string       `json:"indices,omitempty"`
+	Query                  map[string]any `json:"query,omitempty"`
+	Frequency              string         `json:"frequency,omitempty"`
+	QueryDelay             string         `json:"query_delay,omitempty"`
+	ScrollSize             *int64         `json:"scroll_size,omitempty"`
+	ChunkingConfig         map[string]any `json:"chunking_config,omitempty"`
+	DelayedDataCheckConfig map[string]any `json:"delayed_data_check_config,omitempty"`
+	RuntimeMappings        map[string]any `json:"runtime_mappings,omitempty"`
+	ScriptFields           map[string]any `json:"script_fields,omitempty"`
+	Aggregations           map[string]any `json:"aggregations,omitempty"`
+	MaxEmptySearches       *int64         `json:"max_empty_searches,omitempty"`
+	IndicesOptions         map[string]any `json:"indices_options,omitempty"`
+}
+
+// MLDatafeedStats is the datafeed stats object returned by API
+type MLDatafeedStats struct {
+	DatafeedID            string `json:"datafeed_id"`
+	State                 string `json:"state"`
+	AssignmentExplanation string `json:"assignment_explanation,omitempty"`
+}
+
+// mlDatafeedGetResponse is the response of get datafeed API
+type mlDatafeedGetResponse struct {
+	Count     int64        `json:"count"`
+	Datafeeds []MLDatafeed `json:"datafeeds"`
+}
+
+// mlDatafeedStatsResponse is the response of get datafeed stats API
+type mlDatafeedStatsResponse struct {
+	Count     int64             `json:"count"`
+	Datafeeds []MLDatafeedStats `json:"datafeeds"`
+}
+
+// MLDatafeedCreate permit to create new datafeed
+func (h *ElasticsearchHandlerImpl) MLDatafeedCreate(name string, datafeed *MLDatafeed) (err error) {
+
+	b, err := json.Marshal(datafeed)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.ML.PutDatafeed(
+		bytes.NewReader(b),
+		name,
+		h.client.API.ML.PutDatafeed.WithContext(context.Background()),
+		h.client.API.ML.PutDatafeed.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add ML datafeed %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// MLDatafeedUpdate permit to update the datafeed
+// The job can't be changed, so it's not sent
+func (h *ElasticsearchHandlerImpl) MLDatafeedUpdate(name string, datafeed *MLDatafeed) (err error) {
+
+	payload := *datafeed
+	payload.JobID = ""
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.ML.UpdateDatafeed(
+		bytes.NewReader(b),
+		name,
+		h.client.API.ML.UpdateDatafeed.WithContext(context.Background()),
+		h.client.API.ML.UpdateDatafeed.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update ML datafeed %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// MLDatafeedDelete permit to delete the datafeed
+func (h *ElasticsearchHandlerImpl) MLDatafeedDelete(name string) (err error) {
+
+	res, err := h.client.API.ML.DeleteDatafeed(
+		name,
+		h.client.API.ML.DeleteDatafeed.WithContext(context.Background()),
+		h.client.API.ML.DeleteDatafeed.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete ML datafeed %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Deleted ML datafeed %s successfully", name)
+
+	return nil
+}
+
+// MLDatafeedGet permit to get the datafeed
+func (h *ElasticsearchHandlerImpl) MLDatafeedGet(name string) (datafeed *MLDatafeed, err error) {
+
+	res, err := h.client.API.ML.GetDatafeeds(
+		h.client.API.ML.GetDatafeeds.WithContext(context.Background()),
+		h.client.API.ML.GetDatafeeds.WithPretty(),
+		h.client.API.ML.GetDatafeeds.WithDatafeedID(name),
+		h.client.API.ML.GetDatafeeds.WithExcludeGenerated(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get ML datafeed %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get ML datafeed %s successfully:\n%s", name, string(b))
+
+	datafeedResp := &mlDatafeedGetResponse{}
+	if err = json.Unmarshal(b, datafeedResp); err != nil {
+		return nil, err
+	}
+
+	if len(datafeedResp.Datafeeds) == 0 {
+		return nil, nil
+	}
+
+	return &datafeedResp.Datafeeds[0], nil
+}
+
+// MLDatafeedStart permit to start the datafeed
+func (h *ElasticsearchHandlerImpl) MLDatafeedStart(name string) (err error) {
+
+	res, err := h.client.API.ML.StartDatafeed(
+		name,
+		h.client.API.ML.StartDatafeed.WithContext(context.Background()),
+		h.client.API.ML.StartDatafeed.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when start ML datafeed %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Started ML datafeed %s successfully", name)
+
+	return nil
+}
+
+// MLDatafeedStop permit to stop the datafeed
+func (h *ElasticsearchHandlerImpl) MLDatafeedStop(name string) (err error) {
+
+	res, err := h.client.API.ML.StopDatafeed(
+		name,
+		h.client.API.ML.StopDatafeed.WithContext(context.Background()),
+		h.client.API.ML.StopDatafeed.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when stop ML datafeed %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Stopped ML datafeed %s successfully", name)
+
+	return nil
+}
+
+// MLDatafeedStats permit to get the datafeed stats
+func (h *ElasticsearchHandlerImpl) MLDatafeedStats(name string) (stats *MLDatafeedStats, err error) {
+
+	res, err := h.client.API.ML.GetDatafeedStats(
+		h.client.API.ML.GetDatafeedStats.WithContext(context.Background()),
+		h.client.API.ML.GetDatafeedStats.WithPretty(),
+		h.client.API.ML.GetDatafeedStats.WithDatafeedID(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get ML datafeed stats %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get ML datafeed stats %s successfully:\n%s", name, string(b))
+
+	statsResp := &mlDatafeedStatsResponse{}
+	if err = json.Unmarshal(b, statsResp); err != nil {
+		return nil, err
+	}
+
+	if len(statsResp.Datafeeds) == 0 {
+		return nil, nil
+	}
+
+	return &statsResp.Datafeeds[0], nil
+}
+
+// MLDatafeedDiff permit to check if 2 datafeeds are the same
+// It ignore the default values added by Elasticsearch when they are not expected
+func (h *ElasticsearchHandlerImpl) MLDatafeedDiff(actual, expected *MLDatafeed) (diff string, err error) {
+	if actual != nil && expected != nil {
+		tmp := *actual
+		tmp.Query = keepExpectedFields(tmp.Query, expected.Query)
+		tmp.ChunkingConfig = keepExpectedFields(tmp.ChunkingConfig, expected.ChunkingConfig)
+		tmp.DelayedDataCheckConfig = keepExpectedFields(tmp.DelayedDataCheckConfig, expected.DelayedDataCheckConfig)
+		tmp.IndicesOptions = keepExpectedFields(tmp.IndicesOptions, expected.IndicesOptions)
+		if expected.Frequency == "" {
+			tmp.Frequency = ""
+		}
+		if expected.QueryDelay == "" {
+			tmp.QueryDelay = ""
+		}
+		if expected.ScrollSize == nil {
+			tmp.ScrollSize = nil
+		}
+		if expected.MaxEmptySearches == nil {
+			tmp.MaxEmptySearches = nil
+		}
+		actual = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
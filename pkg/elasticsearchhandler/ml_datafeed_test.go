@@ -0,0 +1,249 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlMLDatafeed = fmt.Sprintf("%s/_ml/datafeeds/test", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedGet() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"datafeeds": [
+			{
+				"datafeed_id": "test",
+				"job_id": "test",
+				"query_delay": "61535ms",
+				"indices": ["server-metrics"],
+				"query": {
+					"match_all": {
+						"boost": 1.0
+					}
+				},
+				"scroll_size": 1000,
+				"chunking_config": {
+					"mode": "auto"
+				},
+				"delayed_data_check_config": {
+					"enabled": true
+				}
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlMLDatafeed, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	datafeed, err := t.esHandler.MLDatafeedGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "test", datafeed.JobID)
+	assert.Equal(t.T(), []string{"server-metrics"}, datafeed.Indices)
+
+	// When datafeed not exist
+	httpmock.RegisterResponder("GET", urlMLDatafeed, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	datafeed, err = t.esHandler.MLDatafeedGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), datafeed)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlMLDatafeed, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.MLDatafeedGet("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedStats() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"datafeeds": [
+			{
+				"datafeed_id": "test",
+				"state": "started"
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlMLDatafeed), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	stats, err := t.esHandler.MLDatafeedStats("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "started", stats.State)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlMLDatafeed), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.MLDatafeedStats("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedCreate() {
+	datafeed := &MLDatafeed{
+		JobID:   "test",
+		Indices: []string{"server-metrics"},
+	}
+
+	httpmock.RegisterResponder("PUT", urlMLDatafeed, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"datafeed_id": "test"}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLDatafeedCreate("test", datafeed)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlMLDatafeed, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLDatafeedCreate("test", datafeed)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedUpdate() {
+	datafeed := &MLDatafeed{
+		JobID:   "test",
+		Indices: []string{"server-metrics"},
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlMLDatafeed), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"datafeed_id": "test"}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLDatafeedUpdate("test", datafeed)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// The job ID is removed only from the payload
+	assert.Equal(t.T(), "test", datafeed.JobID)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlMLDatafeed), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLDatafeedUpdate("test", datafeed)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedStartStop() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_start", urlMLDatafeed), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"started": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_stop", urlMLDatafeed), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"stopped": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLDatafeedStart("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	err = t.esHandler.MLDatafeedStop("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_start", urlMLDatafeed), httpmock.NewErrorResponder(errors.New("fack error")))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_stop", urlMLDatafeed), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLDatafeedStart("test")
+	assert.Error(t.T(), err)
+	err = t.esHandler.MLDatafeedStop("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlMLDatafeed, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLDatafeedDelete("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlMLDatafeed, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLDatafeedDelete("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLDatafeedDiff() {
+	var actual, expected *MLDatafeed
+
+	expected = &MLDatafeed{
+		JobID:   "test",
+		Indices: []string{"server-metrics"},
+	}
+
+	// When datafeed not exist yet
+	actual = nil
+	diff, err := t.esHandler.MLDatafeedDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When datafeed is the same, with default values set by Elasticsearch
+	scrollSize := int64(1000)
+	actual = &MLDatafeed{
+		JobID:      "test",
+		Indices:    []string{"server-metrics"},
+		QueryDelay: "61535ms",
+		Query: map[string]any{
+			"match_all": map[string]any{
+				"boost": 1.0,
+			},
+		},
+		ScrollSize: &scrollSize,
+		ChunkingConfig: map[string]any{
+			"mode": "auto",
+		},
+	}
+	diff, err = t.esHandler.MLDatafeedDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When datafeed is not the same
+	expected.Indices = []string{"server-metrics", "other"}
+	diff, err = t.esHandler.MLDatafeedDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -0,0 +1,344 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// MLJob is the anomaly detection job object
+type MLJob struct {
+	Description                          string         `json:"description,omitempty"`
+	Groups                               []string       `json:"groups,omitempty"`
+	AnalysisConfig                       map[string]any `json:"analysis_config,omitempty"`
+	AnalysisLimits                       map[string]any `json:"analysis_limits,omitempty"`
+	DataDescription                      map[string]any `json:"data_description,omitempty"`
+	ModelPlotConfig                      map[string]any `json:"model_plot_config,omitempty"`
+	ModelSnapshotRetentionDays           *int64         `json:"model_snapshot_retention_days,omitempty"`
+	DailyModelSnapshotRetentionAfterDays *int64         `json:"daily_model_snapshot_retention_after_days,omitempty"`
+	ResultsRetentionDays                 *int64         `json:"results_retention_days,omitempty"`
+	RenormalizationWindowDays            *int64         `json:"renormalization_window_days,omitempty"`
+	BackgroundPersistInterval            string         `json:"background_persist_interval,omitempty"`
+	ResultsIndexName                     string         `json:"results_index_name,omitempty"`
+	CustomSettings                       map[string]any `json:"custom_settings,omitempty"`
+	AllowLazyOpen                        bool           `json:"allow_lazy_open,omitempty"`
+}
+
+// MLJobStats is the job stats object returned by API
+type MLJobStats struct {
+	JobID                 string              `json:"job_id"`
+	State                 string              `json:"state"`
+	AssignmentExplanation string              `json:"assignment_explanation,omitempty"`
+	ModelSizeStats        MLJobModelSizeStats `json:"model_size_stats"`
+}
+
+// MLJobModelSizeStats is the model size stats sub section of job stats
+type MLJobModelSizeStats struct {
+	MemoryStatus string `json:"memory_status"`
+	ModelBytes   int64  `json:"model_bytes"`
+}
+
+// mlJobGetResponse is the response of get job API
+type mlJobGetResponse struct {
+	Count int64   `json:"count"`
+	Jobs  []MLJob `json:"jobs"`
+}
+
+// mlJobStatsResponse is the response of get job stats API
+type mlJobStatsResponse struct {
+	Count int64        `json:"count"`
+	Jobs  []MLJobStats `json:"jobs"`
+}
+
+// MLJobCreate permit to create new anomaly detection job
+func (h *ElasticsearchHandlerImpl) MLJobCreate(name string, job *MLJob) (err error) {
+
+	b, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.ML.PutJob(
+		name,
+		bytes.NewReader(b),
+		h.client.API.ML.PutJob.WithContext(context.Background()),
+		h.client.API.ML.PutJob.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add ML job %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// MLJobUpdate permit to update the anomaly detection job
+// Only the fields that can be updated are sent
+func (h *ElasticsearchHandlerImpl) MLJobUpdate(name string, job *MLJob) (err error) {
+
+	payload := *job
+	payload.AnalysisConfig = nil
+	payload.DataDescription = nil
+	payload.ResultsIndexName = ""
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.ML.UpdateJob(
+		name,
+		bytes.NewReader(b),
+		h.client.API.ML.UpdateJob.WithContext(context.Background()),
+		h.client.API.ML.UpdateJob.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update ML job %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// MLJobDelete permit to delete the anomaly detection job
+func (h *ElasticsearchHandlerImpl) MLJobDelete(name string) (err error) {
+
+	res, err := h.client.API.ML.DeleteJob(
+		name,
+		h.client.API.ML.DeleteJob.WithContext(context.Background()),
+		h.client.API.ML.DeleteJob.WithPretty(),
+		h.client.API.ML.DeleteJob.WithWaitForCompletion(true),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete ML job %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Deleted ML job %s successfully", name)
+
+	return nil
+}
+
+// MLJobGet permit to get the anomaly detection job
+func (h *ElasticsearchHandlerImpl) MLJobGet(name string) (job *MLJob, err error) {
+
+	res, err := h.client.API.ML.GetJobs(
+		h.client.API.ML.GetJobs.WithContext(context.Background()),
+		h.client.API.ML.GetJobs.WithPretty(),
+		h.client.API.ML.GetJobs.WithJobID(name),
+		h.client.API.ML.GetJobs.WithExcludeGenerated(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get ML job %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get ML job %s successfully:\n%s", name, string(b))
+
+	jobResp := &mlJobGetResponse{}
+	if err = json.Unmarshal(b, jobResp); err != nil {
+		return nil, err
+	}
+
+	if len(jobResp.Jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobResp.Jobs[0], nil
+}
+
+// MLJobOpen permit to open the anomaly detection job
+func (h *ElasticsearchHandlerImpl) MLJobOpen(name string) (err error) {
+
+	res, err := h.client.API.ML.OpenJob(
+		name,
+		h.client.API.ML.OpenJob.WithContext(context.Background()),
+		h.client.API.ML.OpenJob.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when open ML job %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Opened ML job %s successfully", name)
+
+	return nil
+}
+
+// MLJobClose permit to close the anomaly detection job
+// Elasticsearch stop the datafeed before close the job
+func (h *ElasticsearchHandlerImpl) MLJobClose(name string) (err error) {
+
+	res, err := h.client.API.ML.CloseJob(
+		name,
+		h.client.API.ML.CloseJob.WithContext(context.Background()),
+		h.client.API.ML.CloseJob.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when close ML job %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Closed ML job %s successfully", name)
+
+	return nil
+}
+
+// MLJobStats permit to get the anomaly detection job stats
+func (h *ElasticsearchHandlerImpl) MLJobStats(name string) (stats *MLJobStats, err error) {
+
+	res, err := h.client.API.ML.GetJobStats(
+		h.client.API.ML.GetJobStats.WithContext(context.Background()),
+		h.client.API.ML.GetJobStats.WithPretty(),
+		h.client.API.ML.GetJobStats.WithJobID(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get ML job stats %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get ML job stats %s successfully:\n%s", name, string(b))
+
+	statsResp := &mlJobStatsResponse{}
+	if err = json.Unmarshal(b, statsResp); err != nil {
+		return nil, err
+	}
+
+	if len(statsResp.Jobs) == 0 {
+		return nil, nil
+	}
+
+	return &statsResp.Jobs[0], nil
+}
+
+// MLJobDiff permit to check if 2 anomaly detection jobs are the same
+// It ignore the default values added by Elasticsearch when they are not expected
+func (h *ElasticsearchHandlerImpl) MLJobDiff(actual, expected *MLJob) (diff string, err error) {
+	if actual != nil && expected != nil {
+		tmp := *actual
+		tmp.AnalysisConfig = keepExpectedFields(tmp.AnalysisConfig, expected.AnalysisConfig)
+		tmp.AnalysisLimits = keepExpectedFields(tmp.AnalysisLimits, expected.AnalysisLimits)
+		tmp.DataDescription = keepExpectedFields(tmp.DataDescription, expected.DataDescription)
+		tmp.ModelPlotConfig = keepExpectedFields(tmp.ModelPlotConfig, expected.ModelPlotConfig)
+		if expected.ModelSnapshotRetentionDays == nil {
+			tmp.ModelSnapshotRetentionDays = nil
+		}
+		if expected.DailyModelSnapshotRetentionAfterDays == nil {
+			tmp.DailyModelSnapshotRetentionAfterDays = nil
+		}
+		if expected.ResultsRetentionDays == nil {
+			tmp.ResultsRetentionDays = nil
+		}
+		if expected.RenormalizationWindowDays == nil {
+			tmp.RenormalizationWindowDays = nil
+		}
+		if expected.BackgroundPersistInterval == "" {
+			tmp.BackgroundPersistInterval = ""
+		}
+		// Elasticsearch prefix the custom results index name
+		if expected.ResultsIndexName == "" || tmp.ResultsIndexName == "custom-"+expected.ResultsIndexName {
+			tmp.ResultsIndexName = expected.ResultsIndexName
+		}
+		actual = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
+
+// keepExpectedFields permit to remove from actual the fields that are not set on expected
+// It's needed because Elasticsearch add default values on ML objects
+func keepExpectedFields(actual, expected map[string]any) map[string]any {
+	if expected == nil {
+		return nil
+	}
+
+	return keepExpectedValue(actual, expected).(map[string]any)
+}
+
+func keepExpectedValue(actual, expected any) any {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			return actual
+		}
+		result := make(map[string]any, len(e))
+		for key, value := range e {
+			if v, ok := a[key]; ok {
+				result[key] = keepExpectedValue(v, value)
+			}
+		}
+		return result
+	case []any:
+		a, ok := actual.([]any)
+		if !ok || len(a) != len(e) {
+			return actual
+		}
+		result := make([]any, len(a))
+		for i := range a {
+			result[i] = keepExpectedValue(a[i], e[i])
+		}
+		return result
+	default:
+		return actual
+	}
+}
@@ -0,0 +1,297 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlMLJob = fmt.Sprintf("%s/_ml/anomaly_detectors/test", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobGet() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"jobs": [
+			{
+				"job_id": "test",
+				"job_type": "anomaly_detector",
+				"description": "Total sum of requests",
+				"analysis_config": {
+					"bucket_span": "10m",
+					"detectors": [
+						{
+							"detector_description": "Sum of total",
+							"function": "sum",
+							"field_name": "total",
+							"detector_index": 0
+						}
+					],
+					"influencers": [],
+					"model_prune_window": "30d"
+				},
+				"analysis_limits": {
+					"model_memory_limit": "1024mb",
+					"categorization_examples_limit": 4
+				},
+				"data_description": {
+					"time_field": "timestamp",
+					"time_format": "epoch_ms"
+				},
+				"model_snapshot_retention_days": 10,
+				"daily_model_snapshot_retention_after_days": 1,
+				"results_index_name": "shared",
+				"allow_lazy_open": false
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlMLJob, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	job, err := t.esHandler.MLJobGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "Total sum of requests", job.Description)
+	assert.Equal(t.T(), "10m", job.AnalysisConfig["bucket_span"])
+	assert.Equal(t.T(), int64(10), *job.ModelSnapshotRetentionDays)
+
+	// When job not exist
+	httpmock.RegisterResponder("GET", urlMLJob, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	job, err = t.esHandler.MLJobGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), job)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlMLJob, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.MLJobGet("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobStats() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"jobs": [
+			{
+				"job_id": "test",
+				"state": "opened",
+				"model_size_stats": {
+					"job_id": "test",
+					"result_type": "model_size_stats",
+					"model_bytes": 51722,
+					"memory_status": "ok"
+				}
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlMLJob), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	stats, err := t.esHandler.MLJobStats("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "opened", stats.State)
+	assert.Equal(t.T(), "ok", stats.ModelSizeStats.MemoryStatus)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlMLJob), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.MLJobStats("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobCreate() {
+	job := &MLJob{
+		AnalysisConfig: map[string]any{
+			"bucket_span": "10m",
+		},
+		DataDescription: map[string]any{
+			"time_field": "timestamp",
+		},
+	}
+
+	httpmock.RegisterResponder("PUT", urlMLJob, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"job_id": "test"}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLJobCreate("test", job)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlMLJob, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLJobCreate("test", job)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobUpdate() {
+	job := &MLJob{
+		Description: "test",
+		AnalysisConfig: map[string]any{
+			"bucket_span": "10m",
+		},
+		DataDescription: map[string]any{
+			"time_field": "timestamp",
+		},
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlMLJob), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"job_id": "test"}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLJobUpdate("test", job)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// The analysis config is removed only from the payload
+	assert.NotNil(t.T(), job.AnalysisConfig)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlMLJob), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLJobUpdate("test", job)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobOpenClose() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_open", urlMLJob), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"opened": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_close", urlMLJob), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"closed": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLJobOpen("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	err = t.esHandler.MLJobClose("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_open", urlMLJob), httpmock.NewErrorResponder(errors.New("fack error")))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_close", urlMLJob), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLJobOpen("test")
+	assert.Error(t.T(), err)
+	err = t.esHandler.MLJobClose("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlMLJob, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MLJobDelete("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlMLJob, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MLJobDelete("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMLJobDiff() {
+	var actual, expected *MLJob
+
+	expected = &MLJob{
+		AnalysisConfig: map[string]any{
+			"bucket_span": "10m",
+			"detectors": []any{
+				map[string]any{
+					"function":   "sum",
+					"field_name": "total",
+				},
+			},
+		},
+		DataDescription: map[string]any{
+			"time_field": "timestamp",
+		},
+		ResultsIndexName: "test",
+	}
+
+	// When job not exist yet
+	actual = nil
+	diff, err := t.esHandler.MLJobDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When job is the same, with default values set by Elasticsearch
+	retention := int64(10)
+	actual = &MLJob{
+		AnalysisConfig: map[string]any{
+			"bucket_span": "10m",
+			"detectors": []any{
+				map[string]any{
+					"function":       "sum",
+					"field_name":     "total",
+					"detector_index": 0,
+				},
+			},
+			"model_prune_window": "30d",
+		},
+		AnalysisLimits: map[string]any{
+			"model_memory_limit": "1024mb",
+		},
+		DataDescription: map[string]any{
+			"time_field":  "timestamp",
+			"time_format": "epoch_ms",
+		},
+		ModelSnapshotRetentionDays: &retention,
+		ResultsIndexName:           "custom-test",
+	}
+	diff, err = t.esHandler.MLJobDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When job is not the same
+	expected.Description = "test"
+	diff, err = t.esHandler.MLJobDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -0,0 +1,147 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// MountedIndex is the searchable snapshot mount object
+type MountedIndex struct {
+	Index               string         `json:"index"`
+	RenamedIndex        string         `json:"renamed_index,omitempty"`
+	IndexSettings       map[string]any `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string       `json:"ignore_index_settings,omitempty"`
+}
+
+// MountedIndexInfo is the snapshot source of mounted index read from index settings
+type MountedIndexInfo struct {
+	Repository string
+	Snapshot   string
+	Index      string
+	Storage    string
+}
+
+// mountedIndexSettingsResponse is the response of get index settings API with flat settings
+type mountedIndexSettingsResponse map[string]struct {
+	Settings map[string]string `json:"settings"`
+}
+
+// MountedIndexCreate permit to mount index from snapshot as searchable snapshot
+// It not wait the shards are recovered
+func (h *ElasticsearchHandlerImpl) MountedIndexCreate(repository, snapshot, storage string, mount *MountedIndex) (err error) {
+
+	b, err := json.Marshal(mount)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.SearchableSnapshotsMount(
+		repository,
+		snapshot,
+		bytes.NewReader(b),
+		h.client.API.SearchableSnapshotsMount.WithContext(context.Background()),
+		h.client.API.SearchableSnapshotsMount.WithPretty(),
+		h.client.API.SearchableSnapshotsMount.WithStorage(storage),
+		h.client.API.SearchableSnapshotsMount.WithWaitForCompletion(false),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when mount index %s from snapshot %s/%s: %s\ndata: %s", mount.Index, repository, snapshot, res.String(), string(b))
+	}
+
+	h.log.Infof("Mounted index %s from snapshot %s/%s successfully", mount.Index, repository, snapshot)
+
+	return nil
+}
+
+// MountedIndexDelete permit to unmount the index
+// The snapshot is kept on repository
+func (h *ElasticsearchHandlerImpl) MountedIndexDelete(name string) (err error) {
+
+	res, err := h.client.API.Indices.Delete(
+		[]string{name},
+		h.client.API.Indices.Delete.WithContext(context.Background()),
+		h.client.API.Indices.Delete.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when unmount index %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Unmounted index %s successfully", name)
+
+	return nil
+}
+
+// MountedIndexGet permit to get the snapshot source of the mounted index
+// It return nil if index not exist, and error if index exist but it's not a searchable snapshot
+func (h *ElasticsearchHandlerImpl) MountedIndexGet(name string) (mount *MountedIndexInfo, err error) {
+
+	res, err := h.client.API.Indices.GetSettings(
+		h.client.API.Indices.GetSettings.WithContext(context.Background()),
+		h.client.API.Indices.GetSettings.WithPretty(),
+		h.client.API.Indices.GetSettings.WithIndex(name),
+		h.client.API.Indices.GetSettings.WithName("index.store.*"),
+		h.client.API.Indices.GetSettings.WithFlatSettings(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get mounted index %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get mounted index %s successfully:\n%s", name, string(b))
+
+	settingsResp := make(mountedIndexSettingsResponse)
+	if err = json.Unmarshal(b, &settingsResp); err != nil {
+		return nil, err
+	}
+
+	tmp, ok := settingsResp[name]
+	if !ok {
+		return nil, nil
+	}
+	if tmp.Settings["index.store.type"] != "snapshot" {
+		return nil, errors.Errorf("Index %s already exist and it's not a searchable snapshot", name)
+	}
+
+	mount = &MountedIndexInfo{
+		Repository: tmp.Settings["index.store.snapshot.repository_name"],
+		Snapshot:   tmp.Settings["index.store.snapshot.snapshot_name"],
+		Index:      tmp.Settings["index.store.snapshot.index_name"],
+		Storage:    "full_copy",
+	}
+	if tmp.Settings["index.store.snapshot.partial"] == "true" {
+		mount.Storage = "shared_cache"
+	}
+
+	return mount, nil
+}
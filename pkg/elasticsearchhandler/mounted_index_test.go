@@ -0,0 +1,116 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlMountedIndex = fmt.Sprintf("%s/audit-2020", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestMountedIndexGet() {
+
+	rawResp := `
+	{
+		"audit-2020": {
+			"settings": {
+				"index.store.type": "snapshot",
+				"index.store.snapshot.repository_name": "backup",
+				"index.store.snapshot.snapshot_name": "audit",
+				"index.store.snapshot.index_name": "audit-2020.01",
+				"index.store.snapshot.index_uuid": "UqnOHz8DQ8-gDlfOz1kFjQ",
+				"index.store.snapshot.partial": "true"
+			}
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_settings/index.store.*", urlMountedIndex), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	mount, err := t.esHandler.MountedIndexGet("audit-2020")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "backup", mount.Repository)
+	assert.Equal(t.T(), "audit", mount.Snapshot)
+	assert.Equal(t.T(), "audit-2020.01", mount.Index)
+	assert.Equal(t.T(), "shared_cache", mount.Storage)
+
+	// When index is not a searchable snapshot
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_settings/index.store.*", urlMountedIndex), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"audit-2020": {"settings": {}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	_, err = t.esHandler.MountedIndexGet("audit-2020")
+	assert.Error(t.T(), err)
+
+	// When index not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_settings/index.store.*", urlMountedIndex), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	mount, err = t.esHandler.MountedIndexGet("audit-2020")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), mount)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_settings/index.store.*", urlMountedIndex), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.MountedIndexGet("audit-2020")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMountedIndexCreate() {
+	mount := &MountedIndex{
+		Index:        "audit-2020.01",
+		RenamedIndex: "audit-2020",
+		IndexSettings: map[string]any{
+			"index.number_of_replicas": 0,
+		},
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_snapshot/backup/audit/_mount", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"accepted": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MountedIndexCreate("backup", "audit", "shared_cache", mount)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_snapshot/backup/audit/_mount", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MountedIndexCreate("backup", "audit", "shared_cache", mount)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestMountedIndexDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlMountedIndex, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.MountedIndexDelete("audit-2020")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlMountedIndex, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.MountedIndexDelete("audit-2020")
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,110 @@
+package elasticsearchhandler
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// IndexRecovery is the recovery of one index
+type IndexRecovery struct {
+	Shards []IndexRecoveryShard `json:"shards"`
+}
+
+// IndexRecoveryShard is the recovery of one shard
+type IndexRecoveryShard struct {
+	ID      int64                `json:"id"`
+	Type    string               `json:"type"`
+	Stage   string               `json:"stage"`
+	Primary bool                 `json:"primary"`
+	Source  *IndexRecoverySource `json:"source,omitempty"`
+}
+
+// IndexRecoverySource is the source of the shard recovery
+// Repository and snapshot are only set when the shard is recovered from snapshot
+type IndexRecoverySource struct {
+	Repository string `json:"repository,omitempty"`
+	Snapshot   string `json:"snapshot,omitempty"`
+	Index      string `json:"index,omitempty"`
+}
+
+// IndexRecoveryGet permit to get the shards recovery of indices
+// It return the recovery for all indices when none is provided
+func (h *ElasticsearchHandlerImpl) IndexRecoveryGet(indices ...string) (recovery map[string]IndexRecovery, err error) {
+
+	res, err := h.client.API.Indices.Recovery(
+		h.client.API.Indices.Recovery.WithContext(context.Background()),
+		h.client.API.Indices.Recovery.WithPretty(),
+		h.client.API.Indices.Recovery.WithIndex(indices...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get recovery of indices %v: %s", indices, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get recovery of indices %v successfully:\n%s", indices, string(b))
+
+	recovery = make(map[string]IndexRecovery)
+	if err = json.Unmarshal(b, &recovery); err != nil {
+		return nil, err
+	}
+
+	return recovery, nil
+}
+
+// IndexShard is the allocation state of one shard
+type IndexShard struct {
+	Index            string `json:"index"`
+	Shard            string `json:"shard"`
+	PriRep           string `json:"prirep"`
+	State            string `json:"state"`
+	UnassignedReason string `json:"unassigned.reason,omitempty"`
+}
+
+// IndexShardsGet permit to get the allocation state of shards of indices
+// It return the shards for all indices when none is provided
+func (h *ElasticsearchHandlerImpl) IndexShardsGet(indices ...string) (shards []IndexShard, err error) {
+
+	res, err := h.client.API.Cat.Shards(
+		h.client.API.Cat.Shards.WithContext(context.Background()),
+		h.client.API.Cat.Shards.WithPretty(),
+		h.client.API.Cat.Shards.WithIndex(indices...),
+		h.client.API.Cat.Shards.WithFormat("json"),
+		h.client.API.Cat.Shards.WithH("index", "shard", "prirep", "state", "unassigned.reason"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get shards of indices %v: %s", indices, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get shards of indices %v successfully:\n%s", indices, string(b))
+
+	shards = make([]IndexShard, 0)
+	if err = json.Unmarshal(b, &shards); err != nil {
+		return nil, err
+	}
+
+	return shards, nil
+}
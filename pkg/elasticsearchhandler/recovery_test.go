@@ -0,0 +1,141 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *ElasticsearchHandlerTestSuite) TestIndexRecoveryGet() {
+
+	rawResp := `
+	{
+		"restored-index1": {
+			"shards": [
+				{
+					"id": 0,
+					"type": "SNAPSHOT",
+					"stage": "DONE",
+					"primary": true,
+					"start_time_in_millis": 1652342400000,
+					"stop_time_in_millis": 1652342460000,
+					"total_time_in_millis": 60000,
+					"source": {
+						"repository": "backup",
+						"snapshot": "test",
+						"version": "8.1.0",
+						"index": "index1",
+						"restoreUUID": "PDh1ZAOaRbiGIVtCvZOMww"
+					},
+					"target": {
+						"id": "ryqJ5lO5S4-lSFbGntkEkg",
+						"host": "my.fqdn",
+						"transport_address": "my.fqdn",
+						"ip": "10.0.1.7",
+						"name": "my_es_node"
+					}
+				},
+				{
+					"id": 1,
+					"type": "SNAPSHOT",
+					"stage": "INDEX",
+					"primary": true,
+					"source": {
+						"repository": "backup",
+						"snapshot": "test",
+						"version": "8.1.0",
+						"index": "index1"
+					}
+				}
+			]
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_recovery", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	recovery, err := t.esHandler.IndexRecoveryGet()
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), 2, len(recovery["restored-index1"].Shards))
+	assert.Equal(t.T(), "DONE", recovery["restored-index1"].Shards[0].Stage)
+	assert.Equal(t.T(), "test", recovery["restored-index1"].Shards[0].Source.Snapshot)
+
+	// When index not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/restored-index1/_recovery", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	recovery, err = t.esHandler.IndexRecoveryGet("restored-index1")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), recovery)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_recovery", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.IndexRecoveryGet()
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestIndexShardsGet() {
+
+	rawResp := `
+	[
+		{
+			"index": "restored-index1",
+			"shard": "0",
+			"prirep": "p",
+			"state": "STARTED",
+			"unassigned.reason": null
+		},
+		{
+			"index": "restored-index1",
+			"shard": "1",
+			"prirep": "p",
+			"state": "UNASSIGNED",
+			"unassigned.reason": "ALLOCATION_FAILED"
+		}
+	]
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_cat/shards/restored-index1", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	shards, err := t.esHandler.IndexShardsGet("restored-index1")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), 2, len(shards))
+	assert.Equal(t.T(), "STARTED", shards[0].State)
+	assert.Equal(t.T(), "ALLOCATION_FAILED", shards[1].UnassignedReason)
+
+	// When index not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_cat/shards/restored-index1", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	shards, err = t.esHandler.IndexShardsGet("restored-index1")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), shards)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_cat/shards/restored-index1", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.IndexShardsGet("restored-index1")
+	assert.Error(t.T(), err)
+}
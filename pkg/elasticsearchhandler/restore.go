@@ -0,0 +1,56 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// Restore is the restore object used to restore snapshot
+type Restore struct {
+	Indices             []string       `json:"indices,omitempty"`
+	IgnoreUnavailable   bool           `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState  *bool          `json:"include_global_state,omitempty"`
+	FeatureStates       []string       `json:"feature_states,omitempty"`
+	IncludeAliases      *bool          `json:"include_aliases,omitempty"`
+	Partial             bool           `json:"partial,omitempty"`
+	RenamePattern       string         `json:"rename_pattern,omitempty"`
+	RenameReplacement   string         `json:"rename_replacement,omitempty"`
+	IndexSettings       map[string]any `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string       `json:"ignore_index_settings,omitempty"`
+}
+
+// RestoreStart permit to start the restore of snapshot
+// It not wait the restore is completed
+func (h *ElasticsearchHandlerImpl) RestoreStart(repository, snapshot string, restore *Restore) (err error) {
+
+	b, err := json.Marshal(restore)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.Snapshot.Restore(
+		repository,
+		snapshot,
+		h.client.API.Snapshot.Restore.WithBody(bytes.NewReader(b)),
+		h.client.API.Snapshot.Restore.WithContext(context.Background()),
+		h.client.API.Snapshot.Restore.WithPretty(),
+		h.client.API.Snapshot.Restore.WithWaitForCompletion(false),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when restore snapshot %s/%s: %s\ndata: %s", repository, snapshot, res.String(), string(b))
+	}
+
+	h.log.Infof("Started restore of snapshot %s/%s successfully", repository, snapshot)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *ElasticsearchHandlerTestSuite) TestRestoreStart() {
+	restore := &Restore{
+		Indices:           []string{"index1"},
+		RenamePattern:     "(.+)",
+		RenameReplacement: "restored-$1",
+		IndexSettings: map[string]any{
+			"index.number_of_replicas": 0,
+		},
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_snapshot/backup/test/_restore", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"accepted": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.RestoreStart("backup", "test", restore)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_snapshot/backup/test/_restore", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.RestoreStart("backup", "test", restore)
+	assert.Error(t.T(), err)
+}
@@ -6,12 +6,52 @@ import (
 	"encoding/json"
 	"io/ioutil"
 
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/pkg/errors"
 )
 
+// XPackSecurityRoleMapping is the role mapping object
+// Some fix not provided by olivere, like role templates
+type XPackSecurityRoleMapping struct {
+	Enabled       bool                        `json:"enabled"`
+	Roles         []string                    `json:"roles,omitempty"`
+	RoleTemplates []XPackSecurityRoleTemplate `json:"role_templates,omitempty"`
+	Rules         map[string]any              `json:"rules"`
+	Metadata      any                         `json:"metadata"`
+}
+
+// XPackSecurityRoleTemplate is the mustache template that derive roles from user attributes
+type XPackSecurityRoleTemplate struct {
+	Template map[string]any `json:"template"`
+	Format   string         `json:"format,omitempty"`
+}
+
+// UnmarshalJSON permit to decode the template returned by Elasticsearch as JSON string
+func (h *XPackSecurityRoleTemplate) UnmarshalJSON(data []byte) (err error) {
+	raw := struct {
+		Template json.RawMessage `json:"template"`
+		Format   string          `json:"format,omitempty"`
+	}{}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	h.Format = raw.Format
+	h.Template = nil
+
+	if len(raw.Template) == 0 || string(raw.Template) == "null" {
+		return nil
+	}
+
+	// Elasticsearch return the template as JSON string
+	var templateStr string
+	if err = json.Unmarshal(raw.Template, &templateStr); err == nil {
+		raw.Template = json.RawMessage(templateStr)
+	}
+
+	return json.Unmarshal(raw.Template, &h.Template)
+}
+
 // RoleMappingUpdate permit to create or update role mapping
-func (h *ElasticsearchHandlerImpl) RoleMappingUpdate(name string, roleMapping *olivere.XPackSecurityRoleMapping) (err error) {
+func (h *ElasticsearchHandlerImpl) RoleMappingUpdate(name string, roleMapping *XPackSecurityRoleMapping) (err error) {
 
 	data, err := json.Marshal(roleMapping)
 	if err != nil {
@@ -67,7 +107,7 @@ func (h *ElasticsearchHandlerImpl) RoleMappingDelete(name string) (err error) {
 }
 
 // RoleMappingGet permit to get role mapping
-func (h *ElasticsearchHandlerImpl) RoleMappingGet(name string) (roleMapping *olivere.XPackSecurityRoleMapping, err error) {
+func (h *ElasticsearchHandlerImpl) RoleMappingGet(name string) (roleMapping *XPackSecurityRoleMapping, err error) {
 
 	res, err := h.client.API.Security.GetRoleMapping(
 		h.client.API.Security.GetRoleMapping.WithContext(context.Background()),
@@ -91,7 +131,7 @@ func (h *ElasticsearchHandlerImpl) RoleMappingGet(name string) (roleMapping *oli
 	}
 
 	h.log.Debugf("Get role mapping %s successfully:\n%s", name, string(b))
-	roleMappingResp := make(olivere.XPackSecurityGetRoleMappingResponse)
+	roleMappingResp := make(map[string]XPackSecurityRoleMapping)
 	err = json.Unmarshal(b, &roleMappingResp)
 	if err != nil {
 		return nil, err
@@ -104,6 +144,6 @@ func (h *ElasticsearchHandlerImpl) RoleMappingGet(name string) (roleMapping *oli
 }
 
 // RoleMappingDiff permit to check if 2 role mapping are the same
-func (h *ElasticsearchHandlerImpl) RoleMappingDiff(actual, expected *olivere.XPackSecurityRoleMapping) (diff string, err error) {
+func (h *ElasticsearchHandlerImpl) RoleMappingDiff(actual, expected *XPackSecurityRoleMapping) (diff string, err error) {
 	return standartDiff(actual, expected, h.log, nil)
 }
@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/jarcoal/httpmock"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,8 +13,8 @@ var urlRoleMapping = fmt.Sprintf("%s/_security/role_mapping/test", baseURL)
 
 func (t *ElasticsearchHandlerTestSuite) TestRoleMappingGet() {
 
-	result := make(olivere.XPackSecurityGetRoleMappingResponse)
-	roleMapping := &olivere.XPackSecurityRoleMapping{
+	result := make(map[string]XPackSecurityRoleMapping)
+	roleMapping := &XPackSecurityRoleMapping{
 		Enabled: true,
 		Roles:   []string{"superuser"},
 		Rules: map[string]any{
@@ -41,6 +40,25 @@ func (t *ElasticsearchHandlerTestSuite) TestRoleMappingGet() {
 	}
 	assert.Equal(t.T(), roleMapping, resp)
 
+	// When role templates is returned as JSON string
+	httpmock.RegisterResponder("GET", urlRoleMapping, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"test": {"enabled": true, "roles": [], "role_templates": [{"template": "{\"source\":\"{{#tojson}}groups{{/tojson}}\"}", "format": "json"}], "rules": {"field": {"realm.name": "saml1"}}, "metadata": {}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	resp, err = t.esHandler.RoleMappingGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), []XPackSecurityRoleTemplate{
+		{
+			Template: map[string]any{
+				"source": "{{#tojson}}groups{{/tojson}}",
+			},
+			Format: "json",
+		},
+	}, resp.RoleTemplates)
+
 	// When error
 	httpmock.RegisterResponder("GET", urlRoleMapping, httpmock.NewErrorResponder(errors.New("fack error")))
 	resp, err = t.esHandler.RoleMappingGet("test")
@@ -67,7 +85,7 @@ func (t *ElasticsearchHandlerTestSuite) TestRoleMappingDelete() {
 }
 
 func (t *ElasticsearchHandlerTestSuite) TestRoleMappingUpdate() {
-	roleMapping := &olivere.XPackSecurityRoleMapping{
+	roleMapping := &XPackSecurityRoleMapping{
 		Enabled: true,
 		Roles:   []string{"superuser"},
 		Rules: map[string]any{
@@ -95,9 +113,9 @@ func (t *ElasticsearchHandlerTestSuite) TestRoleMappingUpdate() {
 }
 
 func (t *ElasticsearchHandlerTestSuite) TestRoleMappingDiff() {
-	var actual, expected *olivere.XPackSecurityRoleMapping
+	var actual, expected *XPackSecurityRoleMapping
 
-	expected = &olivere.XPackSecurityRoleMapping{
+	expected = &XPackSecurityRoleMapping{
 		Enabled: true,
 		Roles:   []string{"superuser"},
 		Rules: map[string]any{
@@ -116,7 +134,7 @@ func (t *ElasticsearchHandlerTestSuite) TestRoleMappingDiff() {
 	assert.NotEmpty(t.T(), diff)
 
 	// When role mapping is the same
-	actual = &olivere.XPackSecurityRoleMapping{
+	actual = &XPackSecurityRoleMapping{
 		Enabled: true,
 		Roles:   []string{"superuser"},
 		Rules: map[string]any{
@@ -44,6 +44,34 @@ type SnapshotLifecyclePolicyGet struct {
 	Policy *SnapshotLifecyclePolicySpec `json:"policy"`
 }
 
+// SnapshotLifecyclePolicyStatus is the execution status of snapshot lifecycle policy
+type SnapshotLifecyclePolicyStatus struct {
+	LastSuccess         *SnapshotLifecyclePolicyInvocation `json:"last_success,omitempty"`
+	LastFailure         *SnapshotLifecyclePolicyInvocation `json:"last_failure,omitempty"`
+	NextExecutionMillis int64                              `json:"next_execution_millis,omitempty"`
+	Stats               SnapshotLifecyclePolicyStats       `json:"stats"`
+}
+
+// SnapshotLifecyclePolicyInvocation is the last success or last failure of snapshot lifecycle policy
+type SnapshotLifecyclePolicyInvocation struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+	Details      string `json:"details,omitempty"`
+}
+
+// SnapshotLifecyclePolicyStats is the snapshot stats of snapshot lifecycle policy
+type SnapshotLifecyclePolicyStats struct {
+	SnapshotsTaken           int64 `json:"snapshots_taken"`
+	SnapshotsFailed          int64 `json:"snapshots_failed"`
+	SnapshotsDeleted         int64 `json:"snapshots_deleted"`
+	SnapshotDeletionFailures int64 `json:"snapshot_deletion_failures"`
+}
+
+// snapshotLifecyclePolicyExecuteResponse is the response of execute snapshot lifecycle policy API
+type snapshotLifecyclePolicyExecuteResponse struct {
+	SnapshotName string `json:"snapshot_name"`
+}
+
 // SLMUpdate permit to add or update SLM policy
 func (h *ElasticsearchHandlerImpl) SLMUpdate(name string, policy *SnapshotLifecyclePolicySpec) (err error) {
 
@@ -142,3 +170,87 @@ func (h *ElasticsearchHandlerImpl) SLMGet(name string) (policy *SnapshotLifecycl
 func (h *ElasticsearchHandlerImpl) SLMDiff(actual, expected *SnapshotLifecyclePolicySpec) (diffStr string, err error) {
 	return standartDiff(actual, expected, h.log, nil)
 }
+
+// SLMGetStatus permit to get the execution status of SLM policy
+// It return nil if policy not exist
+func (h *ElasticsearchHandlerImpl) SLMGetStatus(name string) (status *SnapshotLifecyclePolicyStatus, err error) {
+
+	res, err := h.client.API.SlmGetLifecycle(
+		h.client.API.SlmGetLifecycle.WithContext(context.Background()),
+		h.client.API.SlmGetLifecycle.WithPretty(),
+		h.client.API.SlmGetLifecycle.WithPolicyID(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get snapshot lifecycle policy %s: %s", name, res.String())
+
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	statusResp := make(map[string]*SnapshotLifecyclePolicyStatus)
+	if err = json.Unmarshal(b, &statusResp); err != nil {
+		return nil, err
+	}
+
+	return statusResp[name], nil
+}
+
+// SLMExecute permit to take a snapshot immediately with SLM policy
+// It return the name of snapshot in progress
+func (h *ElasticsearchHandlerImpl) SLMExecute(name string) (snapshotName string, err error) {
+
+	res, err := h.client.API.SlmExecuteLifecycle(
+		name,
+		h.client.API.SlmExecuteLifecycle.WithContext(context.Background()),
+		h.client.API.SlmExecuteLifecycle.WithPretty(),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return "", errors.Errorf("Error when execute snapshot lifecycle policy %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	executeResp := &snapshotLifecyclePolicyExecuteResponse{}
+	if err = json.Unmarshal(b, executeResp); err != nil {
+		return "", err
+	}
+
+	h.log.Infof("Execute snapshot lifecycle policy %s successfully: %s", name, executeResp.SnapshotName)
+
+	return executeResp.SnapshotName, nil
+}
+
+// SLMExecuteRetention permit to delete immediately the snapshots that are expired by SLM policies retention
+func (h *ElasticsearchHandlerImpl) SLMExecuteRetention() (err error) {
+
+	res, err := h.client.API.SlmExecuteRetention(
+		h.client.API.SlmExecuteRetention.WithContext(context.Background()),
+		h.client.API.SlmExecuteRetention.WithPretty(),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return errors.Errorf("Error when execute snapshot lifecycle retention: %s", res.String())
+	}
+
+	h.log.Info("Execute snapshot lifecycle retention successfully")
+
+	return nil
+}
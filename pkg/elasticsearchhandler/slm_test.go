@@ -167,3 +167,109 @@ func (t *ElasticsearchHandlerTestSuite) TestSLMDiff() {
 	assert.NotEmpty(t.T(), diff)
 
 }
+
+func (t *ElasticsearchHandlerTestSuite) TestSLMGetStatus() {
+
+	rawResp := `
+{
+	"test": {
+		"version": 1,
+		"modified_date_millis": 1653056400000,
+		"policy": {
+			"name": "<daily-snap-{now/d}>",
+			"schedule": "0 30 1 * * ?",
+			"repository": "my_repository"
+		},
+		"last_success": {
+			"snapshot_name": "daily-snap-2022.05.20-abc",
+			"start_time": 1653096600000,
+			"time": 1653096610000
+		},
+		"last_failure": {
+			"snapshot_name": "daily-snap-2022.05.19-abc",
+			"time": 1653010210000,
+			"details": "{\"type\":\"repository_exception\",\"reason\":\"[my_repository] missing\"}"
+		},
+		"next_execution_millis": 1653183000000,
+		"stats": {
+			"policy": "test",
+			"snapshots_taken": 10,
+			"snapshots_failed": 1,
+			"snapshots_deleted": 3,
+			"snapshot_deletion_failures": 0
+		}
+	}
+}
+	`
+
+	httpmock.RegisterResponder("GET", urlSLM, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	status, err := t.esHandler.SLMGetStatus("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "daily-snap-2022.05.20-abc", status.LastSuccess.SnapshotName)
+	assert.Equal(t.T(), int64(1653010210000), status.LastFailure.Time)
+	assert.Equal(t.T(), int64(1653183000000), status.NextExecutionMillis)
+	assert.Equal(t.T(), int64(10), status.Stats.SnapshotsTaken)
+
+	// When policy not exist
+	httpmock.RegisterResponder("GET", urlSLM, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, `{}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	status, err = t.esHandler.SLMGetStatus("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), status)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlSLM, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SLMGetStatus("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSLMExecute() {
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_execute", urlSLM), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"snapshot_name": "daily-snap-2022.05.20-abc"}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	snapshotName, err := t.esHandler.SLMExecute("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "daily-snap-2022.05.20-abc", snapshotName)
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_execute", urlSLM), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SLMExecute("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSLMExecuteRetention() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_slm/_execute_retention", baseURL), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.SLMExecuteRetention()
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_slm/_execute_retention", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.SLMExecuteRetention()
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,229 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// Snapshot is the snapshot object used to create snapshot
+type Snapshot struct {
+	Indices            []string       `json:"indices,omitempty"`
+	IgnoreUnavailable  bool           `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState *bool          `json:"include_global_state,omitempty"`
+	FeatureStates      []string       `json:"feature_states,omitempty"`
+	Partial            bool           `json:"partial,omitempty"`
+	Metadata           map[string]any `json:"metadata,omitempty"`
+}
+
+// SnapshotInfo is the snapshot object returned by get snapshot API
+type SnapshotInfo struct {
+	Snapshot string                 `json:"snapshot"`
+	UUID     string                 `json:"uuid"`
+	State    string                 `json:"state"`
+	Indices  []string               `json:"indices,omitempty"`
+	Failures []SnapshotShardFailure `json:"failures,omitempty"`
+	Shards   SnapshotInfoShards     `json:"shards"`
+}
+
+// SnapshotShardFailure is a shard failure on snapshot
+type SnapshotShardFailure struct {
+	Index   string `json:"index"`
+	ShardID int64  `json:"shard_id"`
+	Reason  string `json:"reason"`
+	Status  string `json:"status"`
+}
+
+// SnapshotInfoShards is the shards sub section of snapshot info
+type SnapshotInfoShards struct {
+	Total      int64 `json:"total"`
+	Failed     int64 `json:"failed"`
+	Successful int64 `json:"successful"`
+}
+
+// SnapshotStatus is the snapshot status object returned by API
+type SnapshotStatus struct {
+	Snapshot    string                         `json:"snapshot"`
+	Repository  string                         `json:"repository"`
+	State       string                         `json:"state"`
+	ShardsStats SnapshotShardsStats            `json:"shards_stats"`
+	Stats       SnapshotStats                  `json:"stats"`
+	Indices     map[string]SnapshotIndexStatus `json:"indices,omitempty"`
+}
+
+// SnapshotIndexStatus is the status of one index on snapshot status
+type SnapshotIndexStatus struct {
+	ShardsStats SnapshotShardsStats `json:"shards_stats"`
+}
+
+// SnapshotShardsStats is the shards stats sub section of snapshot status
+type SnapshotShardsStats struct {
+	Initializing int64 `json:"initializing"`
+	Started      int64 `json:"started"`
+	Finalizing   int64 `json:"finalizing"`
+	Done         int64 `json:"done"`
+	Failed       int64 `json:"failed"`
+	Total        int64 `json:"total"`
+}
+
+// SnapshotStats is the stats sub section of snapshot status
+type SnapshotStats struct {
+	Processed SnapshotFileStats `json:"processed"`
+	Total     SnapshotFileStats `json:"total"`
+}
+
+// SnapshotFileStats is the files stats
+type SnapshotFileStats struct {
+	FileCount   int64 `json:"file_count"`
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+// snapshotGetResponse is the response of get snapshot API
+type snapshotGetResponse struct {
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+// snapshotStatusResponse is the response of snapshot status API
+type snapshotStatusResponse struct {
+	Snapshots []SnapshotStatus `json:"snapshots"`
+}
+
+// SnapshotCreate permit to create new snapshot
+// It not wait the snapshot is completed
+func (h *ElasticsearchHandlerImpl) SnapshotCreate(repository, name string, snapshot *Snapshot) (err error) {
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.Snapshot.Create(
+		repository,
+		name,
+		h.client.API.Snapshot.Create.WithBody(bytes.NewReader(b)),
+		h.client.API.Snapshot.Create.WithContext(context.Background()),
+		h.client.API.Snapshot.Create.WithPretty(),
+		h.client.API.Snapshot.Create.WithWaitForCompletion(false),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add snapshot %s/%s: %s", repository, name, res.String())
+	}
+
+	h.log.Infof("Started snapshot %s/%s successfully", repository, name)
+
+	return nil
+}
+
+// SnapshotDelete permit to delete the snapshot
+func (h *ElasticsearchHandlerImpl) SnapshotDelete(repository, name string) (err error) {
+
+	res, err := h.client.API.Snapshot.Delete(
+		repository,
+		[]string{name},
+		h.client.API.Snapshot.Delete.WithContext(context.Background()),
+		h.client.API.Snapshot.Delete.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete snapshot %s/%s: %s", repository, name, res.String())
+	}
+
+	h.log.Infof("Deleted snapshot %s/%s successfully", repository, name)
+
+	return nil
+}
+
+// SnapshotGet permit to get the snapshot
+func (h *ElasticsearchHandlerImpl) SnapshotGet(repository, name string) (snapshot *SnapshotInfo, err error) {
+
+	res, err := h.client.API.Snapshot.Get(
+		repository,
+		[]string{name},
+		h.client.API.Snapshot.Get.WithContext(context.Background()),
+		h.client.API.Snapshot.Get.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get snapshot %s/%s: %s", repository, name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get snapshot %s/%s successfully:\n%s", repository, name, string(b))
+
+	snapshotResp := &snapshotGetResponse{}
+	if err = json.Unmarshal(b, snapshotResp); err != nil {
+		return nil, err
+	}
+
+	if len(snapshotResp.Snapshots) == 0 {
+		return nil, nil
+	}
+
+	return &snapshotResp.Snapshots[0], nil
+}
+
+// SnapshotStatus permit to get the detailed status of the snapshot
+func (h *ElasticsearchHandlerImpl) SnapshotStatus(repository, name string) (status *SnapshotStatus, err error) {
+
+	res, err := h.client.API.Snapshot.Status(
+		h.client.API.Snapshot.Status.WithContext(context.Background()),
+		h.client.API.Snapshot.Status.WithPretty(),
+		h.client.API.Snapshot.Status.WithRepository(repository),
+		h.client.API.Snapshot.Status.WithSnapshot(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get snapshot status %s/%s: %s", repository, name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get snapshot status %s/%s successfully:\n%s", repository, name, string(b))
+
+	statusResp := &snapshotStatusResponse{}
+	if err = json.Unmarshal(b, statusResp); err != nil {
+		return nil, err
+	}
+
+	if len(statusResp.Snapshots) == 0 {
+		return nil, nil
+	}
+
+	return &statusResp.Snapshots[0], nil
+}
@@ -5,11 +5,30 @@ import (
 	"context"
 	"encoding/json"
 	"io/ioutil"
+	"sort"
 
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/pkg/errors"
 )
 
+// SnapshotRepositoryCleanupResult is the result of repository cleanup
+type SnapshotRepositoryCleanupResult struct {
+	DeletedBytes int64 `json:"deleted_bytes"`
+	DeletedBlobs int64 `json:"deleted_blobs"`
+}
+
+// snapshotRepositoryVerifyResponse is the response of verify repository API
+type snapshotRepositoryVerifyResponse struct {
+	Nodes map[string]struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+// snapshotRepositoryCleanupResponse is the response of cleanup repository API
+type snapshotRepositoryCleanupResponse struct {
+	Results SnapshotRepositoryCleanupResult `json:"results"`
+}
+
 // SnapshotRepositoryUpdate permit to create or update snapshot repository
 func (h *ElasticsearchHandlerImpl) SnapshotRepositoryUpdate(name string, repository *olivere.SnapshotRepositoryMetaData) (err error) {
 
@@ -104,3 +123,70 @@ func (h *ElasticsearchHandlerImpl) SnapshotRepositoryGet(name string) (repositor
 func (h *ElasticsearchHandlerImpl) SnapshotRepositoryDiff(actual, expected *olivere.SnapshotRepositoryMetaData) (diffStr string, err error) {
 	return standartDiff(actual, expected, h.log, nil)
 }
+
+// SnapshotRepositoryVerify permit to check that repository is functional on all master and data nodes
+// It return the name of nodes that verified the repository
+func (h *ElasticsearchHandlerImpl) SnapshotRepositoryVerify(name string) (nodes []string, err error) {
+
+	res, err := h.client.API.Snapshot.VerifyRepository(
+		name,
+		h.client.API.Snapshot.VerifyRepository.WithContext(context.Background()),
+		h.client.API.Snapshot.VerifyRepository.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, errors.Errorf("Error when verify snapshot repository %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	verifyResp := &snapshotRepositoryVerifyResponse{}
+	if err = json.Unmarshal(b, verifyResp); err != nil {
+		return nil, err
+	}
+
+	nodes = make([]string, 0, len(verifyResp.Nodes))
+	for _, node := range verifyResp.Nodes {
+		nodes = append(nodes, node.Name)
+	}
+	sort.Strings(nodes)
+
+	h.log.Debugf("Verify snapshot repository %s successfully: %v", name, nodes)
+
+	return nodes, nil
+}
+
+// SnapshotRepositoryCleanup permit to remove the data of repository not referenced by existing snapshots
+func (h *ElasticsearchHandlerImpl) SnapshotRepositoryCleanup(name string) (result *SnapshotRepositoryCleanupResult, err error) {
+
+	res, err := h.client.API.Snapshot.CleanupRepository(
+		name,
+		h.client.API.Snapshot.CleanupRepository.WithContext(context.Background()),
+		h.client.API.Snapshot.CleanupRepository.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, errors.Errorf("Error when cleanup snapshot repository %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanupResp := &snapshotRepositoryCleanupResponse{}
+	if err = json.Unmarshal(b, cleanupResp); err != nil {
+		return nil, err
+	}
+
+	h.log.Infof("Cleanup snapshot repository %s successfully", name)
+
+	return &cleanupResp.Results, nil
+}
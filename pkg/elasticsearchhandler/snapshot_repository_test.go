@@ -128,3 +128,53 @@ func (t *ElasticsearchHandlerTestSuite) TestSnapshotRepositoryDiff() {
 	assert.NotEmpty(t.T(), diff)
 
 }
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotRepositoryVerify() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_verify", urlSnapshotRepository), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"nodes": {"id2": {"name": "node-2"}, "id1": {"name": "node-1"}}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	nodes, err := t.esHandler.SnapshotRepositoryVerify("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), []string{"node-1", "node-2"}, nodes)
+
+	// When verification failed
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_verify", urlSnapshotRepository), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(500, `{"error": {"type": "repository_verification_exception", "reason": "[test] path is not accessible on master node"}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	_, err = t.esHandler.SnapshotRepositoryVerify("test")
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_verify", urlSnapshotRepository), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SnapshotRepositoryVerify("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotRepositoryCleanup() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_cleanup", urlSnapshotRepository), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"results": {"deleted_bytes": 20, "deleted_blobs": 5}}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	result, err := t.esHandler.SnapshotRepositoryCleanup("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), int64(20), result.DeletedBytes)
+	assert.Equal(t.T(), int64(5), result.DeletedBlobs)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_cleanup", urlSnapshotRepository), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SnapshotRepositoryCleanup("test")
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,217 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlSnapshot = fmt.Sprintf("%s/_snapshot/backup/test", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotGet() {
+
+	rawResp := `
+	{
+		"snapshots": [
+			{
+				"snapshot": "test",
+				"uuid": "dKb54xw67gvdRctLCxSket",
+				"repository": "backup",
+				"version": "8.1.0",
+				"indices": [
+					"index1",
+					"index2"
+				],
+				"data_streams": [],
+				"feature_states": [],
+				"include_global_state": false,
+				"state": "PARTIAL",
+				"start_time_in_millis": 1652342400000,
+				"end_time_in_millis": 1652342460000,
+				"duration_in_millis": 60000,
+				"failures": [
+					{
+						"index": "index2",
+						"index_uuid": "index2",
+						"shard_id": 0,
+						"reason": "IndexShardSnapshotFailedException[failed]",
+						"node_id": "node1",
+						"status": "INTERNAL_SERVER_ERROR"
+					}
+				],
+				"shards": {
+					"total": 2,
+					"failed": 1,
+					"successful": 1
+				}
+			}
+		],
+		"total": 1,
+		"remaining": 0
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlSnapshot, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	snapshot, err := t.esHandler.SnapshotGet("backup", "test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "PARTIAL", snapshot.State)
+	assert.Equal(t.T(), []string{"index1", "index2"}, snapshot.Indices)
+	assert.Equal(t.T(), int64(1), snapshot.Shards.Failed)
+	assert.Equal(t.T(), "index2", snapshot.Failures[0].Index)
+
+	// When snapshot not exist
+	httpmock.RegisterResponder("GET", urlSnapshot, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	snapshot, err = t.esHandler.SnapshotGet("backup", "test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), snapshot)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlSnapshot, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SnapshotGet("backup", "test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotStatus() {
+
+	rawResp := `
+	{
+		"snapshots": [
+			{
+				"snapshot": "test",
+				"repository": "backup",
+				"uuid": "dKb54xw67gvdRctLCxSket",
+				"state": "STARTED",
+				"include_global_state": false,
+				"shards_stats": {
+					"initializing": 0,
+					"started": 1,
+					"finalizing": 0,
+					"done": 1,
+					"failed": 0,
+					"total": 2
+				},
+				"stats": {
+					"incremental": {
+						"file_count": 8,
+						"size_in_bytes": 4704
+					},
+					"processed": {
+						"file_count": 7,
+						"size_in_bytes": 4254
+					},
+					"total": {
+						"file_count": 8,
+						"size_in_bytes": 4704
+					},
+					"start_time_in_millis": 1652342400000,
+					"time_in_millis": 6000
+				},
+				"indices": {
+					"index1": {
+						"shards_stats": {
+							"initializing": 0,
+							"started": 1,
+							"finalizing": 0,
+							"done": 1,
+							"failed": 0,
+							"total": 2
+						}
+					}
+				}
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_status", urlSnapshot), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	status, err := t.esHandler.SnapshotStatus("backup", "test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "STARTED", status.State)
+	assert.Equal(t.T(), int64(2), status.ShardsStats.Total)
+	assert.Equal(t.T(), int64(1), status.ShardsStats.Done)
+	assert.Equal(t.T(), int64(4704), status.Stats.Total.SizeInBytes)
+	assert.Equal(t.T(), int64(2), status.Indices["index1"].ShardsStats.Total)
+
+	// When snapshot not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_status", urlSnapshot), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	status, err = t.esHandler.SnapshotStatus("backup", "test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), status)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_status", urlSnapshot), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.SnapshotStatus("backup", "test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotCreate() {
+	includeGlobalState := false
+	snapshot := &Snapshot{
+		Indices:            []string{"index1", "index2"},
+		IncludeGlobalState: &includeGlobalState,
+	}
+
+	httpmock.RegisterResponder("PUT", urlSnapshot, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"accepted": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.SnapshotCreate("backup", "test", snapshot)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlSnapshot, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.SnapshotCreate("backup", "test", snapshot)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestSnapshotDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlSnapshot, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.SnapshotDelete("backup", "test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlSnapshot, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.SnapshotDelete("backup", "test")
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,372 @@
+package elasticsearchhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Transform is the transform object
+type Transform struct {
+	Description     string                    `json:"description,omitempty"`
+	Source          *TransformSource          `json:"source,omitempty"`
+	Dest            *TransformDest            `json:"dest,omitempty"`
+	Pivot           map[string]any            `json:"pivot,omitempty"`
+	Latest          *TransformLatest          `json:"latest,omitempty"`
+	Sync            *TransformSync            `json:"sync,omitempty"`
+	Frequency       string                    `json:"frequency,omitempty"`
+	RetentionPolicy *TransformRetentionPolicy `json:"retention_policy,omitempty"`
+	Settings        map[string]any            `json:"settings,omitempty"`
+}
+
+// TransformSource is the source sub section
+type TransformSource struct {
+	Index           []string       `json:"index"`
+	Query           map[string]any `json:"query,omitempty"`
+	RuntimeMappings map[string]any `json:"runtime_mappings,omitempty"`
+}
+
+// TransformDest is the dest sub section
+type TransformDest struct {
+	Index    string `json:"index"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// TransformLatest is the latest sub section
+type TransformLatest struct {
+	UniqueKey []string `json:"unique_key"`
+	Sort      string   `json:"sort"`
+}
+
+// TransformSync is the sync sub section
+type TransformSync struct {
+	Time *TransformSyncTime `json:"time"`
+}
+
+// TransformSyncTime is the time based sync
+type TransformSyncTime struct {
+	Field string `json:"field"`
+	Delay string `json:"delay,omitempty"`
+}
+
+// TransformRetentionPolicy is the retention policy sub section
+type TransformRetentionPolicy struct {
+	Time *TransformRetentionPolicyTime `json:"time"`
+}
+
+// TransformRetentionPolicyTime is the time based retention policy
+type TransformRetentionPolicyTime struct {
+	Field  string `json:"field"`
+	MaxAge string `json:"max_age"`
+}
+
+// TransformStats is the transform stats object returned by API
+type TransformStats struct {
+	ID            string                 `json:"id"`
+	State         string                 `json:"state"`
+	Reason        string                 `json:"reason,omitempty"`
+	Health        *TransformHealth       `json:"health,omitempty"`
+	Stats         TransformIndexerStats  `json:"stats"`
+	Checkpointing TransformCheckpointing `json:"checkpointing"`
+}
+
+// TransformHealth is the health sub section of stats
+type TransformHealth struct {
+	Status string `json:"status"`
+}
+
+// TransformIndexerStats is the indexer stats sub section of stats
+type TransformIndexerStats struct {
+	DocumentsProcessed int64 `json:"documents_processed"`
+	DocumentsIndexed   int64 `json:"documents_indexed"`
+}
+
+// TransformCheckpointing is the checkpointing sub section of stats
+type TransformCheckpointing struct {
+	Last TransformCheckpoint `json:"last"`
+}
+
+// TransformCheckpoint is a checkpoint
+type TransformCheckpoint struct {
+	Checkpoint int64 `json:"checkpoint"`
+}
+
+// transformGetResponse is the response of get transform API
+type transformGetResponse struct {
+	Count      int64       `json:"count"`
+	Transforms []Transform `json:"transforms"`
+}
+
+// transformStatsResponse is the response of get transform stats API
+type transformStatsResponse struct {
+	Count      int64            `json:"count"`
+	Transforms []TransformStats `json:"transforms"`
+}
+
+// TransformCreate permit to create new transform
+func (h *ElasticsearchHandlerImpl) TransformCreate(name string, transform *Transform) (err error) {
+
+	b, err := json.Marshal(transform)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.TransformPutTransform(
+		bytes.NewReader(b),
+		name,
+		h.client.API.TransformPutTransform.WithContext(context.Background()),
+		h.client.API.TransformPutTransform.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// TransformUpdate permit to update the transform
+// Only the fields that can be updated in place are sent
+func (h *ElasticsearchHandlerImpl) TransformUpdate(name string, transform *Transform) (err error) {
+
+	payload := *transform
+	payload.Pivot = nil
+	payload.Latest = nil
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	res, err := h.client.API.TransformUpdateTransform(
+		bytes.NewReader(b),
+		name,
+		h.client.API.TransformUpdateTransform.WithContext(context.Background()),
+		h.client.API.TransformUpdateTransform.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// TransformDelete permit to delete the transform
+func (h *ElasticsearchHandlerImpl) TransformDelete(name string) (err error) {
+
+	res, err := h.client.API.TransformDeleteTransform(
+		name,
+		h.client.API.TransformDeleteTransform.WithContext(context.Background()),
+		h.client.API.TransformDeleteTransform.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete transform %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Deleted transform %s successfully", name)
+
+	return nil
+}
+
+// TransformGet permit to get the transform
+func (h *ElasticsearchHandlerImpl) TransformGet(name string) (transform *Transform, err error) {
+
+	res, err := h.client.API.TransformGetTransform(
+		h.client.API.TransformGetTransform.WithContext(context.Background()),
+		h.client.API.TransformGetTransform.WithPretty(),
+		h.client.API.TransformGetTransform.WithTransformID(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get transform %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get transform %s successfully:\n%s", name, string(b))
+
+	transformResp := &transformGetResponse{}
+	if err = json.Unmarshal(b, transformResp); err != nil {
+		return nil, err
+	}
+
+	if len(transformResp.Transforms) == 0 {
+		return nil, nil
+	}
+
+	return &transformResp.Transforms[0], nil
+}
+
+// TransformStart permit to start the transform
+func (h *ElasticsearchHandlerImpl) TransformStart(name string) (err error) {
+
+	res, err := h.client.API.TransformStartTransform(
+		name,
+		h.client.API.TransformStartTransform.WithContext(context.Background()),
+		h.client.API.TransformStartTransform.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when start transform %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Started transform %s successfully", name)
+
+	return nil
+}
+
+// TransformStop permit to stop the transform
+// It wait the transform is effectively stopped
+func (h *ElasticsearchHandlerImpl) TransformStop(name string) (err error) {
+
+	res, err := h.client.API.TransformStopTransform(
+		name,
+		h.client.API.TransformStopTransform.WithContext(context.Background()),
+		h.client.API.TransformStopTransform.WithPretty(),
+		h.client.API.TransformStopTransform.WithWaitForCompletion(true),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when stop transform %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Stopped transform %s successfully", name)
+
+	return nil
+}
+
+// TransformForceStop permit to stop the transform even if it's failed
+// It wait the transform is effectively stopped
+func (h *ElasticsearchHandlerImpl) TransformForceStop(name string) (err error) {
+
+	res, err := h.client.API.TransformStopTransform(
+		name,
+		h.client.API.TransformStopTransform.WithContext(context.Background()),
+		h.client.API.TransformStopTransform.WithPretty(),
+		h.client.API.TransformStopTransform.WithWaitForCompletion(true),
+		h.client.API.TransformStopTransform.WithForce(true),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when force stop transform %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Force stopped transform %s successfully", name)
+
+	return nil
+}
+
+// TransformStats permit to get the transform stats
+func (h *ElasticsearchHandlerImpl) TransformStats(name string) (stats *TransformStats, err error) {
+
+	res, err := h.client.API.TransformGetTransformStats(
+		name,
+		h.client.API.TransformGetTransformStats.WithContext(context.Background()),
+		h.client.API.TransformGetTransformStats.WithPretty(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get transform stats %s: %s", name, res.String())
+	}
+	b, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h.log.Debugf("Get transform stats %s successfully:\n%s", name, string(b))
+
+	statsResp := &transformStatsResponse{}
+	if err = json.Unmarshal(b, statsResp); err != nil {
+		return nil, err
+	}
+
+	if len(statsResp.Transforms) == 0 {
+		return nil, nil
+	}
+
+	return &statsResp.Transforms[0], nil
+}
+
+// TransformDiff permit to check if 2 transforms are the same
+// It ignore the default values added by Elasticsearch when they are not expected
+func (h *ElasticsearchHandlerImpl) TransformDiff(actual, expected *Transform) (diff string, err error) {
+	if actual != nil && expected != nil {
+		tmp := *actual
+		if expected.Source != nil && expected.Source.Query == nil && tmp.Source != nil && reflect.DeepEqual(tmp.Source.Query, map[string]any{"match_all": map[string]any{}}) {
+			source := *tmp.Source
+			source.Query = nil
+			tmp.Source = &source
+		}
+		if len(expected.Settings) == 0 && len(tmp.Settings) == 0 {
+			tmp.Settings = nil
+		}
+		actual = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
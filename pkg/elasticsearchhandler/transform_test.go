@@ -0,0 +1,329 @@
+package elasticsearchhandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlTransform = fmt.Sprintf("%s/_transform/test", baseURL)
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformGet() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"transforms": [
+			{
+				"id": "test",
+				"source": {
+					"index": ["kibana_sample_data_ecommerce"],
+					"query": {
+						"match_all": {}
+					}
+				},
+				"dest": {
+					"index": "kibana_sample_data_ecommerce_transform"
+				},
+				"frequency": "5m",
+				"sync": {
+					"time": {
+						"field": "order_date",
+						"delay": "60s"
+					}
+				},
+				"pivot": {
+					"group_by": {
+						"customer_id": {
+							"terms": {
+								"field": "customer_id"
+							}
+						}
+					},
+					"aggregations": {
+						"max_price": {
+							"max": {
+								"field": "taxful_total_price"
+							}
+						}
+					}
+				},
+				"settings": {},
+				"version": "8.1.0",
+				"create_time": 1652342400000
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlTransform, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	transform, err := t.esHandler.TransformGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "kibana_sample_data_ecommerce_transform", transform.Dest.Index)
+	assert.Equal(t.T(), "order_date", transform.Sync.Time.Field)
+	assert.NotNil(t.T(), transform.Pivot)
+
+	// When transform not exist
+	httpmock.RegisterResponder("GET", urlTransform, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(404, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+	transform, err = t.esHandler.TransformGet("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), transform)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlTransform, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.TransformGet("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformStats() {
+
+	rawResp := `
+	{
+		"count": 1,
+		"transforms": [
+			{
+				"id": "test",
+				"state": "started",
+				"health": {
+					"status": "green"
+				},
+				"stats": {
+					"pages_processed": 1,
+					"documents_processed": 4675,
+					"documents_indexed": 3321
+				},
+				"checkpointing": {
+					"last": {
+						"checkpoint": 2,
+						"timestamp_millis": 1652342400000
+					}
+				}
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlTransform), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, rawResp)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	stats, err := t.esHandler.TransformStats("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "started", stats.State)
+	assert.Equal(t.T(), "green", stats.Health.Status)
+	assert.Equal(t.T(), int64(4675), stats.Stats.DocumentsProcessed)
+	assert.Equal(t.T(), int64(2), stats.Checkpointing.Last.Checkpoint)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/_stats", urlTransform), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.esHandler.TransformStats("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformCreate() {
+	transform := &Transform{
+		Source: &TransformSource{
+			Index: []string{"kibana_sample_data_ecommerce"},
+		},
+		Dest: &TransformDest{
+			Index: "kibana_sample_data_ecommerce_transform",
+		},
+		Latest: &TransformLatest{
+			UniqueKey: []string{"customer_id"},
+			Sort:      "order_date",
+		},
+	}
+
+	httpmock.RegisterResponder("PUT", urlTransform, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.TransformCreate("test", transform)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlTransform, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.TransformCreate("test", transform)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformUpdate() {
+	transform := &Transform{
+		Source: &TransformSource{
+			Index: []string{"kibana_sample_data_ecommerce"},
+		},
+		Dest: &TransformDest{
+			Index: "kibana_sample_data_ecommerce_transform",
+		},
+		Latest: &TransformLatest{
+			UniqueKey: []string{"customer_id"},
+			Sort:      "order_date",
+		},
+		Frequency: "10m",
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlTransform), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.TransformUpdate("test", transform)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// The latest section is removed only from the payload
+	assert.NotNil(t.T(), transform.Latest)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_update", urlTransform), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.TransformUpdate("test", transform)
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformStartStop() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_start", urlTransform), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_stop", urlTransform), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.TransformStart("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	err = t.esHandler.TransformStop("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When force stop failed transform
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_stop", urlTransform), func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("force") != "true" {
+			return httpmock.NewStringResponse(409, `{"error": "transform is in failed state"}`), nil
+		}
+		resp := httpmock.NewStringResponse(200, `{"acknowledged": true}`)
+		SetHeaders(resp)
+		return resp, nil
+	})
+	err = t.esHandler.TransformForceStop("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_start", urlTransform), httpmock.NewErrorResponder(errors.New("fack error")))
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_stop", urlTransform), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.TransformStart("test")
+	assert.Error(t.T(), err)
+	err = t.esHandler.TransformStop("test")
+	assert.Error(t.T(), err)
+	err = t.esHandler.TransformForceStop("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlTransform, func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.TransformDelete("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlTransform, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.TransformDelete("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestTransformDiff() {
+	var actual, expected *Transform
+
+	expected = &Transform{
+		Source: &TransformSource{
+			Index: []string{"kibana_sample_data_ecommerce"},
+		},
+		Dest: &TransformDest{
+			Index: "kibana_sample_data_ecommerce_transform",
+		},
+		Latest: &TransformLatest{
+			UniqueKey: []string{"customer_id"},
+			Sort:      "order_date",
+		},
+	}
+
+	// When transform not exist yet
+	actual = nil
+	diff, err := t.esHandler.TransformDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When transform is the same, with default values set by Elasticsearch
+	actual = &Transform{
+		Source: &TransformSource{
+			Index: []string{"kibana_sample_data_ecommerce"},
+			Query: map[string]any{
+				"match_all": map[string]any{},
+			},
+		},
+		Dest: &TransformDest{
+			Index: "kibana_sample_data_ecommerce_transform",
+		},
+		Latest: &TransformLatest{
+			UniqueKey: []string{"customer_id"},
+			Sort:      "order_date",
+		},
+		Settings: map[string]any{},
+	}
+	diff, err = t.esHandler.TransformDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When transform is not the same
+	expected.Frequency = "10m"
+	diff, err = t.esHandler.TransformDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -43,42 +43,100 @@ func (h *ElasticsearchHandlerImpl) UserUpdate(name string, user *olivere.XPackSe
 
 	//check if need to update password
 	if user.Password != "" || user.PasswordHash != "" {
-
-		payload := make(map[string]string)
-		if user.Password != "" {
-			payload["password"] = user.Password
-		} else {
-			payload["password_hash"] = user.PasswordHash
-		}
-
-		data, err := json.Marshal(payload)
-		if err != nil {
+		if err = h.UserChangePassword(name, user.Password, user.PasswordHash); err != nil {
 			return err
 		}
+	}
+
+	user.Password = ""
+	user.PasswordHash = ""
+	return h.UserCreate(name, user)
+}
 
-		res, err := h.client.API.Security.ChangePassword(
-			bytes.NewReader(data),
-			h.client.API.Security.ChangePassword.WithUsername(name),
-			h.client.API.Security.ChangePassword.WithContext(context.Background()),
-			h.client.API.Security.ChangePassword.WithPretty(),
-		)
+// UserChangePassword permit to change the user password
+// Set password or passwordHash. It's the only way to change password of reserved users
+func (h *ElasticsearchHandlerImpl) UserChangePassword(name string, password string, passwordHash string) (err error) {
 
-		if err != nil {
-			return err
-		}
+	payload := make(map[string]string)
+	if password != "" {
+		payload["password"] = password
+	} else {
+		payload["password_hash"] = passwordHash
+	}
 
-		defer res.Body.Close()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
 
-		if res.IsError() {
-			return errors.Errorf("Error when change password for user %s: %s", name, res.String())
-		}
+	res, err := h.client.API.Security.ChangePassword(
+		bytes.NewReader(data),
+		h.client.API.Security.ChangePassword.WithUsername(name),
+		h.client.API.Security.ChangePassword.WithContext(context.Background()),
+		h.client.API.Security.ChangePassword.WithPretty(),
+	)
 
-		h.log.Infof("Updated user password %s successfully", name)
+	if err != nil {
+		return err
 	}
 
-	user.Password = ""
-	user.PasswordHash = ""
-	return h.UserCreate(name, user)
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when change password for user %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Updated user password %s successfully", name)
+
+	return nil
+}
+
+// UserEnable permit to enable the user
+func (h *ElasticsearchHandlerImpl) UserEnable(name string) (err error) {
+
+	res, err := h.client.API.Security.EnableUser(
+		name,
+		h.client.API.Security.EnableUser.WithContext(context.Background()),
+		h.client.API.Security.EnableUser.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when enable user %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Enabled user %s successfully", name)
+
+	return nil
+}
+
+// UserDisable permit to disable the user
+func (h *ElasticsearchHandlerImpl) UserDisable(name string) (err error) {
+
+	res, err := h.client.API.Security.DisableUser(
+		name,
+		h.client.API.Security.DisableUser.WithContext(context.Background()),
+		h.client.API.Security.DisableUser.WithPretty(),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when disable user %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Disabled user %s successfully", name)
+
+	return nil
 }
 
 // UserDelete permit to delete the user
@@ -142,6 +142,70 @@ func (t *ElasticsearchHandlerTestSuite) TestUserUpdate() {
 	assert.Error(t.T(), err)
 }
 
+func (t *ElasticsearchHandlerTestSuite) TestUserChangePassword() {
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_password", urlUser), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "{}")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	// With password
+	err := t.esHandler.UserChangePassword("test", "password", "")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// With password hash
+	err = t.esHandler.UserChangePassword("test", "", "hash")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_password", urlUser), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.UserChangePassword("test", "password", "")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestUserEnable() {
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_enable", urlUser), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "{}")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.UserEnable("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_enable", urlUser), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.UserEnable("test")
+	assert.Error(t.T(), err)
+}
+
+func (t *ElasticsearchHandlerTestSuite) TestUserDisable() {
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_disable", urlUser), func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(200, "{}")
+		SetHeaders(resp)
+		return resp, nil
+	})
+
+	err := t.esHandler.UserDisable("test")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/_disable", urlUser), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.esHandler.UserDisable("test")
+	assert.Error(t.T(), err)
+}
+
 func (t *ElasticsearchHandlerTestSuite) TestUserDiff() {
 	var actual, expected *olivere.XPackSecurityPutUserRequest
 
@@ -1,8 +1,12 @@
 package helpers
 
 import (
+	crand "crypto/rand"
+	"math/big"
 	"math/rand"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const charset = "abcdefghijklmnopqrstuvwxyz"
@@ -20,3 +24,25 @@ func randomStringWithCharset(length int, charset string) string {
 func RandomString(length int) string {
 	return randomStringWithCharset(length, charset)
 }
+
+// RandomPassword generates a random password from the charset
+// It use crypto/rand, so the password can't be guessed
+func RandomPassword(length int, charset string) (string, error) {
+	if charset == "" {
+		return "", errors.New("Charset can't be empty")
+	}
+	if length <= 0 {
+		return "", errors.New("Length must be greater than 0")
+	}
+
+	max := big.NewInt(int64(len(charset)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := crand.Int(crand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = charset[n.Int64()]
+	}
+	return string(b), nil
+}
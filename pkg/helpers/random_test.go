@@ -15,3 +15,22 @@ func TestRandomString(t *testing.T) {
 	assert.Equal(t, len(a1), len(a2))
 	assert.Equal(t, 10, len(b1))
 }
+
+func TestRandomPassword(t *testing.T) {
+	p1, err := RandomPassword(32, "abc123")
+	assert.NoError(t, err)
+	p2, err := RandomPassword(32, "abc123")
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, p1, p2)
+	assert.Equal(t, 32, len(p1))
+	assert.Regexp(t, "^[abc123]+$", p1)
+
+	// When charset is empty
+	_, err = RandomPassword(32, "")
+	assert.Error(t, err)
+
+	// When length is 0
+	_, err = RandomPassword(0, "abc123")
+	assert.Error(t, err)
+}
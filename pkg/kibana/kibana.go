@@ -0,0 +1,42 @@
+package kibana
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	GVR = schema.GroupVersionResource{
+		Group:    "kibana.k8s.elastic.co",
+		Version:  "v1",
+		Resource: "kibanas",
+	}
+)
+
+type Kibana struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              KibanaSpec `json:"spec,omitempty"`
+}
+
+type KibanaSpec struct {
+	ElasticsearchRef KibanaSpecElasticsearchRef `json:"elasticsearchRef,omitempty"`
+	HTTP             KibanaSpecHTTP             `json:"http,omitempty"`
+}
+
+type KibanaSpecElasticsearchRef struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type KibanaSpecHTTP struct {
+	TLS KibanaSpecHTTPTLS `json:"tls,omitempty"`
+}
+
+type KibanaSpecHTTPTLS struct {
+	SelfSignedCertificate KibanaSpecHTTPTLSSelfSignedCertificate `json:"selfSignedCertificate,omitempty"`
+}
+
+type KibanaSpecHTTPTLSSelfSignedCertificate struct {
+	Disabled bool `json:"disabled,omitempty"`
+}
@@ -0,0 +1,186 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaAlertRule is the alerting rule object
+type KibanaAlertRule struct {
+	ID         string                  `json:"id,omitempty"`
+	Name       string                  `json:"name"`
+	RuleTypeID string                  `json:"rule_type_id"`
+	Consumer   string                  `json:"consumer"`
+	Schedule   KibanaAlertRuleSchedule `json:"schedule"`
+	Params     map[string]any          `json:"params"`
+	Actions    []KibanaAlertRuleAction `json:"actions,omitempty"`
+	Tags       []string                `json:"tags,omitempty"`
+	Enabled    bool                    `json:"enabled"`
+	NotifyWhen string                  `json:"notify_when,omitempty"`
+	Throttle   string                  `json:"throttle,omitempty"`
+}
+
+// KibanaAlertRuleSchedule is the schedule of rule
+type KibanaAlertRuleSchedule struct {
+	Interval string `json:"interval"`
+}
+
+// KibanaAlertRuleAction is the action run by rule
+type KibanaAlertRuleAction struct {
+	Group  string         `json:"group,omitempty"`
+	ID     string         `json:"id"`
+	Params map[string]any `json:"params"`
+}
+
+// KibanaAlertRuleInfo is the rule object returned by get rule API
+// It contain the execution status of the rule
+type KibanaAlertRuleInfo struct {
+	KibanaAlertRule
+	ExecutionStatus KibanaAlertRuleExecutionStatus `json:"execution_status"`
+	LastRun         *KibanaAlertRuleLastRun        `json:"last_run,omitempty"`
+	NextRun         string                         `json:"next_run,omitempty"`
+}
+
+// KibanaAlertRuleExecutionStatus is the execution status of rule
+type KibanaAlertRuleExecutionStatus struct {
+	Status            string                `json:"status"`
+	LastExecutionDate string                `json:"last_execution_date,omitempty"`
+	LastDuration      int64                 `json:"last_duration,omitempty"`
+	Error             *KibanaAlertRuleError `json:"error,omitempty"`
+}
+
+// KibanaAlertRuleError is the error of the last rule execution
+type KibanaAlertRuleError struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// KibanaAlertRuleLastRun is the result of the last rule execution
+type KibanaAlertRuleLastRun struct {
+	Outcome    string   `json:"outcome"`
+	OutcomeMsg []string `json:"outcome_msg,omitempty"`
+}
+
+// alertRuleUpdateRequest is the body of update rule API
+// The rule type, consumer and enabled can't be updated
+type alertRuleUpdateRequest struct {
+	Name       string                  `json:"name"`
+	Schedule   KibanaAlertRuleSchedule `json:"schedule"`
+	Params     map[string]any          `json:"params"`
+	Actions    []KibanaAlertRuleAction `json:"actions"`
+	Tags       []string                `json:"tags,omitempty"`
+	NotifyWhen string                  `json:"notify_when,omitempty"`
+	Throttle   string                  `json:"throttle,omitempty"`
+}
+
+// AlertRuleCreate permit to create new rule on space
+func (h *KibanaHandlerImpl) AlertRuleCreate(space string, rule *KibanaAlertRule) (err error) {
+
+	payload := *rule
+	payload.ID = ""
+
+	res, err := h.do("POST", space, fmt.Sprintf("/api/alerting/rule/%s", rule.ID), &payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add rule %s: %s", rule.ID, res.String())
+	}
+
+	return nil
+}
+
+// AlertRuleUpdate permit to update rule on space
+// It also enable or disable the rule if needed
+func (h *KibanaHandlerImpl) AlertRuleUpdate(space string, rule *KibanaAlertRule) (err error) {
+
+	payload := &alertRuleUpdateRequest{
+		Name:       rule.Name,
+		Schedule:   rule.Schedule,
+		Params:     rule.Params,
+		Actions:    rule.Actions,
+		Tags:       rule.Tags,
+		NotifyWhen: rule.NotifyWhen,
+		Throttle:   rule.Throttle,
+	}
+	if payload.Actions == nil {
+		payload.Actions = []KibanaAlertRuleAction{}
+	}
+
+	res, err := h.do("PUT", space, fmt.Sprintf("/api/alerting/rule/%s", rule.ID), payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update rule %s: %s", rule.ID, res.String())
+	}
+
+	action := "_disable"
+	if rule.Enabled {
+		action = "_enable"
+	}
+	res, err = h.do("POST", space, fmt.Sprintf("/api/alerting/rule/%s/%s", rule.ID, action), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when %s rule %s: %s", action, rule.ID, res.String())
+	}
+
+	return nil
+}
+
+// AlertRuleDelete permit to delete rule from space
+func (h *KibanaHandlerImpl) AlertRuleDelete(space, id string) (err error) {
+
+	res, err := h.do("DELETE", space, fmt.Sprintf("/api/alerting/rule/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete rule %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted rule %s successfully", id)
+
+	return nil
+}
+
+// AlertRuleGet permit to get rule from space
+func (h *KibanaHandlerImpl) AlertRuleGet(space, id string) (rule *KibanaAlertRuleInfo, err error) {
+
+	res, err := h.do("GET", space, fmt.Sprintf("/api/alerting/rule/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get rule %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get rule %s successfully:\n%s", id, string(res.Body))
+
+	rule = &KibanaAlertRuleInfo{}
+	if err = json.Unmarshal(res.Body, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// AlertRuleDiff permit to check if 2 rules are the same
+func (h *KibanaHandlerImpl) AlertRuleDiff(actual, expected *KibanaAlertRule) (diff string, err error) {
+	return standartDiff(actual, expected, h.log, nil)
+}
@@ -0,0 +1,243 @@
+package kibanahandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlAlertRule = fmt.Sprintf("%s/api/alerting/rule/cpu-high", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestAlertRuleGet() {
+
+	rawResp := `
+	{
+		"id": "cpu-high",
+		"consumer": "alerts",
+		"tags": ["cpu"],
+		"name": "CPU high",
+		"enabled": true,
+		"throttle": null,
+		"schedule": {
+			"interval": "1m"
+		},
+		"params": {
+			"aggType": "avg",
+			"termSize": 6,
+			"thresholdComparator": ">",
+			"timeWindowSize": 5,
+			"timeWindowUnit": "m",
+			"groupBy": "top",
+			"threshold": [1000],
+			"index": ["metrics-*"],
+			"timeField": "@timestamp",
+			"aggField": "system.cpu.total.pct",
+			"termField": "host.name"
+		},
+		"rule_type_id": ".index-threshold",
+		"created_by": "elastic",
+		"updated_by": "elastic",
+		"created_at": "2022-06-08T17:20:31.632Z",
+		"updated_at": "2022-06-08T17:20:31.632Z",
+		"api_key_owner": "elastic",
+		"notify_when": "onActionGroupChange",
+		"mute_all": false,
+		"muted_alert_ids": [],
+		"scheduled_task_id": "cpu-high",
+		"execution_status": {
+			"status": "error",
+			"last_execution_date": "2022-06-08T17:20:31.632Z",
+			"last_duration": 60,
+			"error": {
+				"reason": "execute",
+				"message": "index not found"
+			}
+		},
+		"last_run": {
+			"outcome": "failed",
+			"outcome_msg": ["index not found"],
+			"alerts_count": {}
+		},
+		"next_run": "2022-06-08T17:21:31.632Z",
+		"actions": [
+			{
+				"group": "threshold met",
+				"id": "slack",
+				"params": {
+					"message": "CPU is high"
+				},
+				"connector_type_id": ".slack"
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlAlertRule, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	rule, err := t.kbHandler.AlertRuleGet("default", "cpu-high")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "CPU high", rule.Name)
+	assert.Equal(t.T(), "1m", rule.Schedule.Interval)
+	assert.Equal(t.T(), "slack", rule.Actions[0].ID)
+	assert.Equal(t.T(), "error", rule.ExecutionStatus.Status)
+	assert.Equal(t.T(), "index not found", rule.ExecutionStatus.Error.Message)
+	assert.Equal(t.T(), "failed", rule.LastRun.Outcome)
+
+	// When rule not exist
+	httpmock.RegisterResponder("GET", urlAlertRule, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	rule, err = t.kbHandler.AlertRuleGet("default", "cpu-high")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), rule)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlAlertRule, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.AlertRuleGet("default", "cpu-high")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestAlertRuleCreate() {
+	rule := &KibanaAlertRule{
+		ID:         "cpu-high",
+		Name:       "CPU high",
+		RuleTypeID: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule: KibanaAlertRuleSchedule{
+			Interval: "1m",
+		},
+		Params:  map[string]any{},
+		Enabled: true,
+	}
+
+	httpmock.RegisterResponder("POST", urlAlertRule, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "cpu-high"}`), nil
+	})
+
+	err := t.kbHandler.AlertRuleCreate("default", rule)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", urlAlertRule, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.AlertRuleCreate("default", rule)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestAlertRuleUpdate() {
+	rule := &KibanaAlertRule{
+		ID:         "cpu-high",
+		Name:       "CPU high",
+		RuleTypeID: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule: KibanaAlertRuleSchedule{
+			Interval: "1m",
+		},
+		Params:  map[string]any{},
+		Enabled: false,
+	}
+
+	httpmock.RegisterResponder("PUT", urlAlertRule, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "cpu-high"}`), nil
+	})
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_disable", urlAlertRule), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.AlertRuleUpdate("default", rule)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When enable failed
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/_disable", urlAlertRule), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.AlertRuleUpdate("default", rule)
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlAlertRule, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.AlertRuleUpdate("default", rule)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestAlertRuleDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlAlertRule, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.AlertRuleDelete("default", "cpu-high")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlAlertRule, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.AlertRuleDelete("default", "cpu-high")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestAlertRuleDiff() {
+	var actual, expected *KibanaAlertRule
+
+	expected = &KibanaAlertRule{
+		ID:         "cpu-high",
+		Name:       "CPU high",
+		RuleTypeID: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule: KibanaAlertRuleSchedule{
+			Interval: "1m",
+		},
+		Params: map[string]any{
+			"threshold": []any{1000},
+		},
+		Enabled: true,
+	}
+
+	// When rule not exist yet
+	actual = nil
+	diff, err := t.kbHandler.AlertRuleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When rule is the same
+	actual = &KibanaAlertRule{
+		ID:         "cpu-high",
+		Name:       "CPU high",
+		RuleTypeID: ".index-threshold",
+		Consumer:   "alerts",
+		Schedule: KibanaAlertRuleSchedule{
+			Interval: "1m",
+		},
+		Params: map[string]any{
+			"threshold": []any{1000},
+		},
+		Actions: []KibanaAlertRuleAction{},
+		Enabled: true,
+	}
+	diff, err = t.kbHandler.AlertRuleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When rule is not the same
+	expected.Enabled = false
+	diff, err = t.kbHandler.AlertRuleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
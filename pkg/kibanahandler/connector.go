@@ -0,0 +1,127 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaConnector is the connector object
+type KibanaConnector struct {
+	ID              string         `json:"id,omitempty"`
+	Name            string         `json:"name"`
+	ConnectorTypeID string         `json:"connector_type_id"`
+	Config          map[string]any `json:"config,omitempty"`
+	Secrets         map[string]any `json:"secrets,omitempty"`
+}
+
+// connectorUpdateRequest is the body of update connector API
+// The connector type can't be updated
+type connectorUpdateRequest struct {
+	Name    string         `json:"name"`
+	Config  map[string]any `json:"config,omitempty"`
+	Secrets map[string]any `json:"secrets,omitempty"`
+}
+
+// ConnectorCreate permit to create new connector on space
+func (h *KibanaHandlerImpl) ConnectorCreate(space string, connector *KibanaConnector) (err error) {
+
+	payload := *connector
+	payload.ID = ""
+
+	res, err := h.do("POST", space, fmt.Sprintf("/api/actions/connector/%s", connector.ID), &payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add connector %s: %s", connector.ID, res.String())
+	}
+
+	return nil
+}
+
+// ConnectorUpdate permit to update connector on space
+func (h *KibanaHandlerImpl) ConnectorUpdate(space string, connector *KibanaConnector) (err error) {
+
+	payload := &connectorUpdateRequest{
+		Name:    connector.Name,
+		Config:  connector.Config,
+		Secrets: connector.Secrets,
+	}
+
+	res, err := h.do("PUT", space, fmt.Sprintf("/api/actions/connector/%s", connector.ID), payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update connector %s: %s", connector.ID, res.String())
+	}
+
+	return nil
+}
+
+// ConnectorDelete permit to delete connector from space
+func (h *KibanaHandlerImpl) ConnectorDelete(space, id string) (err error) {
+
+	res, err := h.do("DELETE", space, fmt.Sprintf("/api/actions/connector/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete connector %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted connector %s successfully", id)
+
+	return nil
+}
+
+// ConnectorGet permit to get connector from space
+// The secrets are never returned by Kibana
+func (h *KibanaHandlerImpl) ConnectorGet(space, id string) (connector *KibanaConnector, err error) {
+
+	res, err := h.do("GET", space, fmt.Sprintf("/api/actions/connector/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get connector %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get connector %s successfully:\n%s", id, string(res.Body))
+
+	connector = &KibanaConnector{}
+	if err = json.Unmarshal(res.Body, connector); err != nil {
+		return nil, err
+	}
+
+	return connector, nil
+}
+
+// ConnectorDiff permit to check if 2 connectors are the same
+// The secrets are not compared, because of Kibana never return them
+func (h *KibanaHandlerImpl) ConnectorDiff(actual, expected *KibanaConnector) (diff string, err error) {
+	if actual != nil {
+		a := *actual
+		a.Secrets = nil
+		actual = &a
+	}
+	if expected != nil {
+		e := *expected
+		e.Secrets = nil
+		expected = &e
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
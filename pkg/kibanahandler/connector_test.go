@@ -0,0 +1,176 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlConnector = fmt.Sprintf("%s/api/actions/connector/slack", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestConnectorGet() {
+
+	rawResp := `
+	{
+		"id": "slack",
+		"name": "Slack",
+		"connector_type_id": ".slack",
+		"config": {},
+		"is_preconfigured": false,
+		"is_deprecated": false,
+		"is_missing_secrets": false
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlConnector, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	connector, err := t.kbHandler.ConnectorGet("default", "slack")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "Slack", connector.Name)
+	assert.Equal(t.T(), ".slack", connector.ConnectorTypeID)
+
+	// When connector not exist
+	httpmock.RegisterResponder("GET", urlConnector, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	connector, err = t.kbHandler.ConnectorGet("default", "slack")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), connector)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlConnector, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.ConnectorGet("default", "slack")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestConnectorCreate() {
+	connector := &KibanaConnector{
+		ID:              "slack",
+		Name:            "Slack",
+		ConnectorTypeID: ".slack",
+		Secrets: map[string]any{
+			"webhookUrl": "https://hooks.slack.com/services/xxx",
+		},
+	}
+
+	httpmock.RegisterResponder("POST", urlConnector, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "slack", "name": "Slack", "connector_type_id": ".slack"}`), nil
+	})
+
+	err := t.kbHandler.ConnectorCreate("default", connector)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", urlConnector, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.ConnectorCreate("default", connector)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestConnectorUpdate() {
+	connector := &KibanaConnector{
+		ID:              "slack",
+		Name:            "Slack",
+		ConnectorTypeID: ".slack",
+		Secrets: map[string]any{
+			"webhookUrl": "https://hooks.slack.com/services/xxx",
+		},
+	}
+
+	httpmock.RegisterResponder("PUT", urlConnector, func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if _, ok := body["connector_type_id"]; ok {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"id": "slack", "name": "Slack", "connector_type_id": ".slack"}`), nil
+	})
+
+	err := t.kbHandler.ConnectorUpdate("default", connector)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlConnector, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.ConnectorUpdate("default", connector)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestConnectorDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlConnector, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.ConnectorDelete("default", "slack")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlConnector, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.ConnectorDelete("default", "slack")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestConnectorDiff() {
+	var actual, expected *KibanaConnector
+
+	expected = &KibanaConnector{
+		ID:              "slack",
+		Name:            "Slack",
+		ConnectorTypeID: ".slack",
+		Secrets: map[string]any{
+			"webhookUrl": "https://hooks.slack.com/services/xxx",
+		},
+	}
+
+	// When connector not exist yet
+	actual = nil
+	diff, err := t.kbHandler.ConnectorDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When connector is the same, secrets are ignored
+	actual = &KibanaConnector{
+		ID:              "slack",
+		Name:            "Slack",
+		ConnectorTypeID: ".slack",
+		Config:          map[string]any{},
+	}
+	diff, err = t.kbHandler.ConnectorDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+	assert.NotNil(t.T(), expected.Secrets)
+
+	// When connector is not the same
+	expected.Name = "Slack alerts"
+	diff, err = t.kbHandler.ConnectorDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -0,0 +1,173 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaDataView is the data view object
+type KibanaDataView struct {
+	ID              string                                `json:"id,omitempty"`
+	Title           string                                `json:"title"`
+	Name            string                                `json:"name,omitempty"`
+	TimeFieldName   string                                `json:"timeFieldName,omitempty"`
+	RuntimeFieldMap map[string]KibanaDataViewRuntimeField `json:"runtimeFieldMap,omitempty"`
+	FieldFormats    map[string]KibanaDataViewFieldFormat  `json:"fieldFormats,omitempty"`
+}
+
+// KibanaDataViewRuntimeField is a runtime field of data view
+type KibanaDataViewRuntimeField struct {
+	Type   string                `json:"type"`
+	Script *KibanaDataViewScript `json:"script,omitempty"`
+}
+
+// KibanaDataViewScript is the script of runtime field
+type KibanaDataViewScript struct {
+	Source string `json:"source"`
+}
+
+// KibanaDataViewFieldFormat is the format of field
+type KibanaDataViewFieldFormat struct {
+	ID     string         `json:"id"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// dataViewRequest is the body of create / update data view API
+type dataViewRequest struct {
+	DataView *KibanaDataView `json:"data_view"`
+}
+
+// dataViewResponse is the response of data view API
+type dataViewResponse struct {
+	DataView KibanaDataView `json:"data_view"`
+}
+
+// dataViewDefault is the body / response of default data view API
+type dataViewDefault struct {
+	DataViewID string `json:"data_view_id"`
+	Force      bool   `json:"force,omitempty"`
+}
+
+// DataViewCreate permit to create new data view on space
+func (h *KibanaHandlerImpl) DataViewCreate(space string, dataView *KibanaDataView) (err error) {
+
+	res, err := h.do("POST", space, "/api/data_views/data_view", &dataViewRequest{DataView: dataView})
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add data view %s: %s", dataView.ID, res.String())
+	}
+
+	return nil
+}
+
+// DataViewUpdate permit to update data view on space
+// The ID can't be updated
+func (h *KibanaHandlerImpl) DataViewUpdate(space string, dataView *KibanaDataView) (err error) {
+
+	// The API not accept the ID on body
+	payload := *dataView
+	payload.ID = ""
+
+	res, err := h.do("POST", space, fmt.Sprintf("/api/data_views/data_view/%s", dataView.ID), &dataViewRequest{DataView: &payload})
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update data view %s: %s", dataView.ID, res.String())
+	}
+
+	return nil
+}
+
+// DataViewDelete permit to delete data view from space
+func (h *KibanaHandlerImpl) DataViewDelete(space, id string) (err error) {
+
+	res, err := h.do("DELETE", space, fmt.Sprintf("/api/data_views/data_view/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete data view %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted data view %s successfully", id)
+
+	return nil
+}
+
+// DataViewGet permit to get data view from space
+func (h *KibanaHandlerImpl) DataViewGet(space, id string) (dataView *KibanaDataView, err error) {
+
+	res, err := h.do("GET", space, fmt.Sprintf("/api/data_views/data_view/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get data view %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get data view %s successfully:\n%s", id, string(res.Body))
+
+	dataViewResp := &dataViewResponse{}
+	if err = json.Unmarshal(res.Body, dataViewResp); err != nil {
+		return nil, err
+	}
+
+	return &dataViewResp.DataView, nil
+}
+
+// DataViewDiff permit to check if 2 data views are the same
+func (h *KibanaHandlerImpl) DataViewDiff(actual, expected *KibanaDataView) (diff string, err error) {
+	return standartDiff(actual, expected, h.log, nil)
+}
+
+// DataViewGetDefault permit to get the default data view ID of space
+func (h *KibanaHandlerImpl) DataViewGetDefault(space string) (id string, err error) {
+
+	res, err := h.do("GET", space, "/api/data_views/default", nil)
+	if err != nil {
+		return "", err
+	}
+
+	if res.IsError() {
+		return "", errors.Errorf("Error when get default data view: %s", res.String())
+	}
+
+	defaultResp := &dataViewDefault{}
+	if err = json.Unmarshal(res.Body, defaultResp); err != nil {
+		return "", err
+	}
+
+	return defaultResp.DataViewID, nil
+}
+
+// DataViewSetDefault permit to set the default data view of space
+func (h *KibanaHandlerImpl) DataViewSetDefault(space, id string) (err error) {
+
+	res, err := h.do("POST", space, "/api/data_views/default", &dataViewDefault{DataViewID: id, Force: true})
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when set default data view %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Set default data view %s successfully", id)
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlDataView = fmt.Sprintf("%s/s/marketing/api/data_views/data_view/logs", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestDataViewGet() {
+
+	rawResp := `
+	{
+		"data_view": {
+			"id": "logs",
+			"version": "WzQ2LDJd",
+			"title": "logs-*",
+			"name": "Logs",
+			"timeFieldName": "@timestamp",
+			"sourceFilters": [],
+			"fields": {},
+			"typeMeta": {},
+			"fieldFormats": {
+				"bytes": {
+					"id": "bytes"
+				}
+			},
+			"runtimeFieldMap": {
+				"day_of_week": {
+					"type": "keyword",
+					"script": {
+						"source": "emit(doc['@timestamp'].value.dayOfWeekEnum.toString())"
+					}
+				}
+			},
+			"fieldAttrs": {},
+			"allowNoIndex": false,
+			"namespaces": ["marketing"]
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlDataView, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	dataView, err := t.kbHandler.DataViewGet("marketing", "logs")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "logs-*", dataView.Title)
+	assert.Equal(t.T(), "@timestamp", dataView.TimeFieldName)
+	assert.Equal(t.T(), "keyword", dataView.RuntimeFieldMap["day_of_week"].Type)
+	assert.Equal(t.T(), "bytes", dataView.FieldFormats["bytes"].ID)
+
+	// When data view not exist
+	httpmock.RegisterResponder("GET", urlDataView, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	dataView, err = t.kbHandler.DataViewGet("marketing", "logs")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), dataView)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlDataView, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.DataViewGet("marketing", "logs")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestDataViewCreate() {
+	dataView := &KibanaDataView{
+		ID:            "logs",
+		Title:         "logs-*",
+		TimeFieldName: "@timestamp",
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/s/marketing/api/data_views/data_view", baseURL), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"data_view": {"id": "logs", "title": "logs-*"}}`), nil
+	})
+
+	err := t.kbHandler.DataViewCreate("marketing", dataView)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/s/marketing/api/data_views/data_view", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.DataViewCreate("marketing", dataView)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestDataViewUpdate() {
+	dataView := &KibanaDataView{
+		ID:            "logs",
+		Title:         "logs-*",
+		TimeFieldName: "@timestamp",
+	}
+
+	httpmock.RegisterResponder("POST", urlDataView, func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if _, ok := body["data_view"]["id"]; ok {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"data_view": {"id": "logs", "title": "logs-*"}}`), nil
+	})
+
+	err := t.kbHandler.DataViewUpdate("marketing", dataView)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "logs", dataView.ID)
+
+	// When error
+	httpmock.RegisterResponder("POST", urlDataView, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.DataViewUpdate("marketing", dataView)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestDataViewDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlDataView, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, ""), nil
+	})
+
+	err := t.kbHandler.DataViewDelete("marketing", "logs")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlDataView, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.DataViewDelete("marketing", "logs")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestDataViewDefault() {
+	urlDefault := fmt.Sprintf("%s/s/marketing/api/data_views/default", baseURL)
+
+	httpmock.RegisterResponder("GET", urlDefault, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"data_view_id": "logs"}`), nil
+	})
+
+	id, err := t.kbHandler.DataViewGetDefault("marketing")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "logs", id)
+
+	httpmock.RegisterResponder("POST", urlDefault, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"acknowledged": true}`), nil
+	})
+
+	err = t.kbHandler.DataViewSetDefault("marketing", "logs")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("GET", urlDefault, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.DataViewGetDefault("marketing")
+	assert.Error(t.T(), err)
+
+	httpmock.RegisterResponder("POST", urlDefault, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.DataViewSetDefault("marketing", "logs")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestDataViewDiff() {
+	var actual, expected *KibanaDataView
+
+	expected = &KibanaDataView{
+		ID:            "logs",
+		Title:         "logs-*",
+		TimeFieldName: "@timestamp",
+	}
+
+	// When data view not exist yet
+	actual = nil
+	diff, err := t.kbHandler.DataViewDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When data view is the same
+	actual = &KibanaDataView{
+		ID:              "logs",
+		Title:           "logs-*",
+		TimeFieldName:   "@timestamp",
+		RuntimeFieldMap: map[string]KibanaDataViewRuntimeField{},
+		FieldFormats:    map[string]KibanaDataViewFieldFormat{},
+	}
+	diff, err = t.kbHandler.DataViewDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When data view is not the same
+	expected.FieldFormats = map[string]KibanaDataViewFieldFormat{
+		"bytes": {
+			ID: "bytes",
+		},
+	}
+	diff, err = t.kbHandler.DataViewDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
@@ -0,0 +1,181 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// FleetAgentPolicy is the Fleet agent policy object
+type FleetAgentPolicy struct {
+	ID                 string   `json:"id,omitempty"`
+	Name               string   `json:"name"`
+	Namespace          string   `json:"namespace"`
+	Description        string   `json:"description,omitempty"`
+	MonitoringEnabled  []string `json:"monitoring_enabled"`
+	DataOutputID       string   `json:"data_output_id,omitempty"`
+	MonitoringOutputID string   `json:"monitoring_output_id,omitempty"`
+	FleetServerHostID  string   `json:"fleet_server_host_id,omitempty"`
+	InactivityTimeout  int64    `json:"inactivity_timeout,omitempty"`
+	UnenrollTimeout    int64    `json:"unenroll_timeout,omitempty"`
+}
+
+// FleetEnrollmentAPIKey is the enrollment token of agent policy
+type FleetEnrollmentAPIKey struct {
+	ID       string `json:"id"`
+	APIKeyID string `json:"api_key_id"`
+	APIKey   string `json:"api_key"`
+	Name     string `json:"name"`
+	PolicyID string `json:"policy_id"`
+	Active   bool   `json:"active"`
+}
+
+// fleetAgentPolicyResponse is the response of get agent policy API
+type fleetAgentPolicyResponse struct {
+	Item FleetAgentPolicy `json:"item"`
+}
+
+// fleetEnrollmentAPIKeysResponse is the response of list enrollment API keys API
+type fleetEnrollmentAPIKeysResponse struct {
+	Items []FleetEnrollmentAPIKey `json:"items"`
+}
+
+// FleetAgentPolicyCreate permit to create new agent policy
+func (h *KibanaHandlerImpl) FleetAgentPolicyCreate(policy *FleetAgentPolicy) (err error) {
+
+	res, err := h.do("POST", "", "/api/fleet/agent_policies", policy)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add agent policy %s: %s", policy.ID, res.String())
+	}
+
+	return nil
+}
+
+// FleetAgentPolicyUpdate permit to update agent policy
+func (h *KibanaHandlerImpl) FleetAgentPolicyUpdate(policy *FleetAgentPolicy) (err error) {
+
+	payload := *policy
+	payload.ID = ""
+
+	res, err := h.do("PUT", "", fmt.Sprintf("/api/fleet/agent_policies/%s", policy.ID), &payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update agent policy %s: %s", policy.ID, res.String())
+	}
+
+	return nil
+}
+
+// FleetAgentPolicyDelete permit to delete agent policy
+func (h *KibanaHandlerImpl) FleetAgentPolicyDelete(id string) (err error) {
+
+	payload := map[string]string{
+		"agentPolicyId": id,
+	}
+
+	res, err := h.do("POST", "", "/api/fleet/agent_policies/delete", payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete agent policy %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted agent policy %s successfully", id)
+
+	return nil
+}
+
+// FleetAgentPolicyGet permit to get agent policy
+func (h *KibanaHandlerImpl) FleetAgentPolicyGet(id string) (policy *FleetAgentPolicy, err error) {
+
+	res, err := h.do("GET", "", fmt.Sprintf("/api/fleet/agent_policies/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get agent policy %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get agent policy %s successfully:\n%s", id, string(res.Body))
+
+	policyResp := &fleetAgentPolicyResponse{}
+	if err = json.Unmarshal(res.Body, policyResp); err != nil {
+		return nil, err
+	}
+
+	return &policyResp.Item, nil
+}
+
+// FleetAgentPolicyDiff permit to check if 2 agent policies are the same
+// It ignore the default values set by Fleet when they are not expected
+func (h *KibanaHandlerImpl) FleetAgentPolicyDiff(actual, expected *FleetAgentPolicy) (diff string, err error) {
+	if actual != nil && expected != nil {
+		tmp := *actual
+		if expected.DataOutputID == "" {
+			tmp.DataOutputID = ""
+		}
+		if expected.MonitoringOutputID == "" {
+			tmp.MonitoringOutputID = ""
+		}
+		if expected.FleetServerHostID == "" {
+			tmp.FleetServerHostID = ""
+		}
+		if expected.InactivityTimeout == 0 {
+			tmp.InactivityTimeout = 0
+		}
+		if expected.UnenrollTimeout == 0 {
+			tmp.UnenrollTimeout = 0
+		}
+		actual = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
+
+// FleetEnrollmentTokenGet permit to get the active enrollment token of agent policy
+// It return nil if agent policy has no active enrollment token
+func (h *KibanaHandlerImpl) FleetEnrollmentTokenGet(policyID string) (token *FleetEnrollmentAPIKey, err error) {
+
+	query := url.Values{}
+	query.Set("kuery", fmt.Sprintf("policy_id:\"%s\"", policyID))
+
+	res, err := h.do("GET", "", fmt.Sprintf("/api/fleet/enrollment_api_keys?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get enrollment token of agent policy %s: %s", policyID, res.String())
+	}
+
+	keysResp := &fleetEnrollmentAPIKeysResponse{}
+	if err = json.Unmarshal(res.Body, keysResp); err != nil {
+		return nil, err
+	}
+
+	for _, key := range keysResp.Items {
+		if key.PolicyID == policyID && key.Active {
+			return &key, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,256 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlFleetAgentPolicy = fmt.Sprintf("%s/api/fleet/agent_policies", baseURL)
+var urlFleetEnrollmentAPIKeys = fmt.Sprintf("%s/api/fleet/enrollment_api_keys", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestFleetAgentPolicyGet() {
+
+	rawResp := `
+	{
+		"item": {
+			"id": "linux",
+			"namespace": "default",
+			"monitoring_enabled": ["logs", "metrics"],
+			"inactivity_timeout": 1209600,
+			"name": "Linux servers",
+			"description": "Agent policy of linux servers",
+			"is_managed": false,
+			"status": "active",
+			"revision": 1,
+			"package_policies": []
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux", urlFleetAgentPolicy), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	policy, err := t.kbHandler.FleetAgentPolicyGet("linux")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "Linux servers", policy.Name)
+	assert.Equal(t.T(), []string{"logs", "metrics"}, policy.MonitoringEnabled)
+	assert.Equal(t.T(), int64(1209600), policy.InactivityTimeout)
+
+	// When agent policy not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux", urlFleetAgentPolicy), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	policy, err = t.kbHandler.FleetAgentPolicyGet("linux")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), policy)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux", urlFleetAgentPolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.FleetAgentPolicyGet("linux")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetAgentPolicyCreate() {
+	policy := &FleetAgentPolicy{
+		ID:                "linux",
+		Name:              "Linux servers",
+		Namespace:         "default",
+		MonitoringEnabled: []string{"logs", "metrics"},
+	}
+
+	httpmock.RegisterResponder("POST", urlFleetAgentPolicy, func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if body["id"] != "linux" {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"item": {"id": "linux"}}`), nil
+	})
+
+	err := t.kbHandler.FleetAgentPolicyCreate(policy)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", urlFleetAgentPolicy, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetAgentPolicyCreate(policy)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetAgentPolicyUpdate() {
+	policy := &FleetAgentPolicy{
+		ID:                "linux",
+		Name:              "Linux servers",
+		Namespace:         "default",
+		MonitoringEnabled: []string{"logs"},
+	}
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/linux", urlFleetAgentPolicy), func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if _, ok := body["id"]; ok {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"item": {"id": "linux"}}`), nil
+	})
+
+	err := t.kbHandler.FleetAgentPolicyUpdate(policy)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/linux", urlFleetAgentPolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetAgentPolicyUpdate(policy)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetAgentPolicyDelete() {
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/delete", urlFleetAgentPolicy), func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if body["agentPolicyId"] != "linux" {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"id": "linux", "name": "Linux servers"}`), nil
+	})
+
+	err := t.kbHandler.FleetAgentPolicyDelete("linux")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/delete", urlFleetAgentPolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetAgentPolicyDelete("linux")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetAgentPolicyDiff() {
+	var actual, expected *FleetAgentPolicy
+
+	expected = &FleetAgentPolicy{
+		ID:                "linux",
+		Name:              "Linux servers",
+		Namespace:         "default",
+		MonitoringEnabled: []string{"logs", "metrics"},
+	}
+
+	// When agent policy not exist yet
+	actual = nil
+	diff, err := t.kbHandler.FleetAgentPolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When agent policy is the same, the default values are ignored
+	actual = &FleetAgentPolicy{
+		ID:                "linux",
+		Name:              "Linux servers",
+		Namespace:         "default",
+		MonitoringEnabled: []string{"logs", "metrics"},
+		InactivityTimeout: 1209600,
+	}
+	diff, err = t.kbHandler.FleetAgentPolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When agent policy is not the same
+	expected.MonitoringEnabled = []string{}
+	diff, err = t.kbHandler.FleetAgentPolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetEnrollmentTokenGet() {
+
+	rawResp := `
+	{
+		"items": [
+			{
+				"id": "key1",
+				"active": false,
+				"api_key_id": "api1",
+				"api_key": "token1",
+				"name": "Default (old)",
+				"policy_id": "linux"
+			},
+			{
+				"id": "key2",
+				"active": true,
+				"api_key_id": "api2",
+				"api_key": "token2",
+				"name": "Default",
+				"policy_id": "linux"
+			}
+		],
+		"total": 2,
+		"page": 1,
+		"perPage": 20
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlFleetEnrollmentAPIKeys, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("kuery") != `policy_id:"linux"` {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	token, err := t.kbHandler.FleetEnrollmentTokenGet("linux")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "token2", token.APIKey)
+
+	// When agent policy has no active token
+	httpmock.RegisterResponder("GET", urlFleetEnrollmentAPIKeys, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"items": [], "total": 0, "page": 1, "perPage": 20}`), nil
+	})
+	token, err = t.kbHandler.FleetEnrollmentTokenGet("linux")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), token)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlFleetEnrollmentAPIKeys, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.FleetEnrollmentTokenGet("linux")
+	assert.Error(t.T(), err)
+}
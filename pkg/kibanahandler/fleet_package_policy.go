@@ -0,0 +1,144 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// FleetPackagePolicy is the Fleet package policy object, on simplified format
+type FleetPackagePolicy struct {
+	ID          string                    `json:"id,omitempty"`
+	Name        string                    `json:"name"`
+	Namespace   string                    `json:"namespace,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	PolicyID    string                    `json:"policy_id"`
+	Package     FleetPackagePolicyPackage `json:"package"`
+	Vars        map[string]any            `json:"vars,omitempty"`
+	Inputs      map[string]any            `json:"inputs,omitempty"`
+}
+
+// FleetPackagePolicyPackage is the package used by package policy
+type FleetPackagePolicyPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// fleetPackagePolicyResponse is the response of get package policy API
+type fleetPackagePolicyResponse struct {
+	Item FleetPackagePolicy `json:"item"`
+}
+
+// FleetPackagePolicyCreate permit to create new package policy
+func (h *KibanaHandlerImpl) FleetPackagePolicyCreate(policy *FleetPackagePolicy) (err error) {
+
+	res, err := h.do("POST", "", "/api/fleet/package_policies", policy)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add package policy %s: %s", policy.ID, res.String())
+	}
+
+	return nil
+}
+
+// FleetPackagePolicyUpdate permit to update package policy
+func (h *KibanaHandlerImpl) FleetPackagePolicyUpdate(policy *FleetPackagePolicy) (err error) {
+
+	payload := *policy
+	payload.ID = ""
+
+	res, err := h.do("PUT", "", fmt.Sprintf("/api/fleet/package_policies/%s", policy.ID), &payload)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update package policy %s: %s", policy.ID, res.String())
+	}
+
+	return nil
+}
+
+// FleetPackagePolicyDelete permit to delete package policy
+func (h *KibanaHandlerImpl) FleetPackagePolicyDelete(id string) (err error) {
+
+	res, err := h.do("DELETE", "", fmt.Sprintf("/api/fleet/package_policies/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete package policy %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted package policy %s successfully", id)
+
+	return nil
+}
+
+// FleetPackagePolicyGet permit to get package policy on simplified format
+func (h *KibanaHandlerImpl) FleetPackagePolicyGet(id string) (policy *FleetPackagePolicy, err error) {
+
+	res, err := h.do("GET", "", fmt.Sprintf("/api/fleet/package_policies/%s?format=simplified", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get package policy %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get package policy %s successfully:\n%s", id, string(res.Body))
+
+	policyResp := &fleetPackagePolicyResponse{}
+	if err = json.Unmarshal(res.Body, policyResp); err != nil {
+		return nil, err
+	}
+
+	return &policyResp.Item, nil
+}
+
+// FleetPackagePolicyDiff permit to check if 2 package policies are the same
+// Fleet return all inputs and vars of package with their default values,
+// so only the inputs and vars that are expected are compared
+func (h *KibanaHandlerImpl) FleetPackagePolicyDiff(actual, expected *FleetPackagePolicy) (diff string, err error) {
+	if actual != nil && expected != nil {
+		tmp := *actual
+		tmp.Vars, _ = keepExpectedKeys(tmp.Vars, expected.Vars).(map[string]any)
+		tmp.Inputs, _ = keepExpectedKeys(tmp.Inputs, expected.Inputs).(map[string]any)
+		if expected.Namespace == "" {
+			tmp.Namespace = ""
+		}
+		actual = &tmp
+	}
+
+	return standartDiff(actual, expected, h.log, nil)
+}
+
+// keepExpectedKeys permit to remove recursively the keys of actual map that not exist on expected map
+func keepExpectedKeys(actual, expected any) any {
+	actualMap, isActualMap := actual.(map[string]any)
+	expectedMap, isExpectedMap := expected.(map[string]any)
+	if !isActualMap || !isExpectedMap {
+		return actual
+	}
+
+	result := make(map[string]any, len(expectedMap))
+	for key, expectedValue := range expectedMap {
+		if actualValue, ok := actualMap[key]; ok {
+			result[key] = keepExpectedKeys(actualValue, expectedValue)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,247 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlFleetPackagePolicy = fmt.Sprintf("%s/api/fleet/package_policies", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestFleetPackagePolicyGet() {
+
+	rawResp := `
+	{
+		"item": {
+			"id": "linux-system",
+			"name": "linux-system",
+			"namespace": "default",
+			"description": "",
+			"policy_id": "linux",
+			"package": {
+				"name": "system",
+				"title": "System",
+				"version": "1.20.4"
+			},
+			"vars": {},
+			"inputs": {
+				"system-logfile": {
+					"enabled": true,
+					"streams": {
+						"system.auth": {
+							"enabled": true,
+							"vars": {
+								"paths": ["/var/log/auth.log*"]
+							}
+						}
+					}
+				}
+			},
+			"revision": 1
+		}
+	}
+	`
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("format") != "simplified" {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	policy, err := t.kbHandler.FleetPackagePolicyGet("linux-system")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "linux", policy.PolicyID)
+	assert.Equal(t.T(), "system", policy.Package.Name)
+	assert.NotNil(t.T(), policy.Inputs["system-logfile"])
+
+	// When package policy not exist
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	policy, err = t.kbHandler.FleetPackagePolicyGet("linux-system")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), policy)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.FleetPackagePolicyGet("linux-system")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetPackagePolicyCreate() {
+	policy := &FleetPackagePolicy{
+		ID:       "linux-system",
+		Name:     "linux-system",
+		PolicyID: "linux",
+		Package: FleetPackagePolicyPackage{
+			Name:    "system",
+			Version: "1.20.4",
+		},
+	}
+
+	httpmock.RegisterResponder("POST", urlFleetPackagePolicy, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"item": {"id": "linux-system"}}`), nil
+	})
+
+	err := t.kbHandler.FleetPackagePolicyCreate(policy)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("POST", urlFleetPackagePolicy, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetPackagePolicyCreate(policy)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetPackagePolicyUpdate() {
+	policy := &FleetPackagePolicy{
+		ID:       "linux-system",
+		Name:     "linux-system",
+		PolicyID: "linux",
+		Package: FleetPackagePolicyPackage{
+			Name:    "system",
+			Version: "1.20.4",
+		},
+	}
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body := map[string]any{}
+		if err = json.Unmarshal(b, &body); err != nil {
+			return nil, err
+		}
+		if _, ok := body["id"]; ok {
+			return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+		}
+		return httpmock.NewStringResponse(200, `{"item": {"id": "linux-system"}}`), nil
+	})
+
+	err := t.kbHandler.FleetPackagePolicyUpdate(policy)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetPackagePolicyUpdate(policy)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetPackagePolicyDelete() {
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "linux-system"}`), nil
+	})
+
+	err := t.kbHandler.FleetPackagePolicyDelete("linux-system")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/linux-system", urlFleetPackagePolicy), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.FleetPackagePolicyDelete("linux-system")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestFleetPackagePolicyDiff() {
+	var actual, expected *FleetPackagePolicy
+
+	expected = &FleetPackagePolicy{
+		ID:       "linux-system",
+		Name:     "linux-system",
+		PolicyID: "linux",
+		Package: FleetPackagePolicyPackage{
+			Name:    "system",
+			Version: "1.20.4",
+		},
+		Inputs: map[string]any{
+			"system-logfile": map[string]any{
+				"streams": map[string]any{
+					"system.auth": map[string]any{
+						"vars": map[string]any{
+							"paths": []any{"/var/log/auth.log*"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// When package policy not exist yet
+	actual = nil
+	diff, err := t.kbHandler.FleetPackagePolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When package policy is the same, the inputs and vars not expected are ignored
+	actual = &FleetPackagePolicy{
+		ID:        "linux-system",
+		Name:      "linux-system",
+		Namespace: "default",
+		PolicyID:  "linux",
+		Package: FleetPackagePolicyPackage{
+			Name:    "system",
+			Version: "1.20.4",
+		},
+		Vars: map[string]any{},
+		Inputs: map[string]any{
+			"system-logfile": map[string]any{
+				"enabled": true,
+				"streams": map[string]any{
+					"system.auth": map[string]any{
+						"enabled": true,
+						"vars": map[string]any{
+							"paths":         []any{"/var/log/auth.log*"},
+							"ignore_older":  "72h",
+							"preserve_orig": false,
+						},
+					},
+					"system.syslog": map[string]any{
+						"enabled": true,
+					},
+				},
+			},
+			"system-system/metrics": map[string]any{
+				"enabled": true,
+			},
+		},
+	}
+	diff, err = t.kbHandler.FleetPackagePolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When package policy is not the same
+	expected.Inputs["system-logfile"].(map[string]any)["enabled"] = false
+	diff, err = t.kbHandler.FleetPackagePolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	expected.Inputs["system-logfile"].(map[string]any)["enabled"] = true
+	expected.Package.Version = "1.21.0"
+	diff, err = t.kbHandler.FleetPackagePolicyDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
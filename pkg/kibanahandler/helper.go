@@ -0,0 +1,10 @@
+package kibanahandler
+
+import (
+	"github.com/disaster37/operator-elk-extra/pkg/helpers"
+	"github.com/sirupsen/logrus"
+)
+
+func standartDiff(actual, expected any, log *logrus.Entry, ignore map[string]any) (diff string, err error) {
+	return helpers.StandardDiff(actual, expected, log, ignore)
+}
@@ -0,0 +1,187 @@
+package kibanahandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+type KibanaHandler interface {
+	// Space scope
+	SpaceCreate(space *KibanaSpace) (err error)
+	SpaceUpdate(space *KibanaSpace) (err error)
+	SpaceDelete(id string) (err error)
+	SpaceGet(id string) (space *KibanaSpace, err error)
+	SpaceDiff(actual, expected *KibanaSpace) (diff string, err error)
+
+	// Role scope
+	RoleUpdate(name string, role *KibanaRole) (err error)
+	RoleDelete(name string) (err error)
+	RoleGet(name string) (role *KibanaRole, err error)
+	RoleDiff(actual, expected *KibanaRole) (diff string, err error)
+
+	// Saved objects scope
+	SavedObjectsImport(space string, ndjson []byte) (result *SavedObjectsImportResponse, err error)
+	SavedObjectDelete(space, objectType, id string) (err error)
+
+	// Data view scope
+	DataViewCreate(space string, dataView *KibanaDataView) (err error)
+	DataViewUpdate(space string, dataView *KibanaDataView) (err error)
+	DataViewDelete(space, id string) (err error)
+	DataViewGet(space, id string) (dataView *KibanaDataView, err error)
+	DataViewDiff(actual, expected *KibanaDataView) (diff string, err error)
+	DataViewGetDefault(space string) (id string, err error)
+	DataViewSetDefault(space, id string) (err error)
+
+	// Connector scope
+	ConnectorCreate(space string, connector *KibanaConnector) (err error)
+	ConnectorUpdate(space string, connector *KibanaConnector) (err error)
+	ConnectorDelete(space, id string) (err error)
+	ConnectorGet(space, id string) (connector *KibanaConnector, err error)
+	ConnectorDiff(actual, expected *KibanaConnector) (diff string, err error)
+
+	// Alert rule scope
+	AlertRuleCreate(space string, rule *KibanaAlertRule) (err error)
+	AlertRuleUpdate(space string, rule *KibanaAlertRule) (err error)
+	AlertRuleDelete(space, id string) (err error)
+	AlertRuleGet(space, id string) (rule *KibanaAlertRuleInfo, err error)
+	AlertRuleDiff(actual, expected *KibanaAlertRule) (diff string, err error)
+
+	// Fleet agent policy scope
+	FleetAgentPolicyCreate(policy *FleetAgentPolicy) (err error)
+	FleetAgentPolicyUpdate(policy *FleetAgentPolicy) (err error)
+	FleetAgentPolicyDelete(id string) (err error)
+	FleetAgentPolicyGet(id string) (policy *FleetAgentPolicy, err error)
+	FleetAgentPolicyDiff(actual, expected *FleetAgentPolicy) (diff string, err error)
+	FleetEnrollmentTokenGet(policyID string) (token *FleetEnrollmentAPIKey, err error)
+
+	// Fleet package policy scope
+	FleetPackagePolicyCreate(policy *FleetPackagePolicy) (err error)
+	FleetPackagePolicyUpdate(policy *FleetPackagePolicy) (err error)
+	FleetPackagePolicyDelete(id string) (err error)
+	FleetPackagePolicyGet(id string) (policy *FleetPackagePolicy, err error)
+	FleetPackagePolicyDiff(actual, expected *FleetPackagePolicy) (diff string, err error)
+
+	SetLogger(log *logrus.Entry)
+}
+
+// Config is the setting to connect on Kibana API
+type Config struct {
+	Addresses []string
+	Username  string
+	Password  string
+	Transport http.RoundTripper
+}
+
+type KibanaHandlerImpl struct {
+	client   *http.Client
+	address  string
+	username string
+	password string
+	log      *logrus.Entry
+}
+
+// response is the raw response of Kibana API
+type response struct {
+	StatusCode int
+	Body       []byte
+}
+
+func NewKibanaHandler(cfg Config, log *logrus.Entry) (KibanaHandler, error) {
+
+	if len(cfg.Addresses) == 0 {
+		return nil, errors.New("You need to provide at least one Kibana address")
+	}
+
+	return &KibanaHandlerImpl{
+		client: &http.Client{
+			Transport: cfg.Transport,
+		},
+		address:  strings.TrimSuffix(cfg.Addresses[0], "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+		log:      log,
+	}, nil
+}
+
+func (h *KibanaHandlerImpl) SetLogger(log *logrus.Entry) {
+	h.log = log
+}
+
+// IsError return true if Kibana API return error
+func (r *response) IsError() bool {
+	return r.StatusCode > 299
+}
+
+// String return the status code and body of response
+func (r *response) String() string {
+	return fmt.Sprintf("[%d] %s", r.StatusCode, string(r.Body))
+}
+
+// newRequest permit to init request on Kibana API
+// When space is provided, the API is called on this space
+func (h *KibanaHandlerImpl) newRequest(method, space, path string, body io.Reader) (req *http.Request, err error) {
+	url := h.address
+	if space != "" && space != "default" {
+		url = fmt.Sprintf("%s/s/%s", url, space)
+	}
+	url = url + path
+
+	req, err = http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("kbn-xsrf", "true")
+	req.Header.Set("Content-Type", "application/json")
+	if h.username != "" {
+		req.SetBasicAuth(h.username, h.password)
+	}
+
+	return req, nil
+}
+
+// send permit to call Kibana API and read the response
+func (h *KibanaHandlerImpl) send(req *http.Request) (res *response, err error) {
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		StatusCode: resp.StatusCode,
+		Body:       b,
+	}, nil
+}
+
+// do permit to call Kibana API with JSON payload
+// The payload is optional
+func (h *KibanaHandlerImpl) do(method, space, path string, payload any) (res *response, err error) {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := h.newRequest(method, space, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.send(req)
+}
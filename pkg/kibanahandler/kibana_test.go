@@ -0,0 +1,46 @@
+package kibanahandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func (t *KibanaHandlerTestSuite) TestDo() {
+	h := t.kbHandler.(*KibanaHandlerImpl)
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/status", baseURL), func(req *http.Request) (*http.Response, error) {
+		user, password, ok := req.BasicAuth()
+		assert.True(t.T(), ok)
+		assert.Equal(t.T(), "elastic", user)
+		assert.Equal(t.T(), "changeme", password)
+		assert.Equal(t.T(), "true", req.Header.Get("kbn-xsrf"))
+		return httpmock.NewStringResponse(200, `{"status": "ok"}`), nil
+	})
+
+	res, err := h.do("GET", "", "/api/status", nil)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.False(t.T(), res.IsError())
+	assert.Equal(t.T(), `{"status": "ok"}`, string(res.Body))
+
+	// When call API on space
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/s/test/api/status", baseURL), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404}`), nil
+	})
+	res, err = h.do("GET", "test", "/api/status", nil)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.True(t.T(), res.IsError())
+	assert.Equal(t.T(), 404, res.StatusCode)
+
+	// When error
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/status", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = h.do("GET", "", "/api/status", nil)
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,103 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaRole is the role object managed by Kibana
+type KibanaRole struct {
+	Metadata      map[string]any           `json:"metadata,omitempty"`
+	Elasticsearch KibanaRoleElasticsearch  `json:"elasticsearch"`
+	Kibana        []KibanaRoleKibanaAccess `json:"kibana,omitempty"`
+}
+
+// KibanaRoleElasticsearch is the Elasticsearch privileges of the role
+type KibanaRoleElasticsearch struct {
+	Cluster []string                       `json:"cluster,omitempty"`
+	Indices []KibanaRoleIndicesPermissions `json:"indices,omitempty"`
+	RunAs   []string                       `json:"run_as,omitempty"`
+}
+
+// KibanaRoleIndicesPermissions is the indices permission object
+type KibanaRoleIndicesPermissions struct {
+	Names         []string       `json:"names"`
+	Privileges    []string       `json:"privileges"`
+	FieldSecurity map[string]any `json:"field_security,omitempty"`
+	Query         string         `json:"query,omitempty"`
+}
+
+// KibanaRoleKibanaAccess is the Kibana privileges of the role on list of spaces
+// Base and Feature are mutually exclusive
+type KibanaRoleKibanaAccess struct {
+	Base    []string            `json:"base"`
+	Feature map[string][]string `json:"feature"`
+	Spaces  []string            `json:"spaces"`
+}
+
+// RoleUpdate permit to create or update role
+func (h *KibanaHandlerImpl) RoleUpdate(name string, role *KibanaRole) (err error) {
+
+	res, err := h.do("PUT", "", fmt.Sprintf("/api/security/role/%s", name), role)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add role %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// RoleDelete permit to delete role
+func (h *KibanaHandlerImpl) RoleDelete(name string) (err error) {
+
+	res, err := h.do("DELETE", "", fmt.Sprintf("/api/security/role/%s", name), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete role %s: %s", name, res.String())
+	}
+
+	h.log.Infof("Deleted role %s successfully", name)
+
+	return nil
+}
+
+// RoleGet permit to get role
+func (h *KibanaHandlerImpl) RoleGet(name string) (role *KibanaRole, err error) {
+
+	res, err := h.do("GET", "", fmt.Sprintf("/api/security/role/%s", name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get role %s: %s", name, res.String())
+	}
+
+	h.log.Debugf("Get role %s successfully:\n%s", name, string(res.Body))
+
+	role = &KibanaRole{}
+	if err = json.Unmarshal(res.Body, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// RoleDiff permit to check if 2 roles are the same
+func (h *KibanaHandlerImpl) RoleDiff(actual, expected *KibanaRole) (diff string, err error) {
+	return standartDiff(actual, expected, h.log, nil)
+}
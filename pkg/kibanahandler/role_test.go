@@ -0,0 +1,189 @@
+package kibanahandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlRole = fmt.Sprintf("%s/api/security/role/my_kibana_role", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestRoleGet() {
+
+	rawResp := `
+	{
+		"name": "my_kibana_role",
+		"metadata": {
+			"version": 1
+		},
+		"transient_metadata": {
+			"enabled": true
+		},
+		"elasticsearch": {
+			"indices": [
+				{
+					"names": ["logstash-*"],
+					"privileges": ["read", "view_index_metadata"],
+					"allow_restricted_indices": false
+				}
+			],
+			"cluster": ["monitor"],
+			"run_as": []
+		},
+		"kibana": [
+			{
+				"base": [],
+				"feature": {
+					"discover": ["all"],
+					"dashboard": ["read"]
+				},
+				"spaces": ["marketing"]
+			},
+			{
+				"base": ["read"],
+				"feature": {},
+				"spaces": ["default"]
+			}
+		],
+		"_transform_error": [],
+		"_unrecognized_applications": []
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlRole, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	role, err := t.kbHandler.RoleGet("my_kibana_role")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), []string{"monitor"}, role.Elasticsearch.Cluster)
+	assert.Equal(t.T(), []string{"logstash-*"}, role.Elasticsearch.Indices[0].Names)
+	assert.Equal(t.T(), []string{"all"}, role.Kibana[0].Feature["discover"])
+	assert.Equal(t.T(), []string{"read"}, role.Kibana[1].Base)
+
+	// When role not exist
+	httpmock.RegisterResponder("GET", urlRole, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	role, err = t.kbHandler.RoleGet("my_kibana_role")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), role)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlRole, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.RoleGet("my_kibana_role")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestRoleUpdate() {
+	role := &KibanaRole{
+		Elasticsearch: KibanaRoleElasticsearch{
+			Cluster: []string{"monitor"},
+		},
+		Kibana: []KibanaRoleKibanaAccess{
+			{
+				Base:    []string{"read"},
+				Feature: map[string][]string{},
+				Spaces:  []string{"default"},
+			},
+		},
+	}
+
+	httpmock.RegisterResponder("PUT", urlRole, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.RoleUpdate("my_kibana_role", role)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When bad request
+	httpmock.RegisterResponder("PUT", urlRole, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+	})
+	err = t.kbHandler.RoleUpdate("my_kibana_role", role)
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlRole, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.RoleUpdate("my_kibana_role", role)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestRoleDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlRole, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.RoleDelete("my_kibana_role")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlRole, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.RoleDelete("my_kibana_role")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestRoleDiff() {
+	var actual, expected *KibanaRole
+
+	expected = &KibanaRole{
+		Elasticsearch: KibanaRoleElasticsearch{
+			Cluster: []string{"monitor"},
+		},
+		Kibana: []KibanaRoleKibanaAccess{
+			{
+				Base:    []string{"read"},
+				Feature: map[string][]string{},
+				Spaces:  []string{"default"},
+			},
+		},
+	}
+
+	// When role not exist yet
+	actual = nil
+	diff, err := t.kbHandler.RoleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When role is the same
+	actual = &KibanaRole{
+		Elasticsearch: KibanaRoleElasticsearch{
+			Cluster: []string{"monitor"},
+			RunAs:   []string{},
+		},
+		Kibana: []KibanaRoleKibanaAccess{
+			{
+				Base:    []string{"read"},
+				Feature: map[string][]string{},
+				Spaces:  []string{"default"},
+			},
+		},
+	}
+	diff, err = t.kbHandler.RoleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When role is not the same
+	expected.Kibana[0].Base = []string{"all"}
+	diff, err = t.kbHandler.RoleDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
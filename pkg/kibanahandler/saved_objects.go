@@ -0,0 +1,121 @@
+package kibanahandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/pkg/errors"
+)
+
+// SavedObjectsImportResponse is the response of saved objects import API
+type SavedObjectsImportResponse struct {
+	Success        bool                       `json:"success"`
+	SuccessCount   int64                      `json:"successCount"`
+	SuccessResults []SavedObjectImportSuccess `json:"successResults,omitempty"`
+	Errors         []SavedObjectImportError   `json:"errors,omitempty"`
+}
+
+// SavedObjectImportSuccess is a saved object successfully imported
+type SavedObjectImportSuccess struct {
+	Type          string `json:"type"`
+	ID            string `json:"id"`
+	DestinationID string `json:"destinationId,omitempty"`
+}
+
+// SavedObjectImportError is a saved object that failed to be imported
+type SavedObjectImportError struct {
+	Type  string                       `json:"type"`
+	ID    string                       `json:"id"`
+	Title string                       `json:"title,omitempty"`
+	Error SavedObjectImportErrorDetail `json:"error"`
+}
+
+// SavedObjectImportErrorDetail is the reason of the import error
+type SavedObjectImportErrorDetail struct {
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message,omitempty"`
+	References []SavedObjectReference `json:"references,omitempty"`
+}
+
+// SavedObjectReference is a reference to another saved object
+type SavedObjectReference struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// String return a human readable import error
+func (e SavedObjectImportError) String() string {
+	msg := e.Error.Type
+	if e.Error.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Error.Message)
+	}
+	for _, ref := range e.Error.References {
+		msg = fmt.Sprintf("%s %s/%s", msg, ref.Type, ref.ID)
+	}
+
+	return msg
+}
+
+// SavedObjectsImport permit to import NDJSON saved objects on space
+// It always overwrite the existing objects
+func (h *KibanaHandlerImpl) SavedObjectsImport(space string, ndjson []byte) (result *SavedObjectsImportResponse, err error) {
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "export.ndjson")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = part.Write(ndjson); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := h.newRequest("POST", space, "/api/saved_objects/_import?overwrite=true", body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := h.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when import saved objects on space %s: %s", space, res.String())
+	}
+
+	h.log.Debugf("Import saved objects on space %s successfully:\n%s", space, string(res.Body))
+
+	result = &SavedObjectsImportResponse{}
+	if err = json.Unmarshal(res.Body, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SavedObjectDelete permit to delete saved object from space
+func (h *KibanaHandlerImpl) SavedObjectDelete(space, objectType, id string) (err error) {
+
+	res, err := h.do("DELETE", space, fmt.Sprintf("/api/saved_objects/%s/%s?force=true", objectType, id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete saved object %s/%s: %s", objectType, id, res.String())
+	}
+
+	h.log.Infof("Deleted saved object %s/%s successfully", objectType, id)
+
+	return nil
+}
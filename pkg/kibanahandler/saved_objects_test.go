@@ -0,0 +1,118 @@
+package kibanahandler
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlSavedObjectsImport = fmt.Sprintf("%s/s/marketing/api/saved_objects/_import", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestSavedObjectsImport() {
+	ndjson := []byte(`{"type":"index-pattern","id":"logs","attributes":{"title":"logs-*"}}
+{"type":"dashboard","id":"my-dashboard","attributes":{"title":"My dashboard"},"references":[{"type":"visualization","id":"missing","name":"panel_0"}]}
+`)
+
+	rawResp := `
+	{
+		"success": false,
+		"successCount": 1,
+		"successResults": [
+			{
+				"type": "index-pattern",
+				"id": "logs",
+				"meta": {
+					"title": "logs-*",
+					"icon": "indexPatternApp"
+				}
+			}
+		],
+		"errors": [
+			{
+				"id": "my-dashboard",
+				"type": "dashboard",
+				"title": "My dashboard",
+				"meta": {
+					"title": "My dashboard",
+					"icon": "dashboardApp"
+				},
+				"error": {
+					"type": "missing_references",
+					"references": [
+						{
+							"type": "visualization",
+							"id": "missing"
+						}
+					]
+				}
+			}
+		]
+	}
+	`
+
+	httpmock.RegisterResponder("POST", urlSavedObjectsImport, func(req *http.Request) (*http.Response, error) {
+		if req.URL.Query().Get("overwrite") != "true" {
+			return httpmock.NewStringResponse(400, "overwrite is expected"), nil
+		}
+		file, _, err := req.FormFile("file")
+		if err != nil {
+			return httpmock.NewStringResponse(400, err.Error()), nil
+		}
+		b, err := ioutil.ReadAll(file)
+		if err != nil || string(b) != string(ndjson) {
+			return httpmock.NewStringResponse(400, "bad file"), nil
+		}
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	result, err := t.kbHandler.SavedObjectsImport("marketing", ndjson)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.False(t.T(), result.Success)
+	assert.Equal(t.T(), int64(1), result.SuccessCount)
+	assert.Equal(t.T(), "logs", result.SuccessResults[0].ID)
+	assert.Equal(t.T(), "my-dashboard", result.Errors[0].ID)
+	assert.Equal(t.T(), "missing_references visualization/missing", result.Errors[0].String())
+
+	// When bad request
+	httpmock.RegisterResponder("POST", urlSavedObjectsImport, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(400, `{"statusCode": 400, "error": "Bad Request"}`), nil
+	})
+	_, err = t.kbHandler.SavedObjectsImport("marketing", ndjson)
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("POST", urlSavedObjectsImport, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.SavedObjectsImport("marketing", ndjson)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestSavedObjectDelete() {
+	urlSavedObject := fmt.Sprintf("%s/api/saved_objects/dashboard/my-dashboard", baseURL)
+
+	httpmock.RegisterResponder("DELETE", urlSavedObject, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, "{}"), nil
+	})
+
+	err := t.kbHandler.SavedObjectDelete("default", "dashboard", "my-dashboard")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When object not exist
+	httpmock.RegisterResponder("DELETE", urlSavedObject, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	err = t.kbHandler.SavedObjectDelete("default", "dashboard", "my-dashboard")
+	assert.NoError(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlSavedObject, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.SavedObjectDelete("default", "dashboard", "my-dashboard")
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,100 @@
+package kibanahandler
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// KibanaSpace is the space object
+type KibanaSpace struct {
+	ID               string   `json:"id"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description,omitempty"`
+	Color            string   `json:"color,omitempty"`
+	Initials         string   `json:"initials,omitempty"`
+	DisabledFeatures []string `json:"disabledFeatures,omitempty"`
+	ImageURL         string   `json:"imageUrl,omitempty"`
+}
+
+// SpaceCreate permit to create new space
+func (h *KibanaHandlerImpl) SpaceCreate(space *KibanaSpace) (err error) {
+
+	res, err := h.do("POST", "", "/api/spaces/space", space)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when add space %s: %s", space.ID, res.String())
+	}
+
+	return nil
+}
+
+// SpaceUpdate permit to update space
+func (h *KibanaHandlerImpl) SpaceUpdate(space *KibanaSpace) (err error) {
+
+	res, err := h.do("PUT", "", fmt.Sprintf("/api/spaces/space/%s", space.ID), space)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return errors.Errorf("Error when update space %s: %s", space.ID, res.String())
+	}
+
+	return nil
+}
+
+// SpaceDelete permit to delete space
+// All saved objects of the space are deleted too
+func (h *KibanaHandlerImpl) SpaceDelete(id string) (err error) {
+
+	res, err := h.do("DELETE", "", fmt.Sprintf("/api/spaces/space/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete space %s: %s", id, res.String())
+	}
+
+	h.log.Infof("Deleted space %s successfully", id)
+
+	return nil
+}
+
+// SpaceGet permit to get space
+func (h *KibanaHandlerImpl) SpaceGet(id string) (space *KibanaSpace, err error) {
+
+	res, err := h.do("GET", "", fmt.Sprintf("/api/spaces/space/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get space %s: %s", id, res.String())
+	}
+
+	h.log.Debugf("Get space %s successfully:\n%s", id, string(res.Body))
+
+	space = &KibanaSpace{}
+	if err = json.Unmarshal(res.Body, space); err != nil {
+		return nil, err
+	}
+
+	return space, nil
+}
+
+// SpaceDiff permit to check if 2 spaces are the same
+func (h *KibanaHandlerImpl) SpaceDiff(actual, expected *KibanaSpace) (diff string, err error) {
+	return standartDiff(actual, expected, h.log, nil)
+}
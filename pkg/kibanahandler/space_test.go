@@ -0,0 +1,157 @@
+package kibanahandler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var urlSpace = fmt.Sprintf("%s/api/spaces/space/marketing", baseURL)
+
+func (t *KibanaHandlerTestSuite) TestSpaceGet() {
+
+	rawResp := `
+	{
+		"id": "marketing",
+		"name": "Marketing",
+		"description" : "This is the Marketing Space",
+		"color": "#aabbcc",
+		"initials": "MK",
+		"disabledFeatures": ["visualize"],
+		"imageUrl": ""
+	}
+	`
+
+	httpmock.RegisterResponder("GET", urlSpace, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, rawResp), nil
+	})
+
+	space, err := t.kbHandler.SpaceGet("marketing")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Equal(t.T(), "Marketing", space.Name)
+	assert.Equal(t.T(), "MK", space.Initials)
+	assert.Equal(t.T(), []string{"visualize"}, space.DisabledFeatures)
+
+	// When space not exist
+	httpmock.RegisterResponder("GET", urlSpace, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(404, `{"statusCode": 404, "error": "Not Found"}`), nil
+	})
+	space, err = t.kbHandler.SpaceGet("marketing")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Nil(t.T(), space)
+
+	// When error
+	httpmock.RegisterResponder("GET", urlSpace, httpmock.NewErrorResponder(errors.New("fack error")))
+	_, err = t.kbHandler.SpaceGet("marketing")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestSpaceCreate() {
+	space := &KibanaSpace{
+		ID:   "marketing",
+		Name: "Marketing",
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/spaces/space", baseURL), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "marketing", "name": "Marketing"}`), nil
+	})
+
+	err := t.kbHandler.SpaceCreate(space)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When space already exist
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/spaces/space", baseURL), func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(409, `{"statusCode": 409, "error": "Conflict"}`), nil
+	})
+	err = t.kbHandler.SpaceCreate(space)
+	assert.Error(t.T(), err)
+
+	// When error
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/spaces/space", baseURL), httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.SpaceCreate(space)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestSpaceUpdate() {
+	space := &KibanaSpace{
+		ID:   "marketing",
+		Name: "Marketing",
+	}
+
+	httpmock.RegisterResponder("PUT", urlSpace, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(200, `{"id": "marketing", "name": "Marketing"}`), nil
+	})
+
+	err := t.kbHandler.SpaceUpdate(space)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("PUT", urlSpace, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.SpaceUpdate(space)
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestSpaceDelete() {
+
+	httpmock.RegisterResponder("DELETE", urlSpace, func(req *http.Request) (*http.Response, error) {
+		return httpmock.NewStringResponse(204, ""), nil
+	})
+
+	err := t.kbHandler.SpaceDelete("marketing")
+	if err != nil {
+		t.Fail(err.Error())
+	}
+
+	// When error
+	httpmock.RegisterResponder("DELETE", urlSpace, httpmock.NewErrorResponder(errors.New("fack error")))
+	err = t.kbHandler.SpaceDelete("marketing")
+	assert.Error(t.T(), err)
+}
+
+func (t *KibanaHandlerTestSuite) TestSpaceDiff() {
+	var actual, expected *KibanaSpace
+
+	expected = &KibanaSpace{
+		ID:   "marketing",
+		Name: "Marketing",
+	}
+
+	// When space not exist yet
+	actual = nil
+	diff, err := t.kbHandler.SpaceDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+
+	// When space is the same
+	actual = &KibanaSpace{
+		ID:               "marketing",
+		Name:             "Marketing",
+		DisabledFeatures: []string{},
+	}
+	diff, err = t.kbHandler.SpaceDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.Empty(t.T(), diff)
+
+	// When space is not the same
+	expected.Description = "This is the Marketing Space"
+	diff, err = t.kbHandler.SpaceDiff(actual, expected)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+	assert.NotEmpty(t.T(), diff)
+}
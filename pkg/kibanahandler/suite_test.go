@@ -0,0 +1,41 @@
+package kibanahandler
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+const baseURL = "http://localhost:5601"
+
+type KibanaHandlerTestSuite struct {
+	suite.Suite
+	kbHandler KibanaHandler
+}
+
+func TestKibanaHandlerSuite(t *testing.T) {
+	suite.Run(t, new(KibanaHandlerTestSuite))
+}
+
+func (t *KibanaHandlerTestSuite) SetupTest() {
+
+	cfg := Config{
+		Addresses: []string{baseURL},
+		Username:  "elastic",
+		Password:  "changeme",
+		Transport: httpmock.DefaultTransport,
+	}
+	kbHandler, err := NewKibanaHandler(cfg, logrus.NewEntry(logrus.New()))
+	if err != nil {
+		panic(err)
+	}
+	t.kbHandler = kbHandler
+
+	httpmock.Activate()
+}
+
+func (t *KibanaHandlerTestSuite) BeforeTest(suiteName, testName string) {
+	httpmock.Reset()
+}
@@ -36,6 +36,64 @@ func (m *MockElasticsearchHandler) EXPECT() *MockElasticsearchHandlerMockRecorde
 	return m.recorder
 }
 
+// ApplicationPrivilegeDelete mocks base method.
+func (m *MockElasticsearchHandler) ApplicationPrivilegeDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationPrivilegeDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationPrivilegeDelete indicates an expected call of ApplicationPrivilegeDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) ApplicationPrivilegeDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationPrivilegeDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).ApplicationPrivilegeDelete), arg0, arg1)
+}
+
+// ApplicationPrivilegeDiff mocks base method.
+func (m *MockElasticsearchHandler) ApplicationPrivilegeDiff(arg0, arg1 *elasticsearchhandler.XPackSecurityApplicationPrivilege) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationPrivilegeDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationPrivilegeDiff indicates an expected call of ApplicationPrivilegeDiff.
+func (mr *MockElasticsearchHandlerMockRecorder) ApplicationPrivilegeDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationPrivilegeDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).ApplicationPrivilegeDiff), arg0, arg1)
+}
+
+// ApplicationPrivilegeGet mocks base method.
+func (m *MockElasticsearchHandler) ApplicationPrivilegeGet(arg0, arg1 string) (*elasticsearchhandler.XPackSecurityApplicationPrivilege, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationPrivilegeGet", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearchhandler.XPackSecurityApplicationPrivilege)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplicationPrivilegeGet indicates an expected call of ApplicationPrivilegeGet.
+func (mr *MockElasticsearchHandlerMockRecorder) ApplicationPrivilegeGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationPrivilegeGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).ApplicationPrivilegeGet), arg0, arg1)
+}
+
+// ApplicationPrivilegeUpdate mocks base method.
+func (m *MockElasticsearchHandler) ApplicationPrivilegeUpdate(arg0, arg1 string, arg2 *elasticsearchhandler.XPackSecurityApplicationPrivilege) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplicationPrivilegeUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplicationPrivilegeUpdate indicates an expected call of ApplicationPrivilegeUpdate.
+func (mr *MockElasticsearchHandlerMockRecorder) ApplicationPrivilegeUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationPrivilegeUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).ApplicationPrivilegeUpdate), arg0, arg1, arg2)
+}
+
 // ComponentTemplateDelete mocks base method.
 func (m *MockElasticsearchHandler) ComponentTemplateDelete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +181,21 @@ func (mr *MockElasticsearchHandlerMockRecorder) ILMDiff(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ILMDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).ILMDiff), arg0, arg1)
 }
 
+// ILMExplain mocks base method.
+func (m *MockElasticsearchHandler) ILMExplain(arg0 string) (*elasticsearchhandler.ILMPolicyExplain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ILMExplain", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.ILMPolicyExplain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ILMExplain indicates an expected call of ILMExplain.
+func (mr *MockElasticsearchHandlerMockRecorder) ILMExplain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ILMExplain", reflect.TypeOf((*MockElasticsearchHandler)(nil).ILMExplain), arg0)
+}
+
 // ILMGet mocks base method.
 func (m *MockElasticsearchHandler) ILMGet(arg0 string) (*elastic.XPackIlmGetLifecycleResponse, error) {
 	m.ctrl.T.Helper()
@@ -138,6 +211,20 @@ func (mr *MockElasticsearchHandlerMockRecorder) ILMGet(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ILMGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).ILMGet), arg0)
 }
 
+// ILMRetry mocks base method.
+func (m *MockElasticsearchHandler) ILMRetry(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ILMRetry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ILMRetry indicates an expected call of ILMRetry.
+func (mr *MockElasticsearchHandlerMockRecorder) ILMRetry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ILMRetry", reflect.TypeOf((*MockElasticsearchHandler)(nil).ILMRetry), arg0)
+}
+
 // ILMUpdate mocks base method.
 func (m *MockElasticsearchHandler) ILMUpdate(arg0 string, arg1 *elastic.XPackIlmGetLifecycleResponse) error {
 	m.ctrl.T.Helper()
@@ -152,6 +239,44 @@ func (mr *MockElasticsearchHandlerMockRecorder) ILMUpdate(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ILMUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).ILMUpdate), arg0, arg1)
 }
 
+// IndexRecoveryGet mocks base method.
+func (m *MockElasticsearchHandler) IndexRecoveryGet(arg0 ...string) (map[string]elasticsearchhandler.IndexRecovery, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IndexRecoveryGet", varargs...)
+	ret0, _ := ret[0].(map[string]elasticsearchhandler.IndexRecovery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexRecoveryGet indicates an expected call of IndexRecoveryGet.
+func (mr *MockElasticsearchHandlerMockRecorder) IndexRecoveryGet(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexRecoveryGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).IndexRecoveryGet), arg0...)
+}
+
+// IndexShardsGet mocks base method.
+func (m *MockElasticsearchHandler) IndexShardsGet(arg0 ...string) ([]elasticsearchhandler.IndexShard, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "IndexShardsGet", varargs...)
+	ret0, _ := ret[0].([]elasticsearchhandler.IndexShard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IndexShardsGet indicates an expected call of IndexShardsGet.
+func (mr *MockElasticsearchHandlerMockRecorder) IndexShardsGet(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IndexShardsGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).IndexShardsGet), arg0...)
+}
+
 // IndexTemplateDelete mocks base method.
 func (m *MockElasticsearchHandler) IndexTemplateDelete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -281,6 +406,351 @@ func (mr *MockElasticsearchHandlerMockRecorder) LicenseUpdate(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LicenseUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).LicenseUpdate), arg0)
 }
 
+// LogstashPipelineDelete mocks base method.
+func (m *MockElasticsearchHandler) LogstashPipelineDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogstashPipelineDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogstashPipelineDelete indicates an expected call of LogstashPipelineDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) LogstashPipelineDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogstashPipelineDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).LogstashPipelineDelete), arg0)
+}
+
+// LogstashPipelineDiff mocks base method.
+func (m *MockElasticsearchHandler) LogstashPipelineDiff(arg0, arg1 *elasticsearchhandler.LogstashPipeline) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogstashPipelineDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogstashPipelineDiff indicates an expected call of LogstashPipelineDiff.
+func (mr *MockElasticsearchHandlerMockRecorder) LogstashPipelineDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogstashPipelineDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).LogstashPipelineDiff), arg0, arg1)
+}
+
+// LogstashPipelineGet mocks base method.
+func (m *MockElasticsearchHandler) LogstashPipelineGet(arg0 string) (*elasticsearchhandler.LogstashPipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogstashPipelineGet", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.LogstashPipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogstashPipelineGet indicates an expected call of LogstashPipelineGet.
+func (mr *MockElasticsearchHandlerMockRecorder) LogstashPipelineGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogstashPipelineGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).LogstashPipelineGet), arg0)
+}
+
+// LogstashPipelineUpdate mocks base method.
+func (m *MockElasticsearchHandler) LogstashPipelineUpdate(arg0 string, arg1 *elasticsearchhandler.LogstashPipeline) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogstashPipelineUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogstashPipelineUpdate indicates an expected call of LogstashPipelineUpdate.
+func (mr *MockElasticsearchHandlerMockRecorder) LogstashPipelineUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogstashPipelineUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).LogstashPipelineUpdate), arg0, arg1)
+}
+
+// MLDatafeedCreate mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedCreate(arg0 string, arg1 *elasticsearchhandler.MLDatafeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLDatafeedCreate indicates an expected call of MLDatafeedCreate.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedCreate", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedCreate), arg0, arg1)
+}
+
+// MLDatafeedDelete mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLDatafeedDelete indicates an expected call of MLDatafeedDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedDelete), arg0)
+}
+
+// MLDatafeedDiff mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedDiff(arg0, arg1 *elasticsearchhandler.MLDatafeed) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLDatafeedDiff indicates an expected call of MLDatafeedDiff.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedDiff), arg0, arg1)
+}
+
+// MLDatafeedGet mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedGet(arg0 string) (*elasticsearchhandler.MLDatafeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedGet", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.MLDatafeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLDatafeedGet indicates an expected call of MLDatafeedGet.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedGet), arg0)
+}
+
+// MLDatafeedStart mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedStart(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedStart", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLDatafeedStart indicates an expected call of MLDatafeedStart.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedStart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedStart", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedStart), arg0)
+}
+
+// MLDatafeedStats mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedStats(arg0 string) (*elasticsearchhandler.MLDatafeedStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedStats", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.MLDatafeedStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLDatafeedStats indicates an expected call of MLDatafeedStats.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedStats", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedStats), arg0)
+}
+
+// MLDatafeedStop mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedStop(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedStop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLDatafeedStop indicates an expected call of MLDatafeedStop.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedStop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedStop", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedStop), arg0)
+}
+
+// MLDatafeedUpdate mocks base method.
+func (m *MockElasticsearchHandler) MLDatafeedUpdate(arg0 string, arg1 *elasticsearchhandler.MLDatafeed) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLDatafeedUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLDatafeedUpdate indicates an expected call of MLDatafeedUpdate.
+func (mr *MockElasticsearchHandlerMockRecorder) MLDatafeedUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLDatafeedUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLDatafeedUpdate), arg0, arg1)
+}
+
+// MLJobClose mocks base method.
+func (m *MockElasticsearchHandler) MLJobClose(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLJobClose indicates an expected call of MLJobClose.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobClose", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobClose), arg0)
+}
+
+// MLJobCreate mocks base method.
+func (m *MockElasticsearchHandler) MLJobCreate(arg0 string, arg1 *elasticsearchhandler.MLJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLJobCreate indicates an expected call of MLJobCreate.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobCreate", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobCreate), arg0, arg1)
+}
+
+// MLJobDelete mocks base method.
+func (m *MockElasticsearchHandler) MLJobDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLJobDelete indicates an expected call of MLJobDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobDelete), arg0)
+}
+
+// MLJobDiff mocks base method.
+func (m *MockElasticsearchHandler) MLJobDiff(arg0, arg1 *elasticsearchhandler.MLJob) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLJobDiff indicates an expected call of MLJobDiff.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobDiff), arg0, arg1)
+}
+
+// MLJobGet mocks base method.
+func (m *MockElasticsearchHandler) MLJobGet(arg0 string) (*elasticsearchhandler.MLJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobGet", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.MLJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLJobGet indicates an expected call of MLJobGet.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobGet), arg0)
+}
+
+// MLJobOpen mocks base method.
+func (m *MockElasticsearchHandler) MLJobOpen(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobOpen", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLJobOpen indicates an expected call of MLJobOpen.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobOpen(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobOpen", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobOpen), arg0)
+}
+
+// MLJobStats mocks base method.
+func (m *MockElasticsearchHandler) MLJobStats(arg0 string) (*elasticsearchhandler.MLJobStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobStats", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.MLJobStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MLJobStats indicates an expected call of MLJobStats.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobStats", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobStats), arg0)
+}
+
+// MLJobUpdate mocks base method.
+func (m *MockElasticsearchHandler) MLJobUpdate(arg0 string, arg1 *elasticsearchhandler.MLJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MLJobUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MLJobUpdate indicates an expected call of MLJobUpdate.
+func (mr *MockElasticsearchHandlerMockRecorder) MLJobUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MLJobUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).MLJobUpdate), arg0, arg1)
+}
+
+// MountedIndexCreate mocks base method.
+func (m *MockElasticsearchHandler) MountedIndexCreate(arg0, arg1, arg2 string, arg3 *elasticsearchhandler.MountedIndex) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MountedIndexCreate", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MountedIndexCreate indicates an expected call of MountedIndexCreate.
+func (mr *MockElasticsearchHandlerMockRecorder) MountedIndexCreate(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MountedIndexCreate", reflect.TypeOf((*MockElasticsearchHandler)(nil).MountedIndexCreate), arg0, arg1, arg2, arg3)
+}
+
+// MountedIndexDelete mocks base method.
+func (m *MockElasticsearchHandler) MountedIndexDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MountedIndexDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MountedIndexDelete indicates an expected call of MountedIndexDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) MountedIndexDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MountedIndexDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).MountedIndexDelete), arg0)
+}
+
+// MountedIndexGet mocks base method.
+func (m *MockElasticsearchHandler) MountedIndexGet(arg0 string) (*elasticsearchhandler.MountedIndexInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MountedIndexGet", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.MountedIndexInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MountedIndexGet indicates an expected call of MountedIndexGet.
+func (mr *MockElasticsearchHandlerMockRecorder) MountedIndexGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MountedIndexGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).MountedIndexGet), arg0)
+}
+
+// RestoreStart mocks base method.
+func (m *MockElasticsearchHandler) RestoreStart(arg0, arg1 string, arg2 *elasticsearchhandler.Restore) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreStart", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreStart indicates an expected call of RestoreStart.
+func (mr *MockElasticsearchHandlerMockRecorder) RestoreStart(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreStart", reflect.TypeOf((*MockElasticsearchHandler)(nil).RestoreStart), arg0, arg1, arg2)
+}
+
 // RoleDelete mocks base method.
 func (m *MockElasticsearchHandler) RoleDelete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -340,7 +810,7 @@ func (mr *MockElasticsearchHandlerMockRecorder) RoleMappingDelete(arg0 interface
 }
 
 // RoleMappingDiff mocks base method.
-func (m *MockElasticsearchHandler) RoleMappingDiff(arg0, arg1 *elastic.XPackSecurityRoleMapping) (string, error) {
+func (m *MockElasticsearchHandler) RoleMappingDiff(arg0, arg1 *elasticsearchhandler.XPackSecurityRoleMapping) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RoleMappingDiff", arg0, arg1)
 	ret0, _ := ret[0].(string)
@@ -355,10 +825,10 @@ func (mr *MockElasticsearchHandlerMockRecorder) RoleMappingDiff(arg0, arg1 inter
 }
 
 // RoleMappingGet mocks base method.
-func (m *MockElasticsearchHandler) RoleMappingGet(arg0 string) (*elastic.XPackSecurityRoleMapping, error) {
+func (m *MockElasticsearchHandler) RoleMappingGet(arg0 string) (*elasticsearchhandler.XPackSecurityRoleMapping, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RoleMappingGet", arg0)
-	ret0, _ := ret[0].(*elastic.XPackSecurityRoleMapping)
+	ret0, _ := ret[0].(*elasticsearchhandler.XPackSecurityRoleMapping)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -370,7 +840,7 @@ func (mr *MockElasticsearchHandlerMockRecorder) RoleMappingGet(arg0 interface{})
 }
 
 // RoleMappingUpdate mocks base method.
-func (m *MockElasticsearchHandler) RoleMappingUpdate(arg0 string, arg1 *elastic.XPackSecurityRoleMapping) error {
+func (m *MockElasticsearchHandler) RoleMappingUpdate(arg0 string, arg1 *elasticsearchhandler.XPackSecurityRoleMapping) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RoleMappingUpdate", arg0, arg1)
 	ret0, _ := ret[0].(error)
@@ -426,6 +896,35 @@ func (mr *MockElasticsearchHandlerMockRecorder) SLMDiff(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SLMDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).SLMDiff), arg0, arg1)
 }
 
+// SLMExecute mocks base method.
+func (m *MockElasticsearchHandler) SLMExecute(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SLMExecute", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SLMExecute indicates an expected call of SLMExecute.
+func (mr *MockElasticsearchHandlerMockRecorder) SLMExecute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SLMExecute", reflect.TypeOf((*MockElasticsearchHandler)(nil).SLMExecute), arg0)
+}
+
+// SLMExecuteRetention mocks base method.
+func (m *MockElasticsearchHandler) SLMExecuteRetention() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SLMExecuteRetention")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SLMExecuteRetention indicates an expected call of SLMExecuteRetention.
+func (mr *MockElasticsearchHandlerMockRecorder) SLMExecuteRetention() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SLMExecuteRetention", reflect.TypeOf((*MockElasticsearchHandler)(nil).SLMExecuteRetention))
+}
+
 // SLMGet mocks base method.
 func (m *MockElasticsearchHandler) SLMGet(arg0 string) (*elasticsearchhandler.SnapshotLifecyclePolicySpec, error) {
 	m.ctrl.T.Helper()
@@ -441,6 +940,21 @@ func (mr *MockElasticsearchHandlerMockRecorder) SLMGet(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SLMGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).SLMGet), arg0)
 }
 
+// SLMGetStatus mocks base method.
+func (m *MockElasticsearchHandler) SLMGetStatus(arg0 string) (*elasticsearchhandler.SnapshotLifecyclePolicyStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SLMGetStatus", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.SnapshotLifecyclePolicyStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SLMGetStatus indicates an expected call of SLMGetStatus.
+func (mr *MockElasticsearchHandlerMockRecorder) SLMGetStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SLMGetStatus", reflect.TypeOf((*MockElasticsearchHandler)(nil).SLMGetStatus), arg0)
+}
+
 // SLMUpdate mocks base method.
 func (m *MockElasticsearchHandler) SLMUpdate(arg0 string, arg1 *elasticsearchhandler.SnapshotLifecyclePolicySpec) error {
 	m.ctrl.T.Helper()
@@ -467,6 +981,64 @@ func (mr *MockElasticsearchHandlerMockRecorder) SetLogger(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockElasticsearchHandler)(nil).SetLogger), arg0)
 }
 
+// SnapshotCreate mocks base method.
+func (m *MockElasticsearchHandler) SnapshotCreate(arg0, arg1 string, arg2 *elasticsearchhandler.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotCreate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotCreate indicates an expected call of SnapshotCreate.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotCreate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotCreate", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotCreate), arg0, arg1, arg2)
+}
+
+// SnapshotDelete mocks base method.
+func (m *MockElasticsearchHandler) SnapshotDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotDelete indicates an expected call of SnapshotDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotDelete), arg0, arg1)
+}
+
+// SnapshotGet mocks base method.
+func (m *MockElasticsearchHandler) SnapshotGet(arg0, arg1 string) (*elasticsearchhandler.SnapshotInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotGet", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearchhandler.SnapshotInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotGet indicates an expected call of SnapshotGet.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotGet), arg0, arg1)
+}
+
+// SnapshotRepositoryCleanup mocks base method.
+func (m *MockElasticsearchHandler) SnapshotRepositoryCleanup(arg0 string) (*elasticsearchhandler.SnapshotRepositoryCleanupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotRepositoryCleanup", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.SnapshotRepositoryCleanupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotRepositoryCleanup indicates an expected call of SnapshotRepositoryCleanup.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotRepositoryCleanup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotRepositoryCleanup", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotRepositoryCleanup), arg0)
+}
+
 // SnapshotRepositoryDelete mocks base method.
 func (m *MockElasticsearchHandler) SnapshotRepositoryDelete(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -525,6 +1097,179 @@ func (mr *MockElasticsearchHandlerMockRecorder) SnapshotRepositoryUpdate(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotRepositoryUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotRepositoryUpdate), arg0, arg1)
 }
 
+// SnapshotRepositoryVerify mocks base method.
+func (m *MockElasticsearchHandler) SnapshotRepositoryVerify(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotRepositoryVerify", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotRepositoryVerify indicates an expected call of SnapshotRepositoryVerify.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotRepositoryVerify(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotRepositoryVerify", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotRepositoryVerify), arg0)
+}
+
+// SnapshotStatus mocks base method.
+func (m *MockElasticsearchHandler) SnapshotStatus(arg0, arg1 string) (*elasticsearchhandler.SnapshotStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotStatus", arg0, arg1)
+	ret0, _ := ret[0].(*elasticsearchhandler.SnapshotStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SnapshotStatus indicates an expected call of SnapshotStatus.
+func (mr *MockElasticsearchHandlerMockRecorder) SnapshotStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotStatus", reflect.TypeOf((*MockElasticsearchHandler)(nil).SnapshotStatus), arg0, arg1)
+}
+
+// TransformCreate mocks base method.
+func (m *MockElasticsearchHandler) TransformCreate(arg0 string, arg1 *elasticsearchhandler.Transform) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformCreate indicates an expected call of TransformCreate.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformCreate", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformCreate), arg0, arg1)
+}
+
+// TransformDelete mocks base method.
+func (m *MockElasticsearchHandler) TransformDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformDelete indicates an expected call of TransformDelete.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformDelete", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformDelete), arg0)
+}
+
+// TransformDiff mocks base method.
+func (m *MockElasticsearchHandler) TransformDiff(arg0, arg1 *elasticsearchhandler.Transform) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransformDiff indicates an expected call of TransformDiff.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformDiff), arg0, arg1)
+}
+
+// TransformGet mocks base method.
+func (m *MockElasticsearchHandler) TransformGet(arg0 string) (*elasticsearchhandler.Transform, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformGet", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.Transform)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransformGet indicates an expected call of TransformGet.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformGet", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformGet), arg0)
+}
+
+// TransformStart mocks base method.
+func (m *MockElasticsearchHandler) TransformStart(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformStart", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformStart indicates an expected call of TransformStart.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformStart(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformStart", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformStart), arg0)
+}
+
+// TransformStats mocks base method.
+func (m *MockElasticsearchHandler) TransformStats(arg0 string) (*elasticsearchhandler.TransformStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformStats", arg0)
+	ret0, _ := ret[0].(*elasticsearchhandler.TransformStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransformStats indicates an expected call of TransformStats.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformStats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformStats", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformStats), arg0)
+}
+
+// TransformForceStop mocks base method.
+func (m *MockElasticsearchHandler) TransformForceStop(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformForceStop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformForceStop indicates an expected call of TransformForceStop.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformForceStop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformForceStop", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformForceStop), arg0)
+}
+
+// TransformStop mocks base method.
+func (m *MockElasticsearchHandler) TransformStop(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformStop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformStop indicates an expected call of TransformStop.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformStop(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformStop", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformStop), arg0)
+}
+
+// TransformUpdate mocks base method.
+func (m *MockElasticsearchHandler) TransformUpdate(arg0 string, arg1 *elasticsearchhandler.Transform) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransformUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransformUpdate indicates an expected call of TransformUpdate.
+func (mr *MockElasticsearchHandlerMockRecorder) TransformUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransformUpdate", reflect.TypeOf((*MockElasticsearchHandler)(nil).TransformUpdate), arg0, arg1)
+}
+
+// UserChangePassword mocks base method.
+func (m *MockElasticsearchHandler) UserChangePassword(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserChangePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserChangePassword indicates an expected call of UserChangePassword.
+func (mr *MockElasticsearchHandlerMockRecorder) UserChangePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserChangePassword", reflect.TypeOf((*MockElasticsearchHandler)(nil).UserChangePassword), arg0, arg1, arg2)
+}
+
 // UserCreate mocks base method.
 func (m *MockElasticsearchHandler) UserCreate(arg0 string, arg1 *elastic.XPackSecurityPutUserRequest) error {
 	m.ctrl.T.Helper()
@@ -568,6 +1313,34 @@ func (mr *MockElasticsearchHandlerMockRecorder) UserDiff(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDiff", reflect.TypeOf((*MockElasticsearchHandler)(nil).UserDiff), arg0, arg1)
 }
 
+// UserDisable mocks base method.
+func (m *MockElasticsearchHandler) UserDisable(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDisable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDisable indicates an expected call of UserDisable.
+func (mr *MockElasticsearchHandlerMockRecorder) UserDisable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDisable", reflect.TypeOf((*MockElasticsearchHandler)(nil).UserDisable), arg0)
+}
+
+// UserEnable mocks base method.
+func (m *MockElasticsearchHandler) UserEnable(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserEnable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserEnable indicates an expected call of UserEnable.
+func (mr *MockElasticsearchHandlerMockRecorder) UserEnable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserEnable", reflect.TypeOf((*MockElasticsearchHandler)(nil).UserEnable), arg0)
+}
+
 // UserGet mocks base method.
 func (m *MockElasticsearchHandler) UserGet(arg0 string) (*elastic.XPackSecurityUser, error) {
 	m.ctrl.T.Helper()
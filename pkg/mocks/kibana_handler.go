@@ -0,0 +1,611 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/disaster37/operator-elk-extra/pkg/kibanahandler (interfaces: KibanaHandler)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	kibanahandler "github.com/disaster37/operator-elk-extra/pkg/kibanahandler"
+	gomock "github.com/golang/mock/gomock"
+	logrus "github.com/sirupsen/logrus"
+)
+
+// MockKibanaHandler is a mock of KibanaHandler interface.
+type MockKibanaHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockKibanaHandlerMockRecorder
+}
+
+// MockKibanaHandlerMockRecorder is the mock recorder for MockKibanaHandler.
+type MockKibanaHandlerMockRecorder struct {
+	mock *MockKibanaHandler
+}
+
+// NewMockKibanaHandler creates a new mock instance.
+func NewMockKibanaHandler(ctrl *gomock.Controller) *MockKibanaHandler {
+	mock := &MockKibanaHandler{ctrl: ctrl}
+	mock.recorder = &MockKibanaHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKibanaHandler) EXPECT() *MockKibanaHandlerMockRecorder {
+	return m.recorder
+}
+
+// AlertRuleCreate mocks base method.
+func (m *MockKibanaHandler) AlertRuleCreate(arg0 string, arg1 *kibanahandler.KibanaAlertRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlertRuleCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AlertRuleCreate indicates an expected call of AlertRuleCreate.
+func (mr *MockKibanaHandlerMockRecorder) AlertRuleCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlertRuleCreate", reflect.TypeOf((*MockKibanaHandler)(nil).AlertRuleCreate), arg0, arg1)
+}
+
+// AlertRuleDelete mocks base method.
+func (m *MockKibanaHandler) AlertRuleDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlertRuleDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AlertRuleDelete indicates an expected call of AlertRuleDelete.
+func (mr *MockKibanaHandlerMockRecorder) AlertRuleDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlertRuleDelete", reflect.TypeOf((*MockKibanaHandler)(nil).AlertRuleDelete), arg0, arg1)
+}
+
+// AlertRuleDiff mocks base method.
+func (m *MockKibanaHandler) AlertRuleDiff(arg0, arg1 *kibanahandler.KibanaAlertRule) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlertRuleDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AlertRuleDiff indicates an expected call of AlertRuleDiff.
+func (mr *MockKibanaHandlerMockRecorder) AlertRuleDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlertRuleDiff", reflect.TypeOf((*MockKibanaHandler)(nil).AlertRuleDiff), arg0, arg1)
+}
+
+// AlertRuleGet mocks base method.
+func (m *MockKibanaHandler) AlertRuleGet(arg0, arg1 string) (*kibanahandler.KibanaAlertRuleInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlertRuleGet", arg0, arg1)
+	ret0, _ := ret[0].(*kibanahandler.KibanaAlertRuleInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AlertRuleGet indicates an expected call of AlertRuleGet.
+func (mr *MockKibanaHandlerMockRecorder) AlertRuleGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlertRuleGet", reflect.TypeOf((*MockKibanaHandler)(nil).AlertRuleGet), arg0, arg1)
+}
+
+// AlertRuleUpdate mocks base method.
+func (m *MockKibanaHandler) AlertRuleUpdate(arg0 string, arg1 *kibanahandler.KibanaAlertRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AlertRuleUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AlertRuleUpdate indicates an expected call of AlertRuleUpdate.
+func (mr *MockKibanaHandlerMockRecorder) AlertRuleUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AlertRuleUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).AlertRuleUpdate), arg0, arg1)
+}
+
+// ConnectorCreate mocks base method.
+func (m *MockKibanaHandler) ConnectorCreate(arg0 string, arg1 *kibanahandler.KibanaConnector) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectorCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectorCreate indicates an expected call of ConnectorCreate.
+func (mr *MockKibanaHandlerMockRecorder) ConnectorCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectorCreate", reflect.TypeOf((*MockKibanaHandler)(nil).ConnectorCreate), arg0, arg1)
+}
+
+// ConnectorDelete mocks base method.
+func (m *MockKibanaHandler) ConnectorDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectorDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectorDelete indicates an expected call of ConnectorDelete.
+func (mr *MockKibanaHandlerMockRecorder) ConnectorDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectorDelete", reflect.TypeOf((*MockKibanaHandler)(nil).ConnectorDelete), arg0, arg1)
+}
+
+// ConnectorDiff mocks base method.
+func (m *MockKibanaHandler) ConnectorDiff(arg0, arg1 *kibanahandler.KibanaConnector) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectorDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConnectorDiff indicates an expected call of ConnectorDiff.
+func (mr *MockKibanaHandlerMockRecorder) ConnectorDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectorDiff", reflect.TypeOf((*MockKibanaHandler)(nil).ConnectorDiff), arg0, arg1)
+}
+
+// ConnectorGet mocks base method.
+func (m *MockKibanaHandler) ConnectorGet(arg0, arg1 string) (*kibanahandler.KibanaConnector, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectorGet", arg0, arg1)
+	ret0, _ := ret[0].(*kibanahandler.KibanaConnector)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConnectorGet indicates an expected call of ConnectorGet.
+func (mr *MockKibanaHandlerMockRecorder) ConnectorGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectorGet", reflect.TypeOf((*MockKibanaHandler)(nil).ConnectorGet), arg0, arg1)
+}
+
+// ConnectorUpdate mocks base method.
+func (m *MockKibanaHandler) ConnectorUpdate(arg0 string, arg1 *kibanahandler.KibanaConnector) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectorUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConnectorUpdate indicates an expected call of ConnectorUpdate.
+func (mr *MockKibanaHandlerMockRecorder) ConnectorUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectorUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).ConnectorUpdate), arg0, arg1)
+}
+
+// DataViewCreate mocks base method.
+func (m *MockKibanaHandler) DataViewCreate(arg0 string, arg1 *kibanahandler.KibanaDataView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataViewCreate indicates an expected call of DataViewCreate.
+func (mr *MockKibanaHandlerMockRecorder) DataViewCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewCreate", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewCreate), arg0, arg1)
+}
+
+// DataViewDelete mocks base method.
+func (m *MockKibanaHandler) DataViewDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataViewDelete indicates an expected call of DataViewDelete.
+func (mr *MockKibanaHandlerMockRecorder) DataViewDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewDelete", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewDelete), arg0, arg1)
+}
+
+// DataViewDiff mocks base method.
+func (m *MockKibanaHandler) DataViewDiff(arg0, arg1 *kibanahandler.KibanaDataView) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DataViewDiff indicates an expected call of DataViewDiff.
+func (mr *MockKibanaHandlerMockRecorder) DataViewDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewDiff", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewDiff), arg0, arg1)
+}
+
+// DataViewGet mocks base method.
+func (m *MockKibanaHandler) DataViewGet(arg0, arg1 string) (*kibanahandler.KibanaDataView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewGet", arg0, arg1)
+	ret0, _ := ret[0].(*kibanahandler.KibanaDataView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DataViewGet indicates an expected call of DataViewGet.
+func (mr *MockKibanaHandlerMockRecorder) DataViewGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewGet", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewGet), arg0, arg1)
+}
+
+// DataViewGetDefault mocks base method.
+func (m *MockKibanaHandler) DataViewGetDefault(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewGetDefault", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DataViewGetDefault indicates an expected call of DataViewGetDefault.
+func (mr *MockKibanaHandlerMockRecorder) DataViewGetDefault(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewGetDefault", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewGetDefault), arg0)
+}
+
+// DataViewSetDefault mocks base method.
+func (m *MockKibanaHandler) DataViewSetDefault(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewSetDefault", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataViewSetDefault indicates an expected call of DataViewSetDefault.
+func (mr *MockKibanaHandlerMockRecorder) DataViewSetDefault(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewSetDefault", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewSetDefault), arg0, arg1)
+}
+
+// DataViewUpdate mocks base method.
+func (m *MockKibanaHandler) DataViewUpdate(arg0 string, arg1 *kibanahandler.KibanaDataView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DataViewUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DataViewUpdate indicates an expected call of DataViewUpdate.
+func (mr *MockKibanaHandlerMockRecorder) DataViewUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DataViewUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).DataViewUpdate), arg0, arg1)
+}
+
+// FleetAgentPolicyCreate mocks base method.
+func (m *MockKibanaHandler) FleetAgentPolicyCreate(arg0 *kibanahandler.FleetAgentPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetAgentPolicyCreate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetAgentPolicyCreate indicates an expected call of FleetAgentPolicyCreate.
+func (mr *MockKibanaHandlerMockRecorder) FleetAgentPolicyCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetAgentPolicyCreate", reflect.TypeOf((*MockKibanaHandler)(nil).FleetAgentPolicyCreate), arg0)
+}
+
+// FleetAgentPolicyDelete mocks base method.
+func (m *MockKibanaHandler) FleetAgentPolicyDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetAgentPolicyDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetAgentPolicyDelete indicates an expected call of FleetAgentPolicyDelete.
+func (mr *MockKibanaHandlerMockRecorder) FleetAgentPolicyDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetAgentPolicyDelete", reflect.TypeOf((*MockKibanaHandler)(nil).FleetAgentPolicyDelete), arg0)
+}
+
+// FleetAgentPolicyDiff mocks base method.
+func (m *MockKibanaHandler) FleetAgentPolicyDiff(arg0, arg1 *kibanahandler.FleetAgentPolicy) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetAgentPolicyDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FleetAgentPolicyDiff indicates an expected call of FleetAgentPolicyDiff.
+func (mr *MockKibanaHandlerMockRecorder) FleetAgentPolicyDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetAgentPolicyDiff", reflect.TypeOf((*MockKibanaHandler)(nil).FleetAgentPolicyDiff), arg0, arg1)
+}
+
+// FleetAgentPolicyGet mocks base method.
+func (m *MockKibanaHandler) FleetAgentPolicyGet(arg0 string) (*kibanahandler.FleetAgentPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetAgentPolicyGet", arg0)
+	ret0, _ := ret[0].(*kibanahandler.FleetAgentPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FleetAgentPolicyGet indicates an expected call of FleetAgentPolicyGet.
+func (mr *MockKibanaHandlerMockRecorder) FleetAgentPolicyGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetAgentPolicyGet", reflect.TypeOf((*MockKibanaHandler)(nil).FleetAgentPolicyGet), arg0)
+}
+
+// FleetAgentPolicyUpdate mocks base method.
+func (m *MockKibanaHandler) FleetAgentPolicyUpdate(arg0 *kibanahandler.FleetAgentPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetAgentPolicyUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetAgentPolicyUpdate indicates an expected call of FleetAgentPolicyUpdate.
+func (mr *MockKibanaHandlerMockRecorder) FleetAgentPolicyUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetAgentPolicyUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).FleetAgentPolicyUpdate), arg0)
+}
+
+// FleetEnrollmentTokenGet mocks base method.
+func (m *MockKibanaHandler) FleetEnrollmentTokenGet(arg0 string) (*kibanahandler.FleetEnrollmentAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetEnrollmentTokenGet", arg0)
+	ret0, _ := ret[0].(*kibanahandler.FleetEnrollmentAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FleetEnrollmentTokenGet indicates an expected call of FleetEnrollmentTokenGet.
+func (mr *MockKibanaHandlerMockRecorder) FleetEnrollmentTokenGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetEnrollmentTokenGet", reflect.TypeOf((*MockKibanaHandler)(nil).FleetEnrollmentTokenGet), arg0)
+}
+
+// FleetPackagePolicyCreate mocks base method.
+func (m *MockKibanaHandler) FleetPackagePolicyCreate(arg0 *kibanahandler.FleetPackagePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetPackagePolicyCreate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetPackagePolicyCreate indicates an expected call of FleetPackagePolicyCreate.
+func (mr *MockKibanaHandlerMockRecorder) FleetPackagePolicyCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetPackagePolicyCreate", reflect.TypeOf((*MockKibanaHandler)(nil).FleetPackagePolicyCreate), arg0)
+}
+
+// FleetPackagePolicyDelete mocks base method.
+func (m *MockKibanaHandler) FleetPackagePolicyDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetPackagePolicyDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetPackagePolicyDelete indicates an expected call of FleetPackagePolicyDelete.
+func (mr *MockKibanaHandlerMockRecorder) FleetPackagePolicyDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetPackagePolicyDelete", reflect.TypeOf((*MockKibanaHandler)(nil).FleetPackagePolicyDelete), arg0)
+}
+
+// FleetPackagePolicyDiff mocks base method.
+func (m *MockKibanaHandler) FleetPackagePolicyDiff(arg0, arg1 *kibanahandler.FleetPackagePolicy) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetPackagePolicyDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FleetPackagePolicyDiff indicates an expected call of FleetPackagePolicyDiff.
+func (mr *MockKibanaHandlerMockRecorder) FleetPackagePolicyDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetPackagePolicyDiff", reflect.TypeOf((*MockKibanaHandler)(nil).FleetPackagePolicyDiff), arg0, arg1)
+}
+
+// FleetPackagePolicyGet mocks base method.
+func (m *MockKibanaHandler) FleetPackagePolicyGet(arg0 string) (*kibanahandler.FleetPackagePolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetPackagePolicyGet", arg0)
+	ret0, _ := ret[0].(*kibanahandler.FleetPackagePolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FleetPackagePolicyGet indicates an expected call of FleetPackagePolicyGet.
+func (mr *MockKibanaHandlerMockRecorder) FleetPackagePolicyGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetPackagePolicyGet", reflect.TypeOf((*MockKibanaHandler)(nil).FleetPackagePolicyGet), arg0)
+}
+
+// FleetPackagePolicyUpdate mocks base method.
+func (m *MockKibanaHandler) FleetPackagePolicyUpdate(arg0 *kibanahandler.FleetPackagePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FleetPackagePolicyUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FleetPackagePolicyUpdate indicates an expected call of FleetPackagePolicyUpdate.
+func (mr *MockKibanaHandlerMockRecorder) FleetPackagePolicyUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FleetPackagePolicyUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).FleetPackagePolicyUpdate), arg0)
+}
+
+// RoleDelete mocks base method.
+func (m *MockKibanaHandler) RoleDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleDelete indicates an expected call of RoleDelete.
+func (mr *MockKibanaHandlerMockRecorder) RoleDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleDelete", reflect.TypeOf((*MockKibanaHandler)(nil).RoleDelete), arg0)
+}
+
+// RoleDiff mocks base method.
+func (m *MockKibanaHandler) RoleDiff(arg0, arg1 *kibanahandler.KibanaRole) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoleDiff indicates an expected call of RoleDiff.
+func (mr *MockKibanaHandlerMockRecorder) RoleDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleDiff", reflect.TypeOf((*MockKibanaHandler)(nil).RoleDiff), arg0, arg1)
+}
+
+// RoleGet mocks base method.
+func (m *MockKibanaHandler) RoleGet(arg0 string) (*kibanahandler.KibanaRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleGet", arg0)
+	ret0, _ := ret[0].(*kibanahandler.KibanaRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RoleGet indicates an expected call of RoleGet.
+func (mr *MockKibanaHandlerMockRecorder) RoleGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleGet", reflect.TypeOf((*MockKibanaHandler)(nil).RoleGet), arg0)
+}
+
+// RoleUpdate mocks base method.
+func (m *MockKibanaHandler) RoleUpdate(arg0 string, arg1 *kibanahandler.KibanaRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RoleUpdate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RoleUpdate indicates an expected call of RoleUpdate.
+func (mr *MockKibanaHandlerMockRecorder) RoleUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RoleUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).RoleUpdate), arg0, arg1)
+}
+
+// SavedObjectDelete mocks base method.
+func (m *MockKibanaHandler) SavedObjectDelete(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavedObjectDelete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavedObjectDelete indicates an expected call of SavedObjectDelete.
+func (mr *MockKibanaHandlerMockRecorder) SavedObjectDelete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavedObjectDelete", reflect.TypeOf((*MockKibanaHandler)(nil).SavedObjectDelete), arg0, arg1, arg2)
+}
+
+// SavedObjectsImport mocks base method.
+func (m *MockKibanaHandler) SavedObjectsImport(arg0 string, arg1 []byte) (*kibanahandler.SavedObjectsImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavedObjectsImport", arg0, arg1)
+	ret0, _ := ret[0].(*kibanahandler.SavedObjectsImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SavedObjectsImport indicates an expected call of SavedObjectsImport.
+func (mr *MockKibanaHandlerMockRecorder) SavedObjectsImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavedObjectsImport", reflect.TypeOf((*MockKibanaHandler)(nil).SavedObjectsImport), arg0, arg1)
+}
+
+// SetLogger mocks base method.
+func (m *MockKibanaHandler) SetLogger(arg0 *logrus.Entry) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogger", arg0)
+}
+
+// SetLogger indicates an expected call of SetLogger.
+func (mr *MockKibanaHandlerMockRecorder) SetLogger(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogger", reflect.TypeOf((*MockKibanaHandler)(nil).SetLogger), arg0)
+}
+
+// SpaceCreate mocks base method.
+func (m *MockKibanaHandler) SpaceCreate(arg0 *kibanahandler.KibanaSpace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceCreate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SpaceCreate indicates an expected call of SpaceCreate.
+func (mr *MockKibanaHandlerMockRecorder) SpaceCreate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceCreate", reflect.TypeOf((*MockKibanaHandler)(nil).SpaceCreate), arg0)
+}
+
+// SpaceDelete mocks base method.
+func (m *MockKibanaHandler) SpaceDelete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceDelete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SpaceDelete indicates an expected call of SpaceDelete.
+func (mr *MockKibanaHandlerMockRecorder) SpaceDelete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceDelete", reflect.TypeOf((*MockKibanaHandler)(nil).SpaceDelete), arg0)
+}
+
+// SpaceDiff mocks base method.
+func (m *MockKibanaHandler) SpaceDiff(arg0, arg1 *kibanahandler.KibanaSpace) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceDiff", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpaceDiff indicates an expected call of SpaceDiff.
+func (mr *MockKibanaHandlerMockRecorder) SpaceDiff(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceDiff", reflect.TypeOf((*MockKibanaHandler)(nil).SpaceDiff), arg0, arg1)
+}
+
+// SpaceGet mocks base method.
+func (m *MockKibanaHandler) SpaceGet(arg0 string) (*kibanahandler.KibanaSpace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceGet", arg0)
+	ret0, _ := ret[0].(*kibanahandler.KibanaSpace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpaceGet indicates an expected call of SpaceGet.
+func (mr *MockKibanaHandlerMockRecorder) SpaceGet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceGet", reflect.TypeOf((*MockKibanaHandler)(nil).SpaceGet), arg0)
+}
+
+// SpaceUpdate mocks base method.
+func (m *MockKibanaHandler) SpaceUpdate(arg0 *kibanahandler.KibanaSpace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpaceUpdate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SpaceUpdate indicates an expected call of SpaceUpdate.
+func (mr *MockKibanaHandlerMockRecorder) SpaceUpdate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpaceUpdate", reflect.TypeOf((*MockKibanaHandler)(nil).SpaceUpdate), arg0)
+}